@@ -11,12 +11,18 @@ import (
 	"time"
 
 	"github.com/jokeoa/goigaming/internal/config"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 	handler "github.com/jokeoa/goigaming/internal/handler/http"
 	wsHandler "github.com/jokeoa/goigaming/internal/handler/ws"
+	"github.com/jokeoa/goigaming/internal/repository/mail"
 	"github.com/jokeoa/goigaming/internal/repository/postgres"
+	"github.com/jokeoa/goigaming/internal/repository/storage"
 	"github.com/jokeoa/goigaming/internal/service/game"
+	kycService "github.com/jokeoa/goigaming/internal/service/kyc"
+	notificationService "github.com/jokeoa/goigaming/internal/service/notification"
 	rouletteService "github.com/jokeoa/goigaming/internal/service/roulette"
+	tournamentService "github.com/jokeoa/goigaming/internal/service/tournament"
 	"github.com/jokeoa/goigaming/repository"
 	authService "github.com/jokeoa/goigaming/internal/service/auth"
 	userService "github.com/jokeoa/goigaming/internal/service/user"
@@ -39,29 +45,77 @@ func main() {
 	defer pool.Close()
 
 	userRepo := postgres.NewUserRepository(pool)
+	emailVerificationRepo := postgres.NewEmailVerificationRepository(pool)
+	sessionRepo := postgres.NewSessionRepository(pool)
+	apiKeyRepo := postgres.NewAPIKeyRepository(pool)
+	kycRepo := postgres.NewKYCRepository(pool)
+	notificationRepo := postgres.NewNotificationRepository(pool)
 	walletRepo := postgres.NewWalletRepository(pool)
 	txRepo := postgres.NewTransactionRepository(pool)
 	pokerTableRepo := postgres.NewPokerTableRepository(pool)
 	pokerPlayerRepo := postgres.NewPokerPlayerRepository(pool)
 	pokerHandRepo := postgres.NewPokerHandRepository(pool)
+	tournamentRepo := postgres.NewTournamentRepository(pool)
+	rakeRepo := postgres.NewRakeRepository(pool)
 	rouletteTableRepo := repository.NewRouletteTableRepository(pool)
 	rouletteTableRepoNew := postgres.NewRouletteTableRepo(pool)
 	rouletteRoundRepo := postgres.NewRouletteRoundRepo(pool)
 	rouletteBetRepo := postgres.NewRouletteBetRepo(pool)
 
+	wsHub := wsHandler.NewHub(slog.Default())
+
 	authSvc := authService.NewService(
 		pool,
 		userRepo,
+		sessionRepo,
+		apiKeyRepo,
 		func(db postgres.DBTX) ports.UserRepository {
 			return postgres.NewUserRepository(db)
 		},
 		func(db postgres.DBTX) ports.WalletRepository {
 			return postgres.NewWalletRepository(db)
 		},
+		func(db postgres.DBTX) ports.SessionRepository {
+			return postgres.NewSessionRepository(db)
+		},
+		wsHub,
 		cfg.JWTSecret,
 		cfg.JWTTokenTTL,
 	)
-	userSvc := userService.NewService(userRepo)
+	fileStorage, err := storage.NewLocalStorage(cfg.StorageDir, cfg.StorageURL)
+	if err != nil {
+		log.Fatalf("failed to init file storage: %v", err)
+	}
+
+	kycStorage, err := storage.NewLocalStorage(cfg.KYCStorageDir, "kyc")
+	if err != nil {
+		log.Fatalf("failed to init kyc storage: %v", err)
+	}
+
+	kycSvc := kycService.NewService(
+		pool,
+		userRepo,
+		kycRepo,
+		func(db postgres.DBTX) ports.UserRepository {
+			return postgres.NewUserRepository(db)
+		},
+		func(db postgres.DBTX) ports.KYCRepository {
+			return postgres.NewKYCRepository(db)
+		},
+		func(db postgres.DBTX) ports.NotificationRepository {
+			return postgres.NewNotificationRepository(db)
+		},
+		func(db postgres.DBTX) ports.AuditLogRepository {
+			return postgres.NewAuditLogRepository(db)
+		},
+		kycStorage,
+		domain.KYCLimits{
+			Unverified: cfg.KYCUnverifiedWithdrawMax,
+			Basic:      cfg.KYCBasicWithdrawMax,
+		},
+	)
+	notificationSvc := notificationService.NewService(notificationRepo)
+
 	walletSvc := walletService.NewService(
 		pool,
 		walletRepo,
@@ -72,20 +126,48 @@ func main() {
 		func(db postgres.DBTX) ports.TransactionRepository {
 			return postgres.NewTransactionRepository(db)
 		},
+		kycSvc,
 	)
 
-	wsHub := wsHandler.NewHub(slog.Default())
 	rngSvc := &game.SimpleRNGService{}
 	hubManager := game.NewHubManager(
 		ctx,
-		30*time.Second,
+		game.TurnClock{
+			TurnTimeout:         cfg.TurnTimeout,
+			TimeBank:            cfg.TimeBank,
+			TimeBankRefill:      cfg.TimeBankRefill,
+			TimeBankRefillHands: cfg.TimeBankRefillHands,
+			SitOutAfterTimeouts: cfg.SitOutAfterTimeouts,
+		},
 		wsHub,
 		walletSvc,
 		rngSvc,
 		pokerHandRepo,
 		pokerPlayerRepo,
+		rakeRepo,
 		slog.Default(),
 	)
+	userSvc := userService.NewService(
+		pool,
+		userRepo,
+		emailVerificationRepo,
+		func(db postgres.DBTX) ports.UserRepository {
+			return postgres.NewUserRepository(db)
+		},
+		func(db postgres.DBTX) ports.EmailVerificationRepository {
+			return postgres.NewEmailVerificationRepository(db)
+		},
+		func(db postgres.DBTX) ports.PokerPlayerRepository {
+			return postgres.NewPokerPlayerRepository(db)
+		},
+		func(db postgres.DBTX) ports.SessionRepository {
+			return postgres.NewSessionRepository(db)
+		},
+		fileStorage,
+		mail.NewLogMailer(slog.Default()),
+		hubManager,
+		wsHub,
+	)
 	pokerSvc := game.NewService(
 		pool,
 		pokerTableRepo,
@@ -99,6 +181,16 @@ func main() {
 		},
 	)
 
+	tournamentSvc := tournamentService.NewService(
+		tournamentRepo,
+		pokerTableRepo,
+		pokerSvc,
+		walletSvc,
+		userSvc,
+		hubManager,
+	)
+	go tournamentSvc.Run(ctx)
+
 	rouletteSvc := rouletteService.NewService(
 		pool,
 		walletSvc,
@@ -111,14 +203,17 @@ func main() {
 	)
 
 	authHandler := handler.NewAuthHandler(authSvc)
-	userHandler := handler.NewUserHandler(userSvc)
+	userHandler := handler.NewUserHandler(userSvc, authSvc)
 	walletHandler := handler.NewWalletHandler(walletSvc)
-	adminHandler := handler.NewAdminHandler(pokerTableRepo, rouletteTableRepo)
-	pokerHandler := handler.NewPokerHandler(pokerSvc)
+	adminHandler := handler.NewAdminHandler(pokerTableRepo, rouletteTableRepo, rakeRepo)
+	pokerHandler := handler.NewPokerHandler(pokerSvc, game.NewEquityService())
 	rouletteHandler := handler.NewRouletteHandler(rouletteSvc)
-	ws := wsHandler.NewHandler(wsHub, authSvc, slog.Default())
+	kycHandler := handler.NewKYCHandler(kycSvc)
+	notificationHandler := handler.NewNotificationHandler(notificationSvc)
+	tournamentHandler := handler.NewTournamentHandler(tournamentSvc)
+	ws := wsHandler.NewHandler(wsHub, authSvc, pokerSvc, slog.Default())
 
-	router := handler.NewRouter(authSvc, authHandler, userHandler, walletHandler, adminHandler, pokerHandler, rouletteHandler, ws)
+	router := handler.NewRouter(authSvc, authHandler, userHandler, walletHandler, adminHandler, pokerHandler, rouletteHandler, kycHandler, notificationHandler, tournamentHandler, ws, cfg.StorageDir)
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.ServerPort,
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/shopspring/decimal"
 )
 
 type Config struct {
@@ -14,6 +15,22 @@ type Config struct {
 	JWTTokenTTL time.Duration `env:"JWT_TOKEN_TTL" envDefault:"24h"`
 	RedisURL    string        `env:"REDIS_URL"`
 	TurnTimeout time.Duration `env:"TURN_TIMEOUT" envDefault:"30s"`
+	StorageDir  string        `env:"STORAGE_DIR" envDefault:"uploads"`
+	StorageURL  string        `env:"STORAGE_URL" envDefault:"/api/v1/uploads"`
+
+	// Each player's time bank starts full and regains TimeBankRefill every
+	// TimeBankRefillHands hands, up to the starting amount.
+	TimeBank            time.Duration `env:"TIME_BANK" envDefault:"60s"`
+	TimeBankRefill      time.Duration `env:"TIME_BANK_REFILL" envDefault:"10s"`
+	TimeBankRefillHands int           `env:"TIME_BANK_REFILL_HANDS" envDefault:"10"`
+	// Consecutive turn timeouts after which a player is sat out; 0 disables.
+	SitOutAfterTimeouts int `env:"SIT_OUT_AFTER_TIMEOUTS" envDefault:"2"`
+
+	// KYC documents are kept apart from public uploads and only served
+	// through the admin API.
+	KYCStorageDir            string          `env:"KYC_STORAGE_DIR" envDefault:"kyc_documents"`
+	KYCUnverifiedWithdrawMax decimal.Decimal `env:"KYC_UNVERIFIED_WITHDRAW_MAX" envDefault:"100"`
+	KYCBasicWithdrawMax      decimal.Decimal `env:"KYC_BASIC_WITHDRAW_MAX" envDefault:"1000"`
 }
 
 func Load() (Config, error) {
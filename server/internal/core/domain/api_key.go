@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix starts every API key, which lets Authenticate tell keys
+// apart from JWTs.
+const APIKeyPrefix = "gik_"
+
+type APIKeyScope string
+
+const (
+	ScopeRead   APIKeyScope = "read"
+	ScopeWallet APIKeyScope = "wallet"
+	ScopePlay   APIKeyScope = "play"
+)
+
+func (s APIKeyScope) IsValid() bool {
+	return s == ScopeRead || s == ScopeWallet || s == ScopePlay
+}
+
+// APIKey is a long-lived credential for bots and integrations. Only the
+// hash of the key is stored; Prefix is kept so users can recognise it.
+type APIKey struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Name       string        `json:"name"`
+	Prefix     string        `json:"prefix"`
+	KeyHash    string        `json:"-"`
+	Scopes     []APIKeyScope `json:"scopes"`
+	ExpiresAt  *time.Time    `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+func (k APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// CreatedAPIKey is returned once on creation; Key is never shown again.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyUsage struct {
+	ID        uuid.UUID `json:"id"`
+	APIKeyID  uuid.UUID `json:"api_key_id"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	IPAddress string    `json:"ip_address"`
+	Status    int       `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NewAPIKey struct {
+	Name      string
+	Scopes    []APIKeyScope
+	ExpiresAt *time.Time
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type TokenPair struct {
 	AccessToken string `json:"access_token"`
@@ -12,4 +17,27 @@ type TokenClaims struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Username string    `json:"username"`
 	IsAdmin  bool      `json:"is_admin"`
+
+	SessionID uuid.UUID     `json:"-"`
+	APIKeyID  uuid.UUID     `json:"-"`
+	Scopes    []APIKeyScope `json:"-"`
+	ExpiresAt time.Time     `json:"-"`
+}
+
+func (c TokenClaims) IsAPIKey() bool {
+	return c.APIKeyID != uuid.Nil
+}
+
+// HasScope reports whether the credential may act within scope. Session
+// tokens are not scoped.
+func (c TokenClaims) HasScope(scope APIKeyScope) bool {
+	return !c.IsAPIKey() || slices.Contains(c.Scopes, scope)
+}
+
+// CredentialID identifies the session or API key the claims came from.
+func (c TokenClaims) CredentialID() uuid.UUID {
+	if c.IsAPIKey() {
+		return c.APIKeyID
+	}
+	return c.SessionID
 }
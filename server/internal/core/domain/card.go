@@ -44,6 +44,16 @@ type Card struct {
 	Suit Suit `json:"suit"`
 }
 
+// Value orders ranks from two (2) up to ace (14).
+func (r Rank) Value() int {
+	for i, rank := range allRanks {
+		if r == rank {
+			return i + 2
+		}
+	}
+	return 0
+}
+
 func (c Card) String() string {
 	return string(c.Rank) + string(c.Suit)
 }
@@ -103,6 +113,20 @@ func FullDeck() []Card {
 	return deck
 }
 
+// ShortDeck is the 36-card deck of six-plus hold'em, with the deuces
+// through fives removed.
+func ShortDeck() []Card {
+	deck := make([]Card, 0, 36)
+	for _, c := range FullDeck() {
+		switch c.Rank {
+		case RankTwo, RankThree, RankFour, RankFive:
+			continue
+		}
+		deck = append(deck, c)
+	}
+	return deck
+}
+
 func isValidRank(r Rank) bool {
 	for _, rank := range allRanks {
 		if r == rank {
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrorCode is the stable, machine-readable identifier clients receive
+// next to the human-readable message.
+type ErrorCode string
+
+const (
+	CodeInternal         ErrorCode = "internal_error"
+	CodeValidationFailed ErrorCode = "validation_failed"
+	CodeUnauthorized     ErrorCode = "unauthorized"
+	CodeForbidden        ErrorCode = "forbidden"
+	CodeRateLimited      ErrorCode = "rate_limited"
+)
+
+// ErrorInfo is how an error is presented to clients over HTTP and
+// WebSocket.
+type ErrorInfo struct {
+	Status  int               `json:"-"`
+	Code    ErrorCode         `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+type registeredError struct {
+	err  error
+	info ErrorInfo
+}
+
+var (
+	registryMu    sync.RWMutex
+	errorRegistry = []registeredError{
+		{ErrValidation, ErrorInfo{http.StatusBadRequest, CodeValidationFailed, "invalid request", nil}},
+		{ErrUnauthorized, ErrorInfo{http.StatusUnauthorized, CodeUnauthorized, "unauthorized", nil}},
+		{ErrForbidden, ErrorInfo{http.StatusForbidden, CodeForbidden, "forbidden", nil}},
+
+		{ErrUserNotFound, ErrorInfo{http.StatusNotFound, "user_not_found", "user not found", nil}},
+		{ErrUserAlreadyExists, ErrorInfo{http.StatusConflict, "user_already_exists", "user already exists", nil}},
+		{ErrInvalidCredentials, ErrorInfo{http.StatusUnauthorized, "invalid_credentials", "invalid credentials", nil}},
+		{ErrInvalidToken, ErrorInfo{http.StatusUnauthorized, "invalid_token", "invalid or expired token", nil}},
+		{ErrWalletNotFound, ErrorInfo{http.StatusNotFound, "wallet_not_found", "wallet not found", nil}},
+		{ErrInsufficientFunds, ErrorInfo{http.StatusUnprocessableEntity, "insufficient_funds", "insufficient funds", nil}},
+		{ErrOptimisticLock, ErrorInfo{http.StatusConflict, "concurrent_update", "the resource was modified concurrently, retry the request", nil}},
+		{ErrInvalidAmount, ErrorInfo{http.StatusBadRequest, "invalid_amount", "amount must be greater than zero", nil}},
+
+		{ErrUsernameChangeTooSoon, ErrorInfo{http.StatusTooManyRequests, "username_change_too_soon", "username can only be changed once every 30 days", nil}},
+		{ErrInvalidVerificationToken, ErrorInfo{http.StatusBadRequest, "invalid_verification_token", "invalid or expired verification token", nil}},
+		{ErrInvalidAvatar, ErrorInfo{http.StatusBadRequest, "invalid_avatar", "avatar must be a png, jpeg, gif or webp image", nil}},
+		{ErrAvatarTooLarge, ErrorInfo{http.StatusRequestEntityTooLarge, "avatar_too_large", "avatar must be at most 2 MB", nil}},
+		{ErrSessionNotFound, ErrorInfo{http.StatusNotFound, "session_not_found", "session not found", nil}},
+		{ErrAPIKeyNotFound, ErrorInfo{http.StatusNotFound, "api_key_not_found", "api key not found", nil}},
+		{ErrInvalidAPIKeyScope, ErrorInfo{http.StatusBadRequest, "invalid_api_key_scope", "scopes must be read, wallet or play", nil}},
+		{ErrInvalidAPIKeyExpiry, ErrorInfo{http.StatusBadRequest, "invalid_api_key_expiry", "expires_at must be in the future", nil}},
+		{ErrAPIKeyLimitReached, ErrorInfo{http.StatusConflict, "api_key_limit_reached", "api key limit reached", nil}},
+
+		{ErrKYCRequestNotFound, ErrorInfo{http.StatusNotFound, "kyc_request_not_found", "kyc request not found", nil}},
+		{ErrKYCDocumentNotFound, ErrorInfo{http.StatusNotFound, "kyc_document_not_found", "kyc document not found", nil}},
+		{ErrKYCRequestPending, ErrorInfo{http.StatusConflict, "kyc_request_pending", "a kyc request is already awaiting review", nil}},
+		{ErrKYCRequestClosed, ErrorInfo{http.StatusConflict, "kyc_request_closed", "kyc request is not awaiting review", nil}},
+		{ErrInvalidKYCLevel, ErrorInfo{http.StatusBadRequest, "invalid_kyc_level", "invalid kyc level", nil}},
+		{ErrInvalidKYCDocument, ErrorInfo{http.StatusBadRequest, "invalid_kyc_document", "kyc document must be a png, jpeg or pdf file", nil}},
+		{ErrKYCDocumentTooLarge, ErrorInfo{http.StatusRequestEntityTooLarge, "kyc_document_too_large", "kyc document must be at most 10 MB", nil}},
+		{ErrKYCDocumentsMissing, ErrorInfo{http.StatusBadRequest, "kyc_documents_missing", "missing required kyc documents", nil}},
+		{ErrKYCLevelRequired, ErrorInfo{http.StatusForbidden, "kyc_level_required", "withdrawal amount requires a higher verification level", nil}},
+		{ErrInvalidKYCDecision, ErrorInfo{http.StatusBadRequest, "invalid_kyc_decision", "decision must be approve, reject or request_info", nil}},
+		{ErrKYCReviewNoteRequired, ErrorInfo{http.StatusBadRequest, "kyc_review_note_required", "note is required when requesting more information", nil}},
+		{ErrNotificationNotFound, ErrorInfo{http.StatusNotFound, "notification_not_found", "notification not found", nil}},
+
+		{ErrTableNotFound, ErrorInfo{http.StatusNotFound, "table_not_found", "table not found", nil}},
+		{ErrTableFull, ErrorInfo{http.StatusConflict, "table_full", "table is full", nil}},
+		{ErrPlayerNotFound, ErrorInfo{http.StatusNotFound, "player_not_found", "player not found", nil}},
+		{ErrPlayerAlreadySeated, ErrorInfo{http.StatusConflict, "player_already_seated", "player already seated at this table", nil}},
+		{ErrHandNotFound, ErrorInfo{http.StatusNotFound, "hand_not_found", "hand not found", nil}},
+		{ErrNotPlayerTurn, ErrorInfo{http.StatusBadRequest, "not_player_turn", "not your turn", nil}},
+		{ErrInvalidAction, ErrorInfo{http.StatusBadRequest, "invalid_action", "invalid action", nil}},
+		{ErrInvalidBetAmount, ErrorInfo{http.StatusBadRequest, "invalid_bet_amount", "invalid bet amount", nil}},
+		{ErrInsufficientStack, ErrorInfo{http.StatusBadRequest, "insufficient_stack", "insufficient stack", nil}},
+		{ErrGameNotStarted, ErrorInfo{http.StatusBadRequest, "game_not_started", "game not started", nil}},
+		{ErrGameAlreadyStarted, ErrorInfo{http.StatusConflict, "game_already_started", "game already started", nil}},
+		{ErrInvalidBuyIn, ErrorInfo{http.StatusBadRequest, "invalid_buy_in", "invalid buy-in amount", nil}},
+		{ErrSeatTaken, ErrorInfo{http.StatusConflict, "seat_taken", "seat is taken", nil}},
+		{ErrMinPlayersRequired, ErrorInfo{http.StatusBadRequest, "min_players_required", "minimum 2 players required", nil}},
+		{ErrInvalidMaxPlayers, ErrorInfo{http.StatusBadRequest, "invalid_max_players", "max players must be between 2 and 9", nil}},
+		{ErrAlreadySittingOut, ErrorInfo{http.StatusConflict, "already_sitting_out", "player is already sitting out", nil}},
+		{ErrNotSittingOut, ErrorInfo{http.StatusConflict, "not_sitting_out", "player is not sitting out", nil}},
+		{ErrInvalidGameType, ErrorInfo{http.StatusBadRequest, "invalid_game_type", "invalid game type", nil}},
+		{ErrInvalidRake, ErrorInfo{http.StatusBadRequest, "invalid_rake", "rake must be between 0 and 100 percent with non-negative caps for 2 or more players", nil}},
+		{ErrInvalidPreAction, ErrorInfo{http.StatusBadRequest, "invalid_pre_action", "pre-action is not possible in this spot", nil}},
+		{ErrNoRunItTwiceOffer, ErrorInfo{http.StatusConflict, "no_run_it_twice_offer", "there is no run it twice offer to answer", nil}},
+		{ErrNoCardsToShow, ErrorInfo{http.StatusConflict, "no_cards_to_show", "you have no cards from the last hand to show", nil}},
+		{ErrNoCardsToMuck, ErrorInfo{http.StatusConflict, "no_cards_to_muck", "you have no cards waiting to be shown or mucked", nil}},
+		{ErrTournamentNotFound, ErrorInfo{http.StatusNotFound, "tournament_not_found", "tournament not found", nil}},
+		{ErrTournamentStarted, ErrorInfo{http.StatusConflict, "tournament_started", "tournament has already started", nil}},
+		{ErrAlreadyRegistered, ErrorInfo{http.StatusConflict, "already_registered", "already registered for this tournament", nil}},
+		{ErrNotRegistered, ErrorInfo{http.StatusNotFound, "not_registered", "not registered for this tournament", nil}},
+		{ErrTournamentTable, ErrorInfo{http.StatusConflict, "tournament_table", "not allowed at a tournament table", nil}},
+		{ErrInvalidBlindSchedule, ErrorInfo{http.StatusBadRequest, "invalid_blind_schedule", "blind levels must have positive blinds and a positive duration", nil}},
+		{ErrRegistrationClosed, ErrorInfo{http.StatusConflict, "registration_closed", "tournament registration is closed", nil}},
+		{ErrReEntryLimit, ErrorInfo{http.StatusConflict, "re_entry_limit", "re-entry limit reached", nil}},
+		{ErrInvalidSchedule, ErrorInfo{http.StatusBadRequest, "invalid_schedule", "scheduled tournaments need a future start time, a table size between 2 and 9, and valid late registration and break settings", nil}},
+		{ErrInvalidPayouts, ErrorInfo{http.StatusBadRequest, "invalid_payouts", "payouts must be positive percentages adding up to 100 for at most max_players places", nil}},
+
+		{ErrRoundNotFound, ErrorInfo{http.StatusNotFound, "round_not_found", "round not found", nil}},
+		{ErrBettingClosed, ErrorInfo{http.StatusUnprocessableEntity, "betting_closed", "betting is closed", nil}},
+		{ErrInvalidBetType, ErrorInfo{http.StatusBadRequest, "invalid_bet_type", "invalid bet type", nil}},
+		{ErrBetAmountOutOfRange, ErrorInfo{http.StatusBadRequest, "bet_amount_out_of_range", "bet amount out of range", nil}},
+		{ErrTableNotActive, ErrorInfo{http.StatusBadRequest, "table_not_active", "table is not active", nil}},
+	}
+)
+
+// RegisterError declares how err is presented to clients. Errors that
+// wrap err inherit its status, code and message.
+func RegisterError(err error, status int, code ErrorCode, message string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	errorRegistry = append(errorRegistry, registeredError{err, ErrorInfo{status, code, message, nil}})
+}
+
+// DescribeError finds the registered entry for err. Unknown errors are
+// reported as internal errors so their text never leaks to clients.
+func DescribeError(err error) ErrorInfo {
+	var info ErrorInfo
+	found := false
+
+	registryMu.RLock()
+	for _, r := range errorRegistry {
+		if errors.Is(err, r.err) {
+			info = r.info
+			found = true
+			break
+		}
+	}
+	registryMu.RUnlock()
+
+	if !found {
+		return ErrorInfo{http.StatusInternalServerError, CodeInternal, "internal server error", nil}
+	}
+
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		if ve.Message != "" {
+			info.Message = ve.Message
+		}
+		info.Details = ve.Fields
+	}
+
+	return info
+}
+
+// ErrorBody is the envelope every failed request is answered with, by
+// HTTP handlers and middleware and by the WebSocket upgrade alike.
+type ErrorBody struct {
+	Success bool              `json:"success"`
+	Error   string            `json:"error"`
+	Code    ErrorCode         `json:"code"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// NewErrorBody wraps info in the error envelope.
+func NewErrorBody(info ErrorInfo) ErrorBody {
+	return ErrorBody{Error: info.Message, Code: info.Code, Details: info.Details}
+}
+
+// ValidationError reports invalid input, field by field. It matches
+// ErrValidation with errors.Is.
+type ValidationError struct {
+	Message string
+	Fields  map[string]string
+}
+
+func NewValidationError(field, message string) *ValidationError {
+	return &ValidationError{
+		Message: message,
+		Fields:  map[string]string{field: message},
+	}
+}
+
+func (e *ValidationError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	fields := make([]string, 0, len(e.Fields))
+	for f, reason := range e.Fields {
+		fields = append(fields, f+": "+reason)
+	}
+	sort.Strings(fields)
+	return "invalid request: " + strings.Join(fields, ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
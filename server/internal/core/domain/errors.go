@@ -12,6 +12,31 @@ var (
 	ErrOptimisticLock    = errors.New("optimistic lock conflict")
 	ErrInvalidAmount     = errors.New("amount must be greater than zero")
 	ErrForbidden         = errors.New("forbidden")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrValidation        = errors.New("validation failed")
+
+	ErrUsernameChangeTooSoon    = errors.New("username was changed too recently")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrInvalidAvatar            = errors.New("avatar must be a png, jpeg, gif or webp image")
+	ErrAvatarTooLarge           = errors.New("avatar is too large")
+	ErrSessionNotFound          = errors.New("session not found")
+	ErrAPIKeyNotFound           = errors.New("api key not found")
+	ErrInvalidAPIKeyScope       = errors.New("invalid api key scope")
+	ErrAPIKeyLimitReached       = errors.New("api key limit reached")
+	ErrInvalidAPIKeyExpiry      = errors.New("api key expiry must be in the future")
+
+	ErrKYCRequestNotFound    = errors.New("kyc request not found")
+	ErrKYCDocumentNotFound   = errors.New("kyc document not found")
+	ErrKYCRequestPending     = errors.New("kyc request is already awaiting review")
+	ErrKYCRequestClosed      = errors.New("kyc request is not awaiting review")
+	ErrInvalidKYCLevel       = errors.New("invalid kyc level")
+	ErrInvalidKYCDocument    = errors.New("kyc document must be a png, jpeg or pdf file")
+	ErrKYCDocumentTooLarge   = errors.New("kyc document is too large")
+	ErrKYCDocumentsMissing   = errors.New("kyc request is missing required documents")
+	ErrKYCLevelRequired      = errors.New("higher kyc level required")
+	ErrInvalidKYCDecision    = errors.New("invalid kyc decision")
+	ErrKYCReviewNoteRequired = errors.New("a note is required when requesting more information")
+	ErrNotificationNotFound  = errors.New("notification not found")
 
 	ErrRoundNotFound      = errors.New("round not found")
 	ErrBettingClosed      = errors.New("betting is closed")
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// KYCLevel is how far a user's identity has been verified. Higher levels
+// unlock larger withdrawals.
+type KYCLevel int
+
+const (
+	KYCLevelNone  KYCLevel = 0
+	KYCLevelBasic KYCLevel = 1
+	KYCLevelFull  KYCLevel = 2
+)
+
+func (l KYCLevel) IsValid() bool {
+	return l >= KYCLevelNone && l <= KYCLevelFull
+}
+
+type KYCStatus string
+
+const (
+	KYCStatusPending       KYCStatus = "pending"
+	KYCStatusInfoRequested KYCStatus = "info_requested"
+	KYCStatusApproved      KYCStatus = "approved"
+	KYCStatusRejected      KYCStatus = "rejected"
+)
+
+type KYCDocumentType string
+
+const (
+	KYCDocPassport       KYCDocumentType = "passport"
+	KYCDocIDCard         KYCDocumentType = "id_card"
+	KYCDocDrivingLicence KYCDocumentType = "driving_licence"
+	KYCDocProofOfAddress KYCDocumentType = "proof_of_address"
+	KYCDocSelfie         KYCDocumentType = "selfie"
+)
+
+var KYCDocumentTypes = []KYCDocumentType{
+	KYCDocPassport,
+	KYCDocIDCard,
+	KYCDocDrivingLicence,
+	KYCDocProofOfAddress,
+	KYCDocSelfie,
+}
+
+func (t KYCDocumentType) IsIdentity() bool {
+	return t == KYCDocPassport || t == KYCDocIDCard || t == KYCDocDrivingLicence
+}
+
+type KYCDecision string
+
+const (
+	KYCDecisionApprove     KYCDecision = "approve"
+	KYCDecisionReject      KYCDecision = "reject"
+	KYCDecisionRequestInfo KYCDecision = "request_info"
+)
+
+// KYCRequest asks for the user to be raised to RequestedLevel. It stays
+// open while pending or while the reviewer waits for more documents.
+type KYCRequest struct {
+	ID             uuid.UUID     `json:"id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	RequestedLevel KYCLevel      `json:"requested_level"`
+	Status         KYCStatus     `json:"status"`
+	ReviewerID     *uuid.UUID    `json:"reviewer_id,omitempty"`
+	ReviewNote     string        `json:"review_note,omitempty"`
+	Documents      []KYCDocument `json:"documents"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	ReviewedAt     *time.Time    `json:"reviewed_at,omitempty"`
+}
+
+func (r KYCRequest) IsOpen() bool {
+	return r.Status == KYCStatusPending || r.Status == KYCStatusInfoRequested
+}
+
+// HasDocumentsFor reports whether the attached documents are enough for
+// the requested level: an identity document for basic, plus a proof of
+// address for full.
+func (r KYCRequest) HasDocumentsFor(level KYCLevel) bool {
+	var identity, address bool
+	for _, d := range r.Documents {
+		if d.Type.IsIdentity() {
+			identity = true
+		}
+		if d.Type == KYCDocProofOfAddress {
+			address = true
+		}
+	}
+
+	switch level {
+	case KYCLevelBasic:
+		return identity
+	case KYCLevelFull:
+		return identity && address
+	default:
+		return false
+	}
+}
+
+type KYCDocument struct {
+	ID          uuid.UUID       `json:"id"`
+	RequestID   uuid.UUID       `json:"request_id"`
+	Type        KYCDocumentType `json:"type"`
+	URL         string          `json:"-"`
+	ContentType string          `json:"content_type"`
+	Size        int64           `json:"size"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type KYCUpload struct {
+	Type KYCDocumentType
+	Upload
+}
+
+// KYCOverview is what a user sees about their own verification.
+type KYCOverview struct {
+	Level         KYCLevel         `json:"level"`
+	WithdrawLimit *decimal.Decimal `json:"withdraw_limit"`
+	Request       *KYCRequest      `json:"request,omitempty"`
+}
+
+// KYCLimitWindow is the rolling period over which KYCLimits add up what a
+// user cashes out.
+const KYCLimitWindow = 24 * time.Hour
+
+// KYCLimits caps what a user may cash out within any KYCLimitWindow, per
+// verification level. Full verification has no cap.
+type KYCLimits struct {
+	Unverified decimal.Decimal
+	Basic      decimal.Decimal
+}
+
+// WithdrawLimit returns the most level may cash out within the window, or
+// nil when there is no limit.
+func (l KYCLimits) WithdrawLimit(level KYCLevel) *decimal.Decimal {
+	switch level {
+	case KYCLevelNone:
+		return &l.Unverified
+	case KYCLevelBasic:
+		return &l.Basic
+	default:
+		return nil
+	}
+}
+
+// RequiredLevel returns the lowest level allowed to cash out amount within
+// the window.
+func (l KYCLimits) RequiredLevel(amount decimal.Decimal) KYCLevel {
+	switch {
+	case amount.LessThanOrEqual(l.Unverified):
+		return KYCLevelNone
+	case amount.LessThanOrEqual(l.Basic):
+		return KYCLevelBasic
+	default:
+		return KYCLevelFull
+	}
+}
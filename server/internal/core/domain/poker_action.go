@@ -18,6 +18,55 @@ const (
 	ActionBet   ActionType = "bet"
 )
 
+// PreActionType is an action queued before the player's turn comes.
+type PreActionType string
+
+const (
+	PreActionCheckFold PreActionType = "check_fold"
+	PreActionCheck     PreActionType = "check"
+	PreActionCallExact PreActionType = "call_exact"
+	PreActionCallAny   PreActionType = "call_any"
+	PreActionFold      PreActionType = "fold"
+)
+
+func (t PreActionType) IsValid() bool {
+	switch t {
+	case PreActionCheckFold, PreActionCheck, PreActionCallExact, PreActionCallAny, PreActionFold:
+		return true
+	}
+	return false
+}
+
+type PreAction struct {
+	Type PreActionType `json:"type"`
+	// Amount is the call a call_exact was queued for.
+	Amount decimal.Decimal `json:"amount,omitempty"`
+}
+
+// LegalActions describes what the player to act may do. Bet and raise
+// amounts are the player's total for the betting round, as they are sent
+// back in a player action.
+type LegalActions struct {
+	Actions     []ActionType    `json:"actions"`
+	CallAmount  decimal.Decimal `json:"call_amount"`
+	CallIsAllIn bool            `json:"call_is_all_in"`
+	MinAmount   decimal.Decimal `json:"min_amount"`
+	MaxAmount   decimal.Decimal `json:"max_amount"`
+	// MinIsAllIn is set when the smallest legal bet or raise takes the
+	// whole stack.
+	MinIsAllIn  bool            `json:"min_is_all_in"`
+	AllInAmount decimal.Decimal `json:"all_in_amount"`
+}
+
+func (l LegalActions) Allows(action ActionType) bool {
+	for _, a := range l.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
 type PokerAction struct {
 	ID          uuid.UUID       `json:"id"`
 	HandID      uuid.UUID       `json:"hand_id"`
@@ -0,0 +1,42 @@
+package domain
+
+// EquityMethod is how equities are worked out: by dealing out every
+// possible board, or by sampling them.
+type EquityMethod string
+
+const (
+	EquityExact      EquityMethod = "exact"
+	EquityMonteCarlo EquityMethod = "monte_carlo"
+)
+
+// EquityRequest asks how each hand range fares against the others once
+// the board is dealt out. Ranges use the usual notation, such as "AsKs",
+// "QQ+" or "A2s-A5s,KQo", and Omaha ranges list exact hands. Dead cards
+// cannot be dealt. An empty Method enumerates when that is cheap enough
+// and samples Trials boards otherwise.
+type EquityRequest struct {
+	GameType GameType
+	Ranges   []string
+	Board    []Card
+	Dead     []Card
+	Method   EquityMethod
+	Trials   int
+}
+
+// HandEquity is how one range fared. Win counts the deals where it took
+// the whole pot alone and Tie those where it took part of it. Equity is
+// its average share of the pot. All are fractions of the deals.
+type HandEquity struct {
+	Range  string  `json:"range"`
+	Combos int     `json:"combos"`
+	Win    float64 `json:"win"`
+	Tie    float64 `json:"tie"`
+	Lose   float64 `json:"lose"`
+	Equity float64 `json:"equity"`
+}
+
+type EquityResult struct {
+	Hands  []HandEquity `json:"hands"`
+	Method EquityMethod `json:"method"`
+	Trials int64        `json:"trials"`
+}
@@ -17,5 +17,26 @@ var (
 	ErrInvalidBuyIn       = errors.New("invalid buy-in amount")
 	ErrSeatTaken          = errors.New("seat is taken")
 	ErrMinPlayersRequired = errors.New("minimum 2 players required")
+	ErrInvalidMaxPlayers  = errors.New("max players must be between 2 and 9")
 	ErrInvalidTransition  = errors.New("invalid stage transition")
+	ErrAlreadySittingOut  = errors.New("player is already sitting out")
+	ErrNotSittingOut      = errors.New("player is not sitting out")
+	ErrInvalidPreAction   = errors.New("invalid pre-action")
+	ErrInvalidGameType    = errors.New("invalid game type")
+	ErrInvalidRake        = errors.New("invalid rake settings")
+	ErrNoRunItTwiceOffer  = errors.New("no run it twice offer pending")
+	ErrNoCardsToShow      = errors.New("no cards to show")
+	ErrNoCardsToMuck      = errors.New("no cards to muck")
+	ErrPayoutMismatch     = errors.New("hand payout does not match the pot")
+
+	ErrTournamentNotFound    = errors.New("tournament not found")
+	ErrTournamentStarted     = errors.New("tournament has already started")
+	ErrAlreadyRegistered     = errors.New("already registered for this tournament")
+	ErrNotRegistered         = errors.New("not registered for this tournament")
+	ErrTournamentTable       = errors.New("not allowed at a tournament table")
+	ErrInvalidBlindSchedule  = errors.New("invalid blind schedule")
+	ErrInvalidPayouts        = errors.New("invalid payout structure")
+	ErrRegistrationClosed    = errors.New("tournament registration is closed")
+	ErrReEntryLimit          = errors.New("re-entry limit reached")
+	ErrInvalidSchedule       = errors.New("invalid tournament schedule")
 )
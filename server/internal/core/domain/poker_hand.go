@@ -17,6 +17,30 @@ const (
 	StageRiver    GameStage = "river"
 	StageShowdown GameStage = "showdown"
 	StageComplete GameStage = "complete"
+
+	// Seven card stud streets.
+	StageThirdStreet   GameStage = "third_street"
+	StageFourthStreet  GameStage = "fourth_street"
+	StageFifthStreet   GameStage = "fifth_street"
+	StageSixthStreet   GameStage = "sixth_street"
+	StageSeventhStreet GameStage = "seventh_street"
+)
+
+// HandCategory is the class of a five-card poker hand. Eight-or-better
+// lows all share HandLow.
+type HandCategory string
+
+const (
+	HandHighCard      HandCategory = "high_card"
+	HandPair          HandCategory = "pair"
+	HandTwoPair       HandCategory = "two_pair"
+	HandThreeOfAKind  HandCategory = "three_of_a_kind"
+	HandStraight      HandCategory = "straight"
+	HandFlush         HandCategory = "flush"
+	HandFullHouse     HandCategory = "full_house"
+	HandFourOfAKind   HandCategory = "four_of_a_kind"
+	HandStraightFlush HandCategory = "straight_flush"
+	HandLow           HandCategory = "low"
 )
 
 type PokerHand struct {
@@ -29,6 +53,17 @@ type PokerHand struct {
 	WinnerID       *uuid.UUID      `json:"winner_id,omitempty"`
 	StartedAt      time.Time       `json:"started_at"`
 	EndedAt        *time.Time      `json:"ended_at,omitempty"`
+
+	// Rake is what the house took from the pot.
+	Rake decimal.Decimal `json:"rake"`
+
+	// SecondBoard is the board of the second runout when the hand was run
+	// twice, empty otherwise.
+	SecondBoard string `json:"second_board,omitempty"`
+
+	// Winners are the awards the hand paid out, with the hands that won
+	// them.
+	Winners []WinnerInfo `json:"winners,omitempty"`
 }
 
 type PokerHandPlayer struct {
@@ -25,4 +25,20 @@ type PokerPlayer struct {
 	SeatNumber int             `json:"seat_number"`
 	Status     PlayerStatus    `json:"status"`
 	JoinedAt   time.Time       `json:"joined_at"`
+
+	// Seat state kept by the table hub only.
+	MissedSmallBlind   bool          `json:"missed_small_blind"`
+	MissedBigBlind     bool          `json:"missed_big_blind"`
+	WaitingForBigBlind bool          `json:"waiting_for_big_blind"`
+	SitOutNextHand     bool          `json:"-"`
+	Timeouts           int           `json:"-"`
+	TimeBank           time.Duration `json:"-"`
+}
+
+func (p PokerPlayer) IsSittingOut() bool {
+	return p.Status == PlayerStatusSittingOut
+}
+
+func (p PokerPlayer) OwesBlinds() bool {
+	return p.MissedSmallBlind || p.MissedBigBlind
 }
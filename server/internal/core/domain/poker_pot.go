@@ -15,10 +15,55 @@ type HandResult struct {
 	Winners       []WinnerInfo `json:"winners"`
 	Pots          []Pot        `json:"pots"`
 	ShowdownCards map[uuid.UUID][]Card `json:"showdown_cards,omitempty"`
+
+	// Rake was taken from the pots before they were paid out.
+	Rake decimal.Decimal `json:"rake"`
+
+	// Boards holds both runouts when the hand was run twice. Each pot is
+	// then split between them and every award says which run it is from.
+	Boards [][]Card `json:"boards,omitempty"`
+
+	// Shown lists the hands shown at showdown in the order they were
+	// shown. Losing hands that were mucked are left out.
+	Shown []ShownHand `json:"shown,omitempty"`
+}
+
+// ShownHand is a hand shown at showdown with the best five cards it makes,
+// and in hi-lo games its best low.
+type ShownHand struct {
+	PlayerID  uuid.UUID `json:"player_id"`
+	HoleCards []Card    `json:"hole_cards"`
+	BestFive  []Card    `json:"best_five"`
+	HandRank  string    `json:"hand_rank"`
+	LowFive   []Card    `json:"low_five,omitempty"`
+	LowRank   string    `json:"low_rank,omitempty"`
+	Run       int       `json:"run,omitempty"`
+
+	Category    HandCategory `json:"category"`
+	Description string       `json:"description"`
 }
 
+// PotHalf says which part of a split pot an award came from. It is empty
+// in games that do not split the pot.
+type PotHalf string
+
+const (
+	PotHalfHigh  PotHalf = "high"
+	PotHalfLow   PotHalf = "low"
+	PotHalfScoop PotHalf = "scoop"
+)
+
 type WinnerInfo struct {
 	PlayerID uuid.UUID       `json:"player_id"`
 	Amount   decimal.Decimal `json:"amount"`
 	HandRank string          `json:"hand_rank"`
+	Half     PotHalf         `json:"half,omitempty"`
+	Run      int             `json:"run,omitempty"`
+
+	// The winning hand: its category, the five cards used and a name
+	// with kickers such as "two pair, Aces and Kings, Queen kicker".
+	// They are empty for a player who won because everyone else folded.
+	Category    HandCategory `json:"category,omitempty"`
+	BestFive    []Card       `json:"best_five,omitempty"`
+	Description string       `json:"description,omitempty"`
 }
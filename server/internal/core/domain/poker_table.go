@@ -15,14 +15,169 @@ const (
 	TableStatusClosed  TableStatus = "closed"
 )
 
+type GameType string
+
+const (
+	GameHoldem GameType = "holdem"
+	GamePLO    GameType = "plo"
+	GamePLO8   GameType = "plo8"
+	GameShort  GameType = "short_deck"
+	GameStud   GameType = "stud"
+)
+
+func (t GameType) IsValid() bool {
+	return t == GameHoldem || t == GamePLO || t == GamePLO8 || t == GameShort || t == GameStud
+}
+
+// DefaultLimit is the betting limit the game is usually played with.
+func (t GameType) DefaultLimit() BettingLimit {
+	switch t {
+	case GamePLO, GamePLO8:
+		return LimitPot
+	case GameStud:
+		return LimitFixed
+	default:
+		return LimitNone
+	}
+}
+
+// BettingLimit caps how much a player may bet or raise.
+type BettingLimit string
+
+const (
+	LimitNone  BettingLimit = "no_limit"
+	LimitPot   BettingLimit = "pot_limit"
+	LimitFixed BettingLimit = "fixed_limit"
+)
+
+func (l BettingLimit) IsValid() bool {
+	return l == LimitNone || l == LimitPot || l == LimitFixed
+}
+
+// AnteType says who pays the ante: every player dealt in, or the big
+// blind alone on behalf of the table.
+type AnteType string
+
+const (
+	AnteNone      AnteType = "none"
+	AntePerPlayer AnteType = "per_player"
+	AnteBigBlind  AnteType = "big_blind"
+)
+
+func (t AnteType) IsValid() bool {
+	return t == AnteNone || t == AntePerPlayer || t == AnteBigBlind
+}
+
+// StraddleType is the seat that posts a straddle of two big blinds each
+// hand. Straddles are only posted with three or more players.
+type StraddleType string
+
+const (
+	StraddleNone   StraddleType = "none"
+	StraddleUTG    StraddleType = "utg"
+	StraddleButton StraddleType = "button"
+)
+
+func (t StraddleType) IsValid() bool {
+	return t == StraddleNone || t == StraddleUTG || t == StraddleButton
+}
+
+// BlindType is how the pot is seeded before the flop: a small and a big
+// blind, antes alone, or a single blind posted by the button. The big
+// blind still sets the minimum bet when it is not posted.
+type BlindType string
+
+const (
+	BlindsStandard BlindType = "blinds"
+	BlindsAnteOnly BlindType = "ante_only"
+	BlindsButton   BlindType = "button_blind"
+)
+
+func (t BlindType) IsValid() bool {
+	return t == BlindsStandard || t == BlindsAnteOnly || t == BlindsButton
+}
+
 type PokerTable struct {
 	ID         uuid.UUID       `json:"id"`
 	Name       string          `json:"name"`
+	GameType   GameType        `json:"game_type"`
+	Limit      BettingLimit    `json:"betting_limit"`
 	SmallBlind decimal.Decimal `json:"small_blind"`
 	BigBlind   decimal.Decimal `json:"big_blind"`
+	Ante       decimal.Decimal `json:"ante"`
+	AnteType   AnteType        `json:"ante_type"`
+	Straddle   StraddleType    `json:"straddle"`
+	BlindType  BlindType       `json:"blind_type"`
 	MinBuyIn   decimal.Decimal `json:"min_buy_in"`
 	MaxBuyIn   decimal.Decimal `json:"max_buy_in"`
 	MaxPlayers int             `json:"max_players"`
 	Status     TableStatus     `json:"status"`
 	CreatedAt  time.Time       `json:"created_at"`
+
+	// TournamentID is set on tables that belong to a tournament, where
+	// chips have no cash value and players are seated by the tournament.
+	TournamentID *uuid.UUID `json:"tournament_id,omitempty"`
+
+	// The house takes RakePercent of every cash game pot, up to the cap
+	// for the number of players dealt in. With NoFlopNoDrop, hands that
+	// end before the flop are not raked.
+	RakePercent  decimal.Decimal `json:"rake_percent"`
+	RakeCaps     []RakeCap       `json:"rake_caps"`
+	NoFlopNoDrop bool            `json:"no_flop_no_drop"`
+
+	// ChipDenomination is the smallest chip in play. Every bet is a whole
+	// number of chips, and split pots are divided in whole chips.
+	ChipDenomination decimal.Decimal `json:"chip_denomination"`
+}
+
+// DefaultChipDenomination is the smallest chip at tables that do not set
+// one: a cent.
+var DefaultChipDenomination = decimal.New(1, -2)
+
+// ChipUnit is the table's smallest chip.
+func (t PokerTable) ChipUnit() decimal.Decimal {
+	if !t.ChipDenomination.IsPositive() {
+		return DefaultChipDenomination
+	}
+	return t.ChipDenomination
+}
+
+// IsWholeChips reports whether amount can be made up of the table's chips.
+func (t PokerTable) IsWholeChips(amount decimal.Decimal) bool {
+	return amount.Mod(t.ChipUnit()).IsZero()
+}
+
+// StraddleAmount is what a straddle costs at this table.
+func (t PokerTable) StraddleAmount() decimal.Decimal {
+	return t.BigBlind.Mul(decimal.NewFromInt(2))
+}
+
+// RakeFor is the rake due on a hand's pots in total, rounded down to a
+// whole chip. Tournament tables are never raked.
+func (t PokerTable) RakeFor(pot decimal.Decimal, players int) decimal.Decimal {
+	if t.TournamentID != nil || !t.RakePercent.IsPositive() {
+		return decimal.Zero
+	}
+
+	unit := t.ChipUnit()
+	rake := pot.Mul(t.RakePercent).Div(decimal.NewFromInt(100)).Div(unit).Floor().Mul(unit)
+	if limit, ok := t.RakeCap(players); ok {
+		rake = decimal.Min(rake, limit.Div(unit).Floor().Mul(unit))
+	}
+	return rake
+}
+
+// RakeCap is the most rake taken from a hand dealt to players players:
+// the cap with the highest MinPlayers they reach. There is no cap if none
+// applies.
+func (t PokerTable) RakeCap(players int) (decimal.Decimal, bool) {
+	best := -1
+	var amount decimal.Decimal
+	for _, c := range t.RakeCaps {
+		if c.MinPlayers <= players && c.MinPlayers > best {
+			best = c.MinPlayers
+			amount = c.Amount
+		}
+	}
+	return amount, best >= 0
 }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// HouseRakeAccount is the house account rake is credited to.
+const HouseRakeAccount = "rake"
+
+// RakeCap limits the rake taken from hands dealt to MinPlayers or more
+// players, so a table can cap short-handed pots lower.
+type RakeCap struct {
+	MinPlayers int             `json:"min_players"`
+	Amount     decimal.Decimal `json:"amount"`
+}
+
+// RakeEntry is the rake taken from one hand.
+type RakeEntry struct {
+	ID        uuid.UUID       `json:"id"`
+	HandID    uuid.UUID       `json:"hand_id"`
+	TableID   uuid.UUID       `json:"table_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type RakeFilter struct {
+	TableID *uuid.UUID
+	From    time.Time
+	To      time.Time
+}
+
+// RakeReportRow is the rake taken at one table on one day, in UTC.
+type RakeReportRow struct {
+	TableID   uuid.UUID       `json:"table_id"`
+	TableName string          `json:"table_name"`
+	Day       time.Time       `json:"day"`
+	Hands     int             `json:"hands"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+// RakeReport is the rake taken over a period, by table and day, and what
+// the house rake account holds now.
+type RakeReport struct {
+	From         time.Time       `json:"from"`
+	To           time.Time       `json:"to"`
+	Total        decimal.Decimal `json:"total"`
+	HouseBalance decimal.Decimal `json:"house_balance"`
+	Rows         []RakeReportRow `json:"rows"`
+}
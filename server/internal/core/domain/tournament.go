@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type TournamentKind string
+
+const (
+	TournamentSitAndGo  TournamentKind = "sit_and_go"
+	TournamentScheduled TournamentKind = "scheduled"
+)
+
+func (k TournamentKind) IsValid() bool {
+	return k == TournamentSitAndGo || k == TournamentScheduled
+}
+
+type TournamentStatus string
+
+const (
+	TournamentRegistering TournamentStatus = "registering"
+	TournamentRunning     TournamentStatus = "running"
+	TournamentFinished    TournamentStatus = "finished"
+	TournamentCancelled   TournamentStatus = "cancelled"
+)
+
+// BlindLevel is one step of a tournament's blind schedule. The ante, if
+// any, is paid by every player dealt in.
+type BlindLevel struct {
+	SmallBlind decimal.Decimal `json:"small_blind"`
+	BigBlind   decimal.Decimal `json:"big_blind"`
+	Ante       decimal.Decimal `json:"ante"`
+}
+
+// Tournament is played until a single player has all the chips. A
+// Sit-and-Go starts as soon as MaxPlayers have registered and plays on one
+// table; a scheduled tournament starts at StartsAt with everyone who has
+// registered by then, spread over as many tables as it needs. Entrants pay
+// BuyIn into the prize pool plus Fee to the house, and get StartingChips,
+// which have no cash value.
+type Tournament struct {
+	ID            uuid.UUID         `json:"id"`
+	Name          string            `json:"name"`
+	Kind          TournamentKind    `json:"kind"`
+	GameType      GameType          `json:"game_type"`
+	Limit         BettingLimit      `json:"betting_limit"`
+	BuyIn         decimal.Decimal   `json:"buy_in"`
+	Fee           decimal.Decimal   `json:"fee"`
+	StartingChips decimal.Decimal   `json:"starting_chips"`
+	MaxPlayers    int               `json:"max_players"`
+	Levels        []BlindLevel      `json:"levels"`
+	LevelSeconds  int               `json:"level_seconds"`
+	Payouts       []decimal.Decimal `json:"payouts"`
+	Status        TournamentStatus  `json:"status"`
+	TableID       *uuid.UUID        `json:"table_id,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	StartedAt     *time.Time        `json:"started_at,omitempty"`
+	EndedAt       *time.Time        `json:"ended_at,omitempty"`
+
+	// Registration for a scheduled tournament opens at
+	// RegistrationOpensAt, or at once if it is nil. Late registration and
+	// up to MaxReEntries re-entries stay open for the first LateRegLevels
+	// levels.
+	StartsAt            *time.Time `json:"starts_at,omitempty"`
+	RegistrationOpensAt *time.Time `json:"registration_opens_at,omitempty"`
+	LateRegLevels       int        `json:"late_reg_levels"`
+	MaxReEntries        int        `json:"max_re_entries"`
+	TableSize           int        `json:"table_size"`
+	// Play stops for BreakSeconds after every BreakEvery levels.
+	BreakEvery   int `json:"break_every"`
+	BreakSeconds int `json:"break_seconds"`
+}
+
+// LevelDuration is how long each blind level lasts.
+func (t Tournament) LevelDuration() time.Duration {
+	return time.Duration(t.LevelSeconds) * time.Second
+}
+
+// BreakDuration is how long each break lasts.
+func (t Tournament) BreakDuration() time.Duration {
+	return time.Duration(t.BreakSeconds) * time.Second
+}
+
+// Cost is what an entrant pays to register.
+func (t Tournament) Cost() decimal.Decimal {
+	return t.BuyIn.Add(t.Fee)
+}
+
+// PrizePool is the sum of the buy-ins, re-entries included, paid out by
+// Payouts.
+func (t Tournament) PrizePool(entrants int) decimal.Decimal {
+	return t.BuyIn.Mul(decimal.NewFromInt(int64(entrants)))
+}
+
+// Prizes splits the prize pool by finishing place: Payouts holds the
+// percentage for first place, second place and so on. If fewer players
+// entered than there are places, the places nobody can reach are dropped
+// and the rest scaled up. Amounts are rounded down to the cent and the
+// remainder goes to the winner.
+func (t Tournament) Prizes(entrants int) []decimal.Decimal {
+	pool := t.PrizePool(entrants)
+	payouts := t.Payouts[:min(len(t.Payouts), max(entrants, 0))]
+
+	total := decimal.Zero
+	for _, pct := range payouts {
+		total = total.Add(pct)
+	}
+
+	prizes := make([]decimal.Decimal, len(payouts))
+	paid := decimal.Zero
+	for i, pct := range payouts {
+		prizes[i] = pool.Mul(pct).Div(total).RoundDown(2)
+		paid = paid.Add(prizes[i])
+	}
+	if len(prizes) > 0 {
+		prizes[0] = prizes[0].Add(pool.Sub(paid))
+	}
+	return prizes
+}
+
+// TournamentEntry is a player's registration, or a re-entry after busting
+// out. Position is 0 while they are still in; Prize is set once the
+// tournament has been paid out.
+type TournamentEntry struct {
+	ID           uuid.UUID       `json:"id"`
+	TournamentID uuid.UUID       `json:"tournament_id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	Username     string          `json:"username"`
+	EntryNumber  int             `json:"entry_number"`
+	Position     int             `json:"position,omitempty"`
+	Prize        decimal.Decimal `json:"prize"`
+	EliminatedAt *time.Time      `json:"eliminated_at,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// TournamentDetail is a tournament together with its entrants.
+type TournamentDetail struct {
+	Tournament
+	Entries []TournamentEntry `json:"entries"`
+}
+
+// TournamentStanding is a player's place in a tournament: their stack and
+// table while they are still in, and their finishing position and prize
+// once they are out.
+type TournamentStanding struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Username string          `json:"username"`
+	Stack    decimal.Decimal `json:"stack"`
+	TableID  *uuid.UUID      `json:"table_id,omitempty"`
+	Position int             `json:"position,omitempty"`
+	Prize    decimal.Decimal `json:"prize"`
+}
+
+// TournamentStandings is the state of play: the current level, the prize
+// pool so far and every player still in, by stack, followed by those
+// already out, by finishing position.
+type TournamentStandings struct {
+	TournamentID     uuid.UUID            `json:"tournament_id"`
+	Status           TournamentStatus     `json:"status"`
+	Level            int                  `json:"level"`
+	Blinds           BlindLevel           `json:"blinds"`
+	NextLevelIn      float64              `json:"next_level_in,omitempty"`
+	OnBreak          bool                 `json:"on_break"`
+	HandForHand      bool                 `json:"hand_for_hand"`
+	LateRegistration bool                 `json:"late_registration"`
+	Entries          int                  `json:"entries"`
+	PlayersLeft      int                  `json:"players_left"`
+	PrizePool        decimal.Decimal      `json:"prize_pool"`
+	Prizes           []decimal.Decimal    `json:"prizes"`
+	Tables           []uuid.UUID          `json:"tables"`
+	Players          []TournamentStanding `json:"players"`
+}
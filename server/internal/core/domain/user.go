@@ -1,26 +1,33 @@
 package domain
 
 import (
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type User struct {
+	ID                uuid.UUID  `json:"id"`
+	Username          string     `json:"username"`
+	Email             string     `json:"email"`
+	PasswordHash      string     `json:"-"`
+	AvatarURL         string     `json:"avatar_url"`
+	IsAdmin           bool       `json:"-"`
+	KYCLevel          KYCLevel   `json:"kyc_level"`
+	UsernameChangedAt *time.Time `json:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type UserProfile struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
 	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	IsAdmin      bool      `json:"-"`
+	PendingEmail string    `json:"pending_email,omitempty"`
+	AvatarURL    string    `json:"avatar_url"`
+	KYCLevel     KYCLevel  `json:"kyc_level"`
 	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-}
-
-type UserProfile struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
 }
 
 func NewUserProfile(u User) UserProfile {
@@ -28,6 +35,41 @@ func NewUserProfile(u User) UserProfile {
 		ID:        u.ID,
 		Username:  u.Username,
 		Email:     u.Email,
+		AvatarURL: u.AvatarURL,
+		KYCLevel:  u.KYCLevel,
 		CreatedAt: u.CreatedAt,
 	}
 }
+
+// ProfileUpdate carries the optional changes of a PATCH /users/me request.
+// Nil fields are left untouched.
+type ProfileUpdate struct {
+	Username *string
+	Email    *string
+	Avatar   *Upload
+	Password *PasswordChange
+}
+
+// PasswordChange replaces the password once the current one is confirmed.
+// Every other session of the user is revoked; SessionID, the one making
+// the request, stays valid.
+type PasswordChange struct {
+	Current   string
+	New       string
+	SessionID uuid.UUID
+}
+
+type Upload struct {
+	Reader io.Reader
+	Size   int64
+}
+
+// EmailVerification is a pending email change. The new address replaces
+// the current one only after the token sent to it is confirmed.
+type EmailVerification struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	TokenHash string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -15,6 +15,12 @@ const (
 	WSMsgJoinTable    WSMessageType = "join_table"
 	WSMsgLeaveTable   WSMessageType = "leave_table"
 	WSMsgChat         WSMessageType = "chat"
+	WSMsgSitOut       WSMessageType = "sit_out"
+	WSMsgSitIn        WSMessageType = "sit_in"
+	WSMsgPreAction    WSMessageType = "pre_action"
+	WSMsgRunItTwice   WSMessageType = "run_it_twice"
+	WSMsgShowCards    WSMessageType = "show_cards"
+	WSMsgMuckCards    WSMessageType = "muck_cards"
 
 	// Server -> Client
 	WSMsgTableState    WSMessageType = "table_state"
@@ -28,6 +34,18 @@ const (
 	WSMsgNewHand       WSMessageType = "new_hand"
 	WSMsgError         WSMessageType = "error"
 	WSMsgPotUpdated    WSMessageType = "pot_updated"
+	WSMsgTimeBank      WSMessageType = "time_bank_started"
+	WSMsgPreActionSet  WSMessageType = "pre_action_updated"
+	WSMsgUpCards       WSMessageType = "up_cards"
+	WSMsgBlindLevel    WSMessageType = "blind_level"
+	WSMsgEliminated    WSMessageType = "player_eliminated"
+	WSMsgStandings     WSMessageType = "tournament_standings"
+	WSMsgTableMoved    WSMessageType = "table_moved"
+	WSMsgRunItTwiceAsk WSMessageType = "run_it_twice_offer"
+	WSMsgRunItTwiceSet WSMessageType = "run_it_twice_decided"
+	WSMsgCardsShown    WSMessageType = "cards_shown"
+	WSMsgShowOrMuck    WSMessageType = "show_or_muck"
+	WSMsgEquity        WSMessageType = "equity_update"
 )
 
 type WSMessage struct {
@@ -35,23 +53,110 @@ type WSMessage struct {
 	Payload json.RawMessage `json:"payload"`
 }
 
+// WSError is the payload of a WSMsgError message.
+type WSError struct {
+	Code    ErrorCode         `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func NewWSError(err error) WSError {
+	info := DescribeError(err)
+	return WSError{Code: info.Code, Message: info.Message, Details: info.Details}
+}
+
 type WSPlayerAction struct {
 	TableID  uuid.UUID       `json:"table_id"`
 	Action   ActionType      `json:"action"`
 	Amount   decimal.Decimal `json:"amount,omitempty"`
 }
 
+// WSPreActionRequest queues a pre-action; an empty action clears it.
+type WSPreActionRequest struct {
+	Action PreActionType `json:"action"`
+}
+
+// WSPreActionUpdated tells a player what is queued for them, if anything.
+type WSPreActionUpdated struct {
+	PreAction *PreAction `json:"pre_action"`
+}
+
+// WSRunItTwiceRequest answers a run it twice offer.
+type WSRunItTwiceRequest struct {
+	Agree bool `json:"agree"`
+}
+
+// WSRunItTwiceOffer asks the players in an all-in pot whether to run the
+// rest of the board twice. Everyone listed has to agree within Timeout
+// seconds.
+type WSRunItTwiceOffer struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+	Timeout float64     `json:"timeout"`
+}
+
+// WSRunItTwiceDecided says whether the board will be run twice.
+type WSRunItTwiceDecided struct {
+	Agreed bool `json:"agreed"`
+}
+
+// WSShowOrMuck asks the players whose hands lost at showdown without being
+// shown whether to show them. Hands not shown within Timeout seconds are
+// mucked.
+type WSShowOrMuck struct {
+	HandID  uuid.UUID   `json:"hand_id"`
+	UserIDs []uuid.UUID `json:"user_ids"`
+	Timeout float64     `json:"timeout"`
+}
+
+// WSCardsShown turns hole cards face up, keyed by user ID: every hand in
+// an all-in pot before the board is run out, or a hand its player chose to
+// show once the hand was over.
+type WSCardsShown struct {
+	HandID uuid.UUID            `json:"hand_id"`
+	Cards  map[uuid.UUID][]Card `json:"cards"`
+}
+
+// WSEquity gives each all-in hand's equity, keyed by user ID, as the
+// board is run out. Run numbers the runout when the board is run twice.
+type WSEquity struct {
+	HandID   uuid.UUID                `json:"hand_id"`
+	Stage    GameStage                `json:"stage"`
+	Run      int                      `json:"run,omitempty"`
+	Method   EquityMethod             `json:"method"`
+	Equities map[uuid.UUID]HandEquity `json:"equities"`
+}
+
+type WSTurnChanged struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Timeout  float64   `json:"timeout"`
+	TimeBank float64   `json:"time_bank"`
+	// LegalActions is only sent to the player to act.
+	LegalActions *LegalActions `json:"legal_actions,omitempty"`
+}
+
+type WSSitIn struct {
+	WaitForBigBlind bool `json:"wait_for_big_blind"`
+}
+
 type WSTableState struct {
 	TableID        uuid.UUID       `json:"table_id"`
 	Name           string          `json:"name"`
+	GameType       GameType        `json:"game_type"`
+	BettingLimit   BettingLimit    `json:"betting_limit"`
 	SmallBlind     decimal.Decimal `json:"small_blind"`
 	BigBlind       decimal.Decimal `json:"big_blind"`
+	Ante           decimal.Decimal `json:"ante"`
+	AnteType       AnteType        `json:"ante_type"`
+	Straddle       StraddleType    `json:"straddle"`
+	BlindType      BlindType       `json:"blind_type"`
 	Pot            decimal.Decimal `json:"pot"`
 	CommunityCards []Card          `json:"community_cards"`
 	Stage          GameStage       `json:"stage"`
 	DealerSeat     int             `json:"dealer_seat"`
 	CurrentTurn    *uuid.UUID      `json:"current_turn,omitempty"`
 	Players        []WSPlayerInfo  `json:"players"`
+
+	TournamentID *uuid.UUID `json:"tournament_id,omitempty"`
 }
 
 type WSPlayerInfo struct {
@@ -62,6 +167,45 @@ type WSPlayerInfo struct {
 	Status     PlayerStatus    `json:"status"`
 	BetAmount  decimal.Decimal `json:"bet_amount"`
 	IsDealer   bool            `json:"is_dealer"`
+	TimeBank   float64         `json:"time_bank"`
+
+	MissedSmallBlind   bool `json:"missed_small_blind,omitempty"`
+	MissedBigBlind     bool `json:"missed_big_blind,omitempty"`
+	WaitingForBigBlind bool `json:"waiting_for_big_blind,omitempty"`
+
+	// UpCards are a stud player's face-up cards.
+	UpCards []Card `json:"up_cards,omitempty"`
+}
+
+// WSUpCards is sent after each stud street with the face-up cards of the
+// players still in the hand, keyed by user ID. CommunityCards holds the
+// shared seventh-street card dealt when the deck runs short.
+type WSUpCards struct {
+	Stage          GameStage            `json:"stage"`
+	UpCards        map[uuid.UUID][]Card `json:"up_cards"`
+	CommunityCards []Card               `json:"community_cards,omitempty"`
+}
+
+// WSBlindLevel announces a tournament's blind level. The new blinds take
+// effect from the next hand.
+type WSBlindLevel struct {
+	Level       int        `json:"level"`
+	BlindLevel  BlindLevel `json:"blinds"`
+	NextLevelIn float64    `json:"next_level_in,omitempty"`
+}
+
+// WSEliminated is sent when a tournament player busts out.
+type WSEliminated struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Position int       `json:"position"`
+}
+
+// WSTableMoved tells a tournament player they have been moved to another
+// table to balance the tables.
+type WSTableMoved struct {
+	TournamentID uuid.UUID `json:"tournament_id"`
+	TableID      uuid.UUID `json:"table_id"`
+	SeatNumber   int       `json:"seat_number"`
 }
 
 type WSCardsDealt struct {
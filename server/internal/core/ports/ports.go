@@ -2,9 +2,11 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 type GameStateRepository interface {
@@ -20,11 +22,56 @@ type GameStateRepository interface {
 type RNGService interface {
 	GenerateServerSeed() (string, error)
 	HashSeed(serverSeed string) string
-	ShuffleDeck(serverSeed, clientSeed string, nonce int) []domain.Card
+	// ShuffleDeck returns a shuffled copy of deck.
+	ShuffleDeck(deck []domain.Card, serverSeed, clientSeed string, nonce int) []domain.Card
 	VerifySeed(serverSeed, hash string) bool
 }
 
 type Broadcaster interface {
 	BroadcastToTable(tableID uuid.UUID, msg domain.WSMessage)
+	// BroadcastToTableExcept reaches everyone at the table but one user,
+	// for messages that user gets a private version of.
+	BroadcastToTableExcept(tableID, exceptUserID uuid.UUID, msg domain.WSMessage)
 	SendToPlayer(tableID, userID uuid.UUID, msg domain.WSMessage)
 }
+
+// FileStorage stores user uploads. Save returns the URL of the stored
+// object; Open and Delete accept a URL previously returned by Save.
+type FileStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+	Open(ctx context.Context, url string) (io.ReadCloser, error)
+	Delete(ctx context.Context, url string) error
+}
+
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// PlayerRenamer propagates a username change to every table the user is
+// currently seated at.
+type PlayerRenamer interface {
+	RenamePlayer(userID uuid.UUID, username string)
+}
+
+// TournamentHost is how a tournament director reaches the rest of the
+// system: it opens and closes tables as the field grows and shrinks,
+// records players busting out, and pays the prizes once a single player is
+// left. Tables never move money themselves.
+type TournamentHost interface {
+	OpenTable(ctx context.Context, tournamentID uuid.UUID) (domain.PokerTable, error)
+	CloseTable(ctx context.Context, tableID uuid.UUID) error
+	PlayerEliminated(ctx context.Context, tournamentID, userID uuid.UUID, position int) error
+	TournamentFinished(ctx context.Context, tournamentID, winnerID uuid.UUID) error
+}
+
+// SessionTerminator closes live connections that were opened with a
+// revoked session or API key.
+type SessionTerminator interface {
+	CloseSession(sessionID uuid.UUID)
+}
+
+// WithdrawalLimiter decides whether a user who has already cashed out
+// withdrawn within domain.KYCLimitWindow may withdraw amount more.
+type WithdrawalLimiter interface {
+	CheckWithdrawal(ctx context.Context, userID uuid.UUID, amount, withdrawn decimal.Decimal) error
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
@@ -14,6 +15,54 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindByUsername(ctx context.Context, username string) (domain.User, error)
 	Update(ctx context.Context, user domain.User) (domain.User, error)
+	UpdateKYCLevel(ctx context.Context, id uuid.UUID, level domain.KYCLevel) error
+}
+
+type EmailVerificationRepository interface {
+	Upsert(ctx context.Context, v domain.EmailVerification) (domain.EmailVerification, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) (domain.EmailVerification, error)
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+type SessionRepository interface {
+	Create(ctx context.Context, session domain.Session) (domain.Session, error)
+	FindByID(ctx context.Context, id uuid.UUID) (domain.Session, error)
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	Touch(ctx context.Context, id uuid.UUID) error
+	Revoke(ctx context.Context, id, userID uuid.UUID) error
+	RevokeAllExcept(ctx context.Context, userID, exceptID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key domain.APIKey) (domain.APIKey, error)
+	FindByHash(ctx context.Context, hash string) (domain.APIKey, error)
+	FindByID(ctx context.Context, id, userID uuid.UUID) (domain.APIKey, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error)
+	CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	Revoke(ctx context.Context, id, userID uuid.UUID) error
+	RecordUsage(ctx context.Context, usage domain.APIKeyUsage) error
+	FindUsage(ctx context.Context, keyID uuid.UUID, limit, offset int) ([]domain.APIKeyUsage, error)
+}
+
+type KYCRepository interface {
+	CreateRequest(ctx context.Context, req domain.KYCRequest) (domain.KYCRequest, error)
+	FindRequestByID(ctx context.Context, id uuid.UUID) (domain.KYCRequest, error)
+	FindLatestRequestByUserID(ctx context.Context, userID uuid.UUID) (domain.KYCRequest, error)
+	FindRequestsByStatus(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]domain.KYCRequest, error)
+	UpdateRequest(ctx context.Context, req domain.KYCRequest) (domain.KYCRequest, error)
+	CreateDocument(ctx context.Context, doc domain.KYCDocument) (domain.KYCDocument, error)
+	FindDocumentByID(ctx context.Context, id uuid.UUID) (domain.KYCDocument, error)
+	FindDocumentsByRequestID(ctx context.Context, requestID uuid.UUID) ([]domain.KYCDocument, error)
+}
+
+type NotificationRepository interface {
+	Create(ctx context.Context, n domain.Notification) (domain.Notification, error)
+	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID) error
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry domain.AuditLog) (domain.AuditLog, error)
 }
 
 type WalletRepository interface {
@@ -25,6 +74,7 @@ type WalletRepository interface {
 type TransactionRepository interface {
 	Create(ctx context.Context, tx domain.Transaction) (domain.Transaction, error)
 	FindByWalletID(ctx context.Context, filter domain.TransactionFilter) ([]domain.Transaction, error)
+	SumSince(ctx context.Context, walletID uuid.UUID, referenceType string, since time.Time) (decimal.Decimal, error)
 }
 
 type PokerTableRepository interface {
@@ -43,10 +93,19 @@ type PokerPlayerRepository interface {
 	FindByTableAndUser(ctx context.Context, tableID, userID uuid.UUID) (domain.PokerPlayer, error)
 	UpdateStack(ctx context.Context, playerID uuid.UUID, stack decimal.Decimal) error
 	UpdateStatus(ctx context.Context, playerID uuid.UUID, status domain.PlayerStatus) error
+	UpdateUsernameByUserID(ctx context.Context, userID uuid.UUID, username string) error
 	Delete(ctx context.Context, playerID uuid.UUID) error
 	CountByTableID(ctx context.Context, tableID uuid.UUID) (int, error)
 }
 
+// RakeRepository records the rake taken from each hand, crediting it to
+// the house rake account.
+type RakeRepository interface {
+	Credit(ctx context.Context, entry domain.RakeEntry) (domain.RakeEntry, error)
+	Report(ctx context.Context, filter domain.RakeFilter) ([]domain.RakeReportRow, error)
+	HouseBalance(ctx context.Context, account string) (decimal.Decimal, error)
+}
+
 type PokerHandRepository interface {
 	Create(ctx context.Context, hand domain.PokerHand) (domain.PokerHand, error)
 	FindByID(ctx context.Context, id uuid.UUID) (domain.PokerHand, error)
@@ -59,6 +118,17 @@ type PokerHandRepository interface {
 	FindActionsByHandID(ctx context.Context, handID uuid.UUID) ([]domain.PokerAction, error)
 }
 
+type TournamentRepository interface {
+	Create(ctx context.Context, t domain.Tournament) (domain.Tournament, error)
+	FindByID(ctx context.Context, id uuid.UUID) (domain.Tournament, error)
+	FindByStatus(ctx context.Context, statuses ...domain.TournamentStatus) ([]domain.Tournament, error)
+	Update(ctx context.Context, t domain.Tournament) (domain.Tournament, error)
+	CreateEntry(ctx context.Context, e domain.TournamentEntry) (domain.TournamentEntry, error)
+	FindEntries(ctx context.Context, tournamentID uuid.UUID) ([]domain.TournamentEntry, error)
+	UpdateEntry(ctx context.Context, e domain.TournamentEntry) error
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+}
+
 type RouletteTableRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (domain.RouletteTable, error)
 	FindActive(ctx context.Context) ([]domain.RouletteTable, error)
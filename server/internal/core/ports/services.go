@@ -2,20 +2,47 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 type AuthService interface {
 	Register(ctx context.Context, username, email, password string) (domain.User, error)
-	Login(ctx context.Context, email, password string) (domain.TokenPair, error)
+	Login(ctx context.Context, email, password string, client domain.ClientInfo) (domain.TokenPair, error)
 	ValidateToken(token string) (domain.TokenClaims, error)
+	Authenticate(ctx context.Context, token string) (domain.TokenClaims, error)
+	ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	CreateAPIKey(ctx context.Context, userID uuid.UUID, req domain.NewAPIKey) (domain.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error
+	ListAPIKeyUsage(ctx context.Context, userID, keyID uuid.UUID, limit, offset int) ([]domain.APIKeyUsage, error)
+	RecordAPIKeyUsage(ctx context.Context, usage domain.APIKeyUsage) error
 }
 
 type UserService interface {
 	GetProfile(ctx context.Context, userID uuid.UUID) (domain.UserProfile, error)
 	GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, update domain.ProfileUpdate) (domain.UserProfile, error)
+	VerifyEmail(ctx context.Context, userID uuid.UUID, token string) (domain.UserProfile, error)
+}
+
+type KYCService interface {
+	GetOverview(ctx context.Context, userID uuid.UUID) (domain.KYCOverview, error)
+	Submit(ctx context.Context, userID uuid.UUID, level domain.KYCLevel, uploads []domain.KYCUpload) (domain.KYCRequest, error)
+	ListRequests(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]domain.KYCRequest, error)
+	GetRequest(ctx context.Context, requestID uuid.UUID) (domain.KYCRequest, error)
+	Review(ctx context.Context, reviewerID, requestID uuid.UUID, decision domain.KYCDecision, note string) (domain.KYCRequest, error)
+	OpenDocument(ctx context.Context, documentID uuid.UUID) (domain.KYCDocument, io.ReadCloser, error)
+	CheckWithdrawal(ctx context.Context, userID uuid.UUID, amount, withdrawn decimal.Decimal) error
+}
+
+type NotificationService interface {
+	List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error
 }
 
 type WalletService interface {
@@ -23,6 +50,7 @@ type WalletService interface {
 	GetBalance(ctx context.Context, userID uuid.UUID) (domain.Wallet, error)
 	Deposit(ctx context.Context, userID uuid.UUID, amount string) (domain.Wallet, error)
 	Withdraw(ctx context.Context, userID uuid.UUID, amount string) (domain.Wallet, error)
+	CashOut(ctx context.Context, userID uuid.UUID, amount string) (domain.Wallet, error)
 	GetTransactions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Transaction, error)
 }
 
@@ -32,9 +60,28 @@ type PokerService interface {
 	ListTables(ctx context.Context) ([]domain.PokerTable, error)
 	JoinTable(ctx context.Context, tableID, userID uuid.UUID, seatNumber int, buyIn string) (domain.PokerPlayer, error)
 	LeaveTable(ctx context.Context, tableID, userID uuid.UUID) error
+	SitOut(ctx context.Context, tableID, userID uuid.UUID) error
+	SitIn(ctx context.Context, tableID, userID uuid.UUID, waitForBigBlind bool) error
+	SetPreAction(ctx context.Context, tableID, userID uuid.UUID, action domain.PreActionType) error
+	RunItTwice(ctx context.Context, tableID, userID uuid.UUID, agree bool) error
+	ShowCards(ctx context.Context, tableID, userID uuid.UUID) error
+	MuckCards(ctx context.Context, tableID, userID uuid.UUID) error
 	GetTableState(ctx context.Context, tableID uuid.UUID) (domain.WSTableState, error)
 }
 
+type EquityService interface {
+	CalculateEquity(ctx context.Context, req domain.EquityRequest) (domain.EquityResult, error)
+}
+
+type TournamentService interface {
+	CreateTournament(ctx context.Context, t domain.Tournament) (domain.Tournament, error)
+	GetTournament(ctx context.Context, id uuid.UUID) (domain.TournamentDetail, error)
+	ListTournaments(ctx context.Context) ([]domain.Tournament, error)
+	GetStandings(ctx context.Context, id uuid.UUID) (domain.TournamentStandings, error)
+	Register(ctx context.Context, tournamentID, userID uuid.UUID) (domain.TournamentEntry, error)
+	Unregister(ctx context.Context, tournamentID, userID uuid.UUID) error
+}
+
 type RouletteService interface {
 	GetTable(ctx context.Context, tableID uuid.UUID) (domain.RouletteTable, error)
 	ListActiveTables(ctx context.Context) ([]domain.RouletteTable, error)
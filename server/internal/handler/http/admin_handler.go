@@ -1,8 +1,8 @@
 package http
 
 import (
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,15 +16,18 @@ import (
 type AdminHandler struct {
 	pokerRepo    ports.PokerTableRepository
 	rouletteRepo *repository.RouletteTableRepository
+	rakeRepo     ports.RakeRepository
 }
 
 func NewAdminHandler(
 	pokerRepo ports.PokerTableRepository,
 	rouletteRepo *repository.RouletteTableRepository,
+	rakeRepo ports.RakeRepository,
 ) *AdminHandler {
 	return &AdminHandler{
 		pokerRepo:    pokerRepo,
 		rouletteRepo: rouletteRepo,
+		rakeRepo:     rakeRepo,
 	}
 }
 
@@ -32,21 +35,51 @@ func NewAdminHandler(
 
 type createPokerTableRequest struct {
 	Name       string `json:"name" binding:"required"`
+	GameType   string `json:"game_type" binding:"omitempty,oneof=holdem plo plo8 short_deck stud"`
+	Limit      string `json:"betting_limit" binding:"omitempty,oneof=no_limit pot_limit fixed_limit"`
 	SmallBlind string `json:"small_blind" binding:"required"`
 	BigBlind   string `json:"big_blind" binding:"required"`
 	MinBuyIn   string `json:"min_buy_in" binding:"required"`
 	MaxBuyIn   string `json:"max_buy_in" binding:"required"`
 	MaxPlayers int    `json:"max_players" binding:"required,min=2,max=10"`
+	Ante       string `json:"ante"`
+	AnteType   string `json:"ante_type" binding:"omitempty,oneof=none per_player big_blind"`
+	Straddle   string `json:"straddle" binding:"omitempty,oneof=none utg button"`
+	BlindType  string `json:"blind_type" binding:"omitempty,oneof=blinds ante_only button_blind"`
+
+	RakePercent  string           `json:"rake_percent"`
+	RakeCaps     []rakeCapRequest `json:"rake_caps" binding:"dive"`
+	NoFlopNoDrop bool             `json:"no_flop_no_drop"`
+
+	ChipDenomination string `json:"chip_denomination"`
 }
 
 type updatePokerTableRequest struct {
 	Name       string `json:"name" binding:"required"`
+	GameType   string `json:"game_type" binding:"omitempty,oneof=holdem plo plo8 short_deck stud"`
+	Limit      string `json:"betting_limit" binding:"omitempty,oneof=no_limit pot_limit fixed_limit"`
 	SmallBlind string `json:"small_blind" binding:"required"`
 	BigBlind   string `json:"big_blind" binding:"required"`
 	MinBuyIn   string `json:"min_buy_in" binding:"required"`
 	MaxBuyIn   string `json:"max_buy_in" binding:"required"`
 	MaxPlayers int    `json:"max_players" binding:"required,min=2,max=10"`
+	Ante       string `json:"ante"`
+	AnteType   string `json:"ante_type" binding:"omitempty,oneof=none per_player big_blind"`
+	Straddle   string `json:"straddle" binding:"omitempty,oneof=none utg button"`
+	BlindType  string `json:"blind_type" binding:"omitempty,oneof=blinds ante_only button_blind"`
 	Status     string `json:"status" binding:"required,oneof=waiting active closed"`
+
+	RakePercent  string           `json:"rake_percent"`
+	RakeCaps     []rakeCapRequest `json:"rake_caps" binding:"dive"`
+	NoFlopNoDrop bool             `json:"no_flop_no_drop"`
+
+	ChipDenomination string `json:"chip_denomination"`
+}
+
+// rakeCapRequest caps the rake of hands dealt to MinPlayers or more.
+type rakeCapRequest struct {
+	MinPlayers int    `json:"min_players" binding:"required,min=2,max=10"`
+	Amount     string `json:"amount" binding:"required"`
 }
 
 type pokerDecimals struct {
@@ -59,32 +92,32 @@ type pokerDecimals struct {
 func parsePokerDecimals(sb, bb, minBI, maxBI string) (pokerDecimals, error) {
 	smallBlind, err := decimal.NewFromString(sb)
 	if err != nil {
-		return pokerDecimals{}, fmt.Errorf("invalid small_blind")
+		return pokerDecimals{}, domain.NewValidationError("small_blind", "invalid small_blind")
 	}
 	bigBlind, err := decimal.NewFromString(bb)
 	if err != nil {
-		return pokerDecimals{}, fmt.Errorf("invalid big_blind")
+		return pokerDecimals{}, domain.NewValidationError("big_blind", "invalid big_blind")
 	}
 	minBuyIn, err := decimal.NewFromString(minBI)
 	if err != nil {
-		return pokerDecimals{}, fmt.Errorf("invalid min_buy_in")
+		return pokerDecimals{}, domain.NewValidationError("min_buy_in", "invalid min_buy_in")
 	}
 	maxBuyIn, err := decimal.NewFromString(maxBI)
 	if err != nil {
-		return pokerDecimals{}, fmt.Errorf("invalid max_buy_in")
+		return pokerDecimals{}, domain.NewValidationError("max_buy_in", "invalid max_buy_in")
 	}
 
 	if smallBlind.LessThanOrEqual(decimal.Zero) || bigBlind.LessThanOrEqual(decimal.Zero) {
-		return pokerDecimals{}, fmt.Errorf("blinds must be positive")
+		return pokerDecimals{}, domain.NewValidationError("big_blind", "blinds must be positive")
 	}
 	if smallBlind.GreaterThanOrEqual(bigBlind) {
-		return pokerDecimals{}, fmt.Errorf("small_blind must be less than big_blind")
+		return pokerDecimals{}, domain.NewValidationError("small_blind", "small_blind must be less than big_blind")
 	}
 	if minBuyIn.LessThanOrEqual(decimal.Zero) || maxBuyIn.LessThanOrEqual(decimal.Zero) {
-		return pokerDecimals{}, fmt.Errorf("buy-in amounts must be positive")
+		return pokerDecimals{}, domain.NewValidationError("min_buy_in", "buy-in amounts must be positive")
 	}
 	if minBuyIn.GreaterThanOrEqual(maxBuyIn) {
-		return pokerDecimals{}, fmt.Errorf("min_buy_in must be less than max_buy_in")
+		return pokerDecimals{}, domain.NewValidationError("min_buy_in", "min_buy_in must be less than max_buy_in")
 	}
 
 	return pokerDecimals{
@@ -95,30 +128,199 @@ func parsePokerDecimals(sb, bb, minBI, maxBI string) (pokerDecimals, error) {
 	}, nil
 }
 
+func gameTypeOrDefault(gameType string) domain.GameType {
+	if gameType == "" {
+		return domain.GameHoldem
+	}
+	return domain.GameType(gameType)
+}
+
+// checkStudTable applies the rules of seven card stud: fixed-limit
+// betting, at most eight seats, and a per-player ante with a bring-in in
+// place of blinds and straddles.
+func checkStudTable(limit domain.BettingLimit, maxPlayers int, f pokerForcedBets) error {
+	if limit != domain.LimitFixed {
+		return domain.NewValidationError("betting_limit", "stud is played fixed-limit")
+	}
+	if maxPlayers > 8 {
+		return domain.NewValidationError("max_players", "stud seats at most 8 players")
+	}
+	if f.straddle != domain.StraddleNone || f.blindType != domain.BlindsStandard || f.anteType == domain.AnteBigBlind {
+		return domain.NewValidationError("game_type", "stud uses a per-player ante and a bring-in instead of blinds")
+	}
+	return nil
+}
+
+func limitOrDefault(limit string, gameType domain.GameType) domain.BettingLimit {
+	if limit == "" {
+		return gameType.DefaultLimit()
+	}
+	return domain.BettingLimit(limit)
+}
+
+type pokerForcedBets struct {
+	ante      decimal.Decimal
+	anteType  domain.AnteType
+	straddle  domain.StraddleType
+	blindType domain.BlindType
+}
+
+// parsePokerForcedBets reads the optional ante, straddle and blind
+// settings. An ante amount needs an ante type and the other way round.
+// Straddles need the usual blinds, and ante-only tables need everyone to
+// ante.
+func parsePokerForcedBets(ante, anteType, straddle, blindType string) (pokerForcedBets, error) {
+	f := pokerForcedBets{
+		ante:      decimal.Zero,
+		anteType:  domain.AnteNone,
+		straddle:  domain.StraddleNone,
+		blindType: domain.BlindsStandard,
+	}
+	if anteType != "" {
+		f.anteType = domain.AnteType(anteType)
+	}
+	if straddle != "" {
+		f.straddle = domain.StraddleType(straddle)
+	}
+	if blindType != "" {
+		f.blindType = domain.BlindType(blindType)
+	}
+
+	if ante != "" {
+		amount, err := decimal.NewFromString(ante)
+		if err != nil || amount.IsNegative() {
+			return pokerForcedBets{}, domain.NewValidationError("ante", "invalid ante")
+		}
+		f.ante = amount
+	}
+
+	if f.anteType == domain.AnteNone && f.ante.IsPositive() {
+		return pokerForcedBets{}, domain.NewValidationError("ante_type", "ante_type is required with an ante")
+	}
+	if f.anteType != domain.AnteNone && !f.ante.IsPositive() {
+		return pokerForcedBets{}, domain.NewValidationError("ante", "ante must be positive")
+	}
+	if f.blindType != domain.BlindsStandard && f.straddle != domain.StraddleNone {
+		return pokerForcedBets{}, domain.NewValidationError("straddle", "straddles need the usual blinds")
+	}
+	if f.blindType == domain.BlindsAnteOnly && f.anteType != domain.AntePerPlayer {
+		return pokerForcedBets{}, domain.NewValidationError("ante_type", "ante-only tables need a per-player ante")
+	}
+
+	return f, nil
+}
+
+type pokerRake struct {
+	percent      decimal.Decimal
+	caps         []domain.RakeCap
+	noFlopNoDrop bool
+}
+
+// parsePokerRake reads the optional rake settings. Tables without a rake
+// percentage are not raked.
+func parsePokerRake(percent string, caps []rakeCapRequest, noFlopNoDrop bool) (pokerRake, error) {
+	r := pokerRake{percent: decimal.Zero, caps: []domain.RakeCap{}, noFlopNoDrop: noFlopNoDrop}
+
+	if percent != "" {
+		p, err := decimal.NewFromString(percent)
+		if err != nil || p.IsNegative() || p.GreaterThan(decimal.NewFromInt(100)) {
+			return pokerRake{}, domain.NewValidationError("rake_percent", "rake_percent must be between 0 and 100")
+		}
+		r.percent = p
+	}
+
+	for _, c := range caps {
+		amount, err := decimal.NewFromString(c.Amount)
+		if err != nil || amount.IsNegative() {
+			return pokerRake{}, domain.NewValidationError("rake_caps", "invalid rake cap amount")
+		}
+		r.caps = append(r.caps, domain.RakeCap{MinPlayers: c.MinPlayers, Amount: amount})
+	}
+
+	return r, nil
+}
+
+// parseChipDenomination reads the table's smallest chip, a cent by
+// default. Blinds, ante and buy-ins must all be whole chips.
+func parseChipDenomination(value string, d pokerDecimals, f pokerForcedBets) (decimal.Decimal, error) {
+	table := domain.PokerTable{ChipDenomination: domain.DefaultChipDenomination}
+	if value != "" {
+		chip, err := decimal.NewFromString(value)
+		if err != nil || !chip.IsPositive() {
+			return decimal.Zero, domain.NewValidationError("chip_denomination", "chip_denomination must be positive")
+		}
+		table.ChipDenomination = chip
+	}
+
+	for _, amount := range []decimal.Decimal{d.smallBlind, d.bigBlind, f.ante, d.minBuyIn, d.maxBuyIn} {
+		if !table.IsWholeChips(amount) {
+			return decimal.Zero, domain.NewValidationError("chip_denomination", "blinds, ante and buy-ins must be whole chips")
+		}
+	}
+
+	return table.ChipDenomination, nil
+}
+
 func (h *AdminHandler) CreatePokerTable(c *gin.Context) {
 	var req createPokerTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: " + err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	d, err := parsePokerDecimals(req.SmallBlind, req.BigBlind, req.MinBuyIn, req.MaxBuyIn)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	f, err := parsePokerForcedBets(req.Ante, req.AnteType, req.Straddle, req.BlindType)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	rake, err := parsePokerRake(req.RakePercent, req.RakeCaps, req.NoFlopNoDrop)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	chip, err := parseChipDenomination(req.ChipDenomination, d, f)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
+	gameType := gameTypeOrDefault(req.GameType)
+	limit := limitOrDefault(req.Limit, gameType)
+	if gameType == domain.GameStud {
+		if err := checkStudTable(limit, req.MaxPlayers, f); err != nil {
+			respondError(c, err)
+			return
+		}
+	}
+
 	table, err := h.pokerRepo.Create(c.Request.Context(), domain.PokerTable{
 		Name:       req.Name,
+		GameType:   gameType,
+		Limit:      limit,
 		SmallBlind: d.smallBlind,
 		BigBlind:   d.bigBlind,
+		Ante:       f.ante,
+		AnteType:   f.anteType,
+		Straddle:   f.straddle,
+		BlindType:  f.blindType,
 		MinBuyIn:   d.minBuyIn,
 		MaxBuyIn:   d.maxBuyIn,
 		MaxPlayers: req.MaxPlayers,
 		Status:     domain.TableStatusWaiting,
+
+		RakePercent:  rake.percent,
+		RakeCaps:     rake.caps,
+		NoFlopNoDrop: rake.noFlopNoDrop,
+
+		ChipDenomination: chip,
 	})
 	if err != nil {
 		respondError(c, err)
@@ -141,7 +343,7 @@ func (h *AdminHandler) ListPokerTables(c *gin.Context) {
 func (h *AdminHandler) GetPokerTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -157,34 +359,70 @@ func (h *AdminHandler) GetPokerTable(c *gin.Context) {
 func (h *AdminHandler) UpdatePokerTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
 	var req updatePokerTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: " + err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	d, err := parsePokerDecimals(req.SmallBlind, req.BigBlind, req.MinBuyIn, req.MaxBuyIn)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	f, err := parsePokerForcedBets(req.Ante, req.AnteType, req.Straddle, req.BlindType)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	rake, err := parsePokerRake(req.RakePercent, req.RakeCaps, req.NoFlopNoDrop)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	chip, err := parseChipDenomination(req.ChipDenomination, d, f)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
+	gameType := gameTypeOrDefault(req.GameType)
+	limit := limitOrDefault(req.Limit, gameType)
+	if gameType == domain.GameStud {
+		if err := checkStudTable(limit, req.MaxPlayers, f); err != nil {
+			respondError(c, err)
+			return
+		}
+	}
+
 	table, err := h.pokerRepo.Update(c.Request.Context(), domain.PokerTable{
 		ID:         id,
 		Name:       req.Name,
+		GameType:   gameType,
+		Limit:      limit,
 		SmallBlind: d.smallBlind,
 		BigBlind:   d.bigBlind,
+		Ante:       f.ante,
+		AnteType:   f.anteType,
+		Straddle:   f.straddle,
+		BlindType:  f.blindType,
 		MinBuyIn:   d.minBuyIn,
 		MaxBuyIn:   d.maxBuyIn,
 		MaxPlayers: req.MaxPlayers,
 		Status:     domain.TableStatus(req.Status),
+
+		RakePercent:  rake.percent,
+		RakeCaps:     rake.caps,
+		NoFlopNoDrop: rake.noFlopNoDrop,
+
+		ChipDenomination: chip,
 	})
 	if err != nil {
 		respondError(c, err)
@@ -194,10 +432,75 @@ func (h *AdminHandler) UpdatePokerTable(c *gin.Context) {
 	respondSuccess(c, http.StatusOK, table)
 }
 
+// RakeReport sums the rake taken by table and day. from and to are UTC
+// dates, both included; the report covers the last 30 days by default
+// and can be narrowed to one table with table_id.
+func (h *AdminHandler) RakeReport(c *gin.Context) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	filter := domain.RakeFilter{
+		From: today.AddDate(0, 0, -29),
+		To:   today.AddDate(0, 0, 1),
+	}
+
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			respondInvalid(c, "from", "from must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.From = day
+	}
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			respondInvalid(c, "to", "to must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+	if !filter.From.Before(filter.To) {
+		respondInvalid(c, "from", "from must not be after to")
+		return
+	}
+	if tableID := c.Query("table_id"); tableID != "" {
+		id, err := uuid.Parse(tableID)
+		if err != nil {
+			respondInvalid(c, "table_id", "invalid table id")
+			return
+		}
+		filter.TableID = &id
+	}
+
+	rows, err := h.rakeRepo.Report(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	balance, err := h.rakeRepo.HouseBalance(c.Request.Context(), domain.HouseRakeAccount)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	report := domain.RakeReport{
+		From:         filter.From,
+		To:           filter.To,
+		Total:        decimal.Zero,
+		HouseBalance: balance,
+		Rows:         rows,
+	}
+	for _, row := range rows {
+		report.Total = report.Total.Add(row.Amount)
+	}
+
+	respondSuccess(c, http.StatusOK, report)
+}
+
 func (h *AdminHandler) DeletePokerTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -227,10 +530,7 @@ type updateRouletteTableRequest struct {
 func (h *AdminHandler) CreateRouletteTable(c *gin.Context) {
 	var req createRouletteTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: " + err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -261,7 +561,7 @@ func (h *AdminHandler) ListRouletteTables(c *gin.Context) {
 func (h *AdminHandler) GetRouletteTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -277,16 +577,13 @@ func (h *AdminHandler) GetRouletteTable(c *gin.Context) {
 func (h *AdminHandler) UpdateRouletteTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
 	var req updateRouletteTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: " + err.Error(),
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -308,7 +605,7 @@ func (h *AdminHandler) UpdateRouletteTable(c *gin.Context) {
 func (h *AdminHandler) DeleteRouletteTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
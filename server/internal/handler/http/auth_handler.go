@@ -30,10 +30,7 @@ type loginRequest struct {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req registerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: check required fields and format",
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -49,14 +46,14 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req loginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: check required fields and format",
-		})
+		respondBindError(c, err)
 		return
 	}
 
-	tokenPair, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	tokenPair, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, domain.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	})
 	if err != nil {
 		respondError(c, err)
 		return
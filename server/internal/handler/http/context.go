@@ -1,31 +1,42 @@
 package http
 
 import (
-	"net/http"
+	"fmt"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/handler/http/middleware"
 )
 
 func getUserID(c *gin.Context) (uuid.UUID, bool) {
 	val, exists := c.Get(middleware.ContextKeyUserID)
 	if !exists {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, Response{
-			Success: false,
-			Error:   "unauthorized",
-		})
+		abortWithError(c, domain.ErrUnauthorized)
 		return uuid.UUID{}, false
 	}
 
 	userID, ok := val.(uuid.UUID)
 	if !ok {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, Response{
-			Success: false,
-			Error:   "internal server error",
-		})
+		abortWithError(c, fmt.Errorf("unexpected %T in request context", val))
 		return uuid.UUID{}, false
 	}
 
 	return userID, true
 }
+
+func getSessionID(c *gin.Context) (uuid.UUID, bool) {
+	val, exists := c.Get(middleware.ContextKeySessionID)
+	if !exists {
+		abortWithError(c, domain.ErrUnauthorized)
+		return uuid.UUID{}, false
+	}
+
+	sessionID, ok := val.(uuid.UUID)
+	if !ok {
+		abortWithError(c, fmt.Errorf("unexpected %T in request context", val))
+		return uuid.UUID{}, false
+	}
+
+	return sessionID, true
+}
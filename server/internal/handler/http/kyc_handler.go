@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+)
+
+type KYCHandler struct {
+	kycService ports.KYCService
+}
+
+func NewKYCHandler(kycService ports.KYCService) *KYCHandler {
+	return &KYCHandler{kycService: kycService}
+}
+
+func (h *KYCHandler) GetMine(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	overview, err := h.kycService.GetOverview(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, overview)
+}
+
+// Submit expects a multipart form with a "level" field and one file per
+// document, keyed by document type, e.g. "passport" or "proof_of_address".
+func (h *KYCHandler) Submit(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	level, err := strconv.Atoi(c.PostForm("level"))
+	if err != nil {
+		respondInvalid(c, "level", "level is required")
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		respondInvalid(c, "body", "multipart form expected")
+		return
+	}
+
+	var uploads []domain.KYCUpload
+	for _, docType := range domain.KYCDocumentTypes {
+		for _, fh := range form.File[string(docType)] {
+			f, err := fh.Open()
+			if err != nil {
+				respondInvalid(c, "documents", "invalid document upload")
+				return
+			}
+			defer f.Close()
+			uploads = append(uploads, domain.KYCUpload{
+				Type:   docType,
+				Upload: domain.Upload{Reader: f, Size: fh.Size},
+			})
+		}
+	}
+
+	req, err := h.kycService.Submit(c.Request.Context(), userID, domain.KYCLevel(level), uploads)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, req)
+}
+
+func (h *KYCHandler) ListRequests(c *gin.Context) {
+	status := domain.KYCStatus(c.DefaultQuery("status", string(domain.KYCStatusPending)))
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	requests, err := h.kycService.ListRequests(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, requests)
+}
+
+func (h *KYCHandler) GetRequest(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid request id")
+		return
+	}
+
+	req, err := h.kycService.GetRequest(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, req)
+}
+
+type reviewKYCRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve reject request_info"`
+	Note     string `json:"note" binding:"max=1000"`
+}
+
+func (h *KYCHandler) Review(c *gin.Context) {
+	reviewerID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid request id")
+		return
+	}
+
+	var req reviewKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.kycService.Review(c.Request.Context(), reviewerID, id, domain.KYCDecision(req.Decision), req.Note)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, result)
+}
+
+func (h *KYCHandler) GetDocument(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid document id")
+		return
+	}
+
+	doc, r, err := h.kycService.OpenDocument(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer r.Close()
+
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, doc.Size, doc.ContentType, r, nil)
+}
@@ -4,25 +4,20 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 )
 
 func Admin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		val, exists := c.Get(ContextKeyIsAdmin)
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"success": false,
-				"error":   "forbidden: admin access required",
-			})
+			abort(c, http.StatusForbidden, domain.CodeForbidden, "forbidden: admin access required")
 			return
 		}
 
 		isAdmin, ok := val.(bool)
 		if !ok || !isAdmin {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"success": false,
-				"error":   "forbidden: admin access required",
-			})
+			abort(c, http.StatusForbidden, domain.CodeForbidden, "forbidden: admin access required")
 			return
 		}
 
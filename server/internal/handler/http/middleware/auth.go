@@ -1,51 +1,68 @@
 package middleware
 
 import (
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 )
 
 const (
-	ContextKeyUserID   = "user_id"
-	ContextKeyUsername = "username"
-	ContextKeyIsAdmin  = "is_admin"
+	ContextKeyUserID    = "user_id"
+	ContextKeyUsername  = "username"
+	ContextKeyIsAdmin   = "is_admin"
+	ContextKeySessionID = "session_id"
+	ContextKeyClaims    = "claims"
 )
 
 func Auth(authService ports.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		header := c.GetHeader("Authorization")
 		if header == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "missing authorization header",
-			})
+			abort(c, http.StatusUnauthorized, domain.CodeUnauthorized, "missing authorization header")
 			return
 		}
 
+		// API keys are sent the same way as JWTs and told apart by their
+		// prefix.
 		token, found := strings.CutPrefix(header, "Bearer ")
 		if !found || token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "invalid authorization format",
-			})
+			abort(c, http.StatusUnauthorized, domain.CodeUnauthorized, "invalid authorization format")
 			return
 		}
 
-		claims, err := authService.ValidateToken(token)
+		claims, err := authService.Authenticate(c.Request.Context(), token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"error":   "invalid or expired token",
-			})
+			info := domain.DescribeError(err)
+			abort(c, info.Status, info.Code, info.Message)
 			return
 		}
 
 		c.Set(ContextKeyUserID, claims.UserID)
 		c.Set(ContextKeyUsername, claims.Username)
 		c.Set(ContextKeyIsAdmin, claims.IsAdmin)
+		c.Set(ContextKeySessionID, claims.SessionID)
+		c.Set(ContextKeyClaims, claims)
 		c.Next()
+
+		if claims.IsAPIKey() {
+			err := authService.RecordAPIKeyUsage(c.Request.Context(), domain.APIKeyUsage{
+				APIKeyID:  claims.APIKeyID,
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				IPAddress: c.ClientIP(),
+				Status:    c.Writer.Status(),
+			})
+			if err != nil {
+				slog.Error("failed to record api key usage", "api_key_id", claims.APIKeyID, "error", err)
+			}
+		}
 	}
 }
+
+func abort(c *gin.Context, status int, code domain.ErrorCode, message string) {
+	c.AbortWithStatusJSON(status, domain.NewErrorBody(domain.ErrorInfo{Code: code, Message: message}))
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// RateLimit lets each user make burst requests at once and one more every
+// interval after that, rejecting the rest. It must run after Auth.
+func RateLimit(interval time.Duration, burst int) gin.HandlerFunc {
+	l := &rateLimiter{interval: interval, burst: burst, buckets: make(map[string]*tokenBucket)}
+	return func(c *gin.Context) {
+		key := c.ClientIP()
+		if claims, ok := claimsFrom(c); ok {
+			key = claims.UserID.String()
+		}
+
+		if wait := l.take(key, time.Now()); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			abort(c, http.StatusTooManyRequests, domain.CodeRateLimited, "too many requests, try again later")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type rateLimiter struct {
+	interval time.Duration
+	burst    int
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// take spends a token from key's bucket, returning how long until one is
+// free if there is none.
+func (l *rateLimiter) take(key string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = min(float64(l.burst), b.tokens+float64(now.Sub(b.last))/float64(l.interval))
+	b.last = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) * float64(l.interval))
+	}
+	b.tokens--
+	return 0
+}
+
+// sweep drops the buckets that have filled up again, so idle users cost
+// nothing.
+func (l *rateLimiter) sweep(now time.Time) {
+	full := l.interval * time.Duration(l.burst)
+	if now.Sub(l.lastSweep) < full {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, key)
+		}
+	}
+}
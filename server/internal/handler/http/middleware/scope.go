@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// RequireScope rejects API keys that were not granted scope. Session
+// tokens always pass.
+func RequireScope(scope domain.APIKeyScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := claimsFrom(c)
+		if !ok || !claims.HasScope(scope) {
+			abort(c, http.StatusForbidden, domain.CodeForbidden, "forbidden: api key lacks the "+string(scope)+" scope")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// SessionOnly rejects API keys altogether. It guards account management,
+// so a leaked key cannot be used to mint new keys or change credentials.
+func SessionOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := claimsFrom(c)
+		if !ok || claims.IsAPIKey() {
+			abort(c, http.StatusForbidden, domain.CodeForbidden, "forbidden: not available to api keys")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func claimsFrom(c *gin.Context) (domain.TokenClaims, bool) {
+	val, exists := c.Get(ContextKeyClaims)
+	if !exists {
+		return domain.TokenClaims{}, false
+	}
+
+	claims, ok := val.(domain.TokenClaims)
+	return claims, ok
+}
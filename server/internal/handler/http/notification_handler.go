@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+)
+
+type NotificationHandler struct {
+	notificationService ports.NotificationService
+}
+
+func NewNotificationHandler(notificationService ports.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	notifications, err := h.notificationService.List(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, notifications)
+}
+
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid notification id")
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "notification marked as read"})
+}
@@ -5,15 +5,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 )
 
 type PokerHandler struct {
-	pokerService ports.PokerService
+	pokerService  ports.PokerService
+	equityService ports.EquityService
 }
 
-func NewPokerHandler(pokerService ports.PokerService) *PokerHandler {
-	return &PokerHandler{pokerService: pokerService}
+func NewPokerHandler(pokerService ports.PokerService, equityService ports.EquityService) *PokerHandler {
+	return &PokerHandler{pokerService: pokerService, equityService: equityService}
 }
 
 func (h *PokerHandler) ListTables(c *gin.Context) {
@@ -29,7 +31,7 @@ func (h *PokerHandler) ListTables(c *gin.Context) {
 func (h *PokerHandler) GetTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -55,16 +57,13 @@ func (h *PokerHandler) JoinTable(c *gin.Context) {
 
 	tableID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
 	var req joinTableRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request body",
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -85,7 +84,7 @@ func (h *PokerHandler) LeaveTable(c *gin.Context) {
 
 	tableID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -97,10 +96,63 @@ func (h *PokerHandler) LeaveTable(c *gin.Context) {
 	respondSuccess(c, http.StatusOK, gin.H{"message": "left table"})
 }
 
+func (h *PokerHandler) SitOut(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	tableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid table id")
+		return
+	}
+
+	if err := h.pokerService.SitOut(c.Request.Context(), tableID, userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "sitting out"})
+}
+
+type sitInRequest struct {
+	WaitForBigBlind bool `json:"wait_for_big_blind"`
+}
+
+func (h *PokerHandler) SitIn(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	tableID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid table id")
+		return
+	}
+
+	// The body is optional; without one the player posts any missed blinds.
+	var req sitInRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err)
+			return
+		}
+	}
+
+	if err := h.pokerService.SitIn(c.Request.Context(), tableID, userID, req.WaitForBigBlind); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "sitting in"})
+}
+
 func (h *PokerHandler) GetTableState(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -112,3 +164,58 @@ func (h *PokerHandler) GetTableState(c *gin.Context) {
 
 	respondSuccess(c, http.StatusOK, state)
 }
+
+type equityRequest struct {
+	GameType string   `json:"game_type" binding:"omitempty,oneof=holdem plo plo8 short_deck"`
+	Ranges   []string `json:"ranges" binding:"required,min=2,max=9"`
+	Board    []string `json:"board" binding:"max=5"`
+	Dead     []string `json:"dead"`
+	Method   string   `json:"method" binding:"omitempty,oneof=exact monte_carlo"`
+	Trials   int      `json:"trials" binding:"min=0,max=1000000"`
+}
+
+func (h *PokerHandler) CalculateEquity(c *gin.Context) {
+	var req equityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	board, err := parseCardList(req.Board)
+	if err != nil {
+		respondInvalid(c, "board", err.Error())
+		return
+	}
+	dead, err := parseCardList(req.Dead)
+	if err != nil {
+		respondInvalid(c, "dead", err.Error())
+		return
+	}
+
+	result, err := h.equityService.CalculateEquity(c.Request.Context(), domain.EquityRequest{
+		GameType: gameTypeOrDefault(req.GameType),
+		Ranges:   req.Ranges,
+		Board:    board,
+		Dead:     dead,
+		Method:   domain.EquityMethod(req.Method),
+		Trials:   req.Trials,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, result)
+}
+
+func parseCardList(cards []string) ([]domain.Card, error) {
+	parsed := make([]domain.Card, len(cards))
+	for i, s := range cards {
+		card, err := domain.ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = card
+	}
+	return parsed, nil
+}
@@ -2,16 +2,20 @@ package http
 
 import (
 	"errors"
-	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/jokeoa/goigaming/internal/core/domain"
 )
 
+// Response is the envelope of a successful request. Failed ones get a
+// domain.ErrorBody.
 type Response struct {
-	Success bool   `json:"success"`
-	Data    any    `json:"data,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Success bool `json:"success"`
+	Data    any  `json:"data,omitempty"`
 }
 
 func respondSuccess(c *gin.Context, status int, data any) {
@@ -21,69 +25,79 @@ func respondSuccess(c *gin.Context, status int, data any) {
 	})
 }
 
+// respondError writes err using the status, code and message registered
+// for it in the domain error registry.
 func respondError(c *gin.Context, err error) {
-	status, message := mapDomainError(err)
-	c.JSON(status, Response{
-		Success: false,
-		Error:   message,
+	info := domain.DescribeError(err)
+	c.JSON(info.Status, domain.NewErrorBody(info))
+}
+
+func abortWithError(c *gin.Context, err error) {
+	c.Abort()
+	respondError(c, err)
+}
+
+// respondInvalid reports a single malformed field, such as a path
+// parameter that is not a UUID.
+func respondInvalid(c *gin.Context, field, message string) {
+	respondError(c, domain.NewValidationError(field, message))
+}
+
+// respondBindError turns a gin binding failure into a validation error
+// with one entry per offending field.
+func respondBindError(c *gin.Context, err error) {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		respondError(c, &domain.ValidationError{
+			Message: "invalid request body",
+			Fields:  map[string]string{"body": err.Error()},
+		})
+		return
+	}
+
+	fields := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		fields[fe.Field()] = describeFieldError(fe)
+	}
+
+	respondError(c, &domain.ValidationError{Fields: fields})
+}
+
+// useJSONFieldNames makes validation errors name fields after their json
+// tag, or form tag for multipart requests, so details match the request.
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		for _, key := range []string{"json", "form"} {
+			if name, _, _ := strings.Cut(f.Tag.Get(key), ","); name != "" && name != "-" {
+				return name
+			}
+		}
+		return f.Name
 	})
 }
 
-func mapDomainError(err error) (int, string) {
-	switch {
-	case errors.Is(err, domain.ErrUserNotFound):
-		return http.StatusNotFound, "user not found"
-	case errors.Is(err, domain.ErrUserAlreadyExists):
-		return http.StatusConflict, "user already exists"
-	case errors.Is(err, domain.ErrInvalidCredentials):
-		return http.StatusUnauthorized, "invalid credentials"
-	case errors.Is(err, domain.ErrInvalidToken):
-		return http.StatusUnauthorized, "invalid or expired token"
-	case errors.Is(err, domain.ErrWalletNotFound):
-		return http.StatusNotFound, "wallet not found"
-	case errors.Is(err, domain.ErrInsufficientFunds):
-		return http.StatusUnprocessableEntity, "insufficient funds"
-	case errors.Is(err, domain.ErrInvalidAmount):
-		return http.StatusBadRequest, "amount must be greater than zero"
-	case errors.Is(err, domain.ErrTableNotFound):
-		return http.StatusNotFound, "table not found"
-	case errors.Is(err, domain.ErrTableFull):
-		return http.StatusConflict, "table is full"
-	case errors.Is(err, domain.ErrPlayerNotFound):
-		return http.StatusNotFound, "player not found"
-	case errors.Is(err, domain.ErrPlayerAlreadySeated):
-		return http.StatusConflict, "player already seated at this table"
-	case errors.Is(err, domain.ErrHandNotFound):
-		return http.StatusNotFound, "hand not found"
-	case errors.Is(err, domain.ErrNotPlayerTurn):
-		return http.StatusBadRequest, "not your turn"
-	case errors.Is(err, domain.ErrInvalidAction):
-		return http.StatusBadRequest, "invalid action"
-	case errors.Is(err, domain.ErrInvalidBetAmount):
-		return http.StatusBadRequest, "invalid bet amount"
-	case errors.Is(err, domain.ErrInsufficientStack):
-		return http.StatusBadRequest, "insufficient stack"
-	case errors.Is(err, domain.ErrGameNotStarted):
-		return http.StatusBadRequest, "game not started"
-	case errors.Is(err, domain.ErrInvalidBuyIn):
-		return http.StatusBadRequest, "invalid buy-in amount"
-	case errors.Is(err, domain.ErrSeatTaken):
-		return http.StatusConflict, "seat is taken"
-	case errors.Is(err, domain.ErrMinPlayersRequired):
-		return http.StatusBadRequest, "minimum 2 players required"
-	case errors.Is(err, domain.ErrForbidden):
-		return http.StatusForbidden, "forbidden"
-	case errors.Is(err, domain.ErrRoundNotFound):
-		return http.StatusNotFound, "round not found"
-	case errors.Is(err, domain.ErrBettingClosed):
-		return http.StatusUnprocessableEntity, "betting is closed"
-	case errors.Is(err, domain.ErrInvalidBetType):
-		return http.StatusBadRequest, "invalid bet type"
-	case errors.Is(err, domain.ErrBetAmountOutOfRange):
-		return http.StatusBadRequest, "bet amount out of range"
-	case errors.Is(err, domain.ErrTableNotActive):
-		return http.StatusBadRequest, "table is not active"
+func describeFieldError(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gtfield":
+		return "must be greater than " + fe.Param()
+	case "oneof":
+		return "must be one of: " + fe.Param()
 	default:
-		return http.StatusInternalServerError, "internal server error"
+		return "is invalid (" + fe.Tag() + ")"
 	}
 }
@@ -30,7 +30,7 @@ func (h *RouletteHandler) ListTables(c *gin.Context) {
 func (h *RouletteHandler) GetTable(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -46,7 +46,7 @@ func (h *RouletteHandler) GetTable(c *gin.Context) {
 func (h *RouletteHandler) GetCurrentRound(c *gin.Context) {
 	tableID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -74,22 +74,19 @@ func (h *RouletteHandler) PlaceBet(c *gin.Context) {
 
 	tableID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
 	var req placeBetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request body",
-		})
+		respondBindError(c, err)
 		return
 	}
 
 	roundID, err := uuid.Parse(req.RoundID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid round_id"})
+		respondInvalid(c, "round_id", "invalid round_id")
 		return
 	}
 
@@ -108,7 +105,7 @@ func (h *RouletteHandler) PlaceBet(c *gin.Context) {
 func (h *RouletteHandler) GetRoundHistory(c *gin.Context) {
 	tableID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid table id"})
+		respondInvalid(c, "id", "invalid table id")
 		return
 	}
 
@@ -159,7 +156,7 @@ func (h *RouletteHandler) GetMyBets(c *gin.Context) {
 func (h *RouletteHandler) GetRound(c *gin.Context) {
 	roundID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, Response{Success: false, Error: "invalid round id"})
+		respondInvalid(c, "id", "invalid round id")
 		return
 	}
 
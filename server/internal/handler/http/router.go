@@ -1,7 +1,10 @@
 package http
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 	"github.com/jokeoa/goigaming/internal/handler/http/middleware"
 	wsHandler "github.com/jokeoa/goigaming/internal/handler/ws"
@@ -15,15 +18,21 @@ func NewRouter(
 	adminHandler *AdminHandler,
 	pokerHandler *PokerHandler,
 	rouletteHandler *RouletteHandler,
+	kycHandler *KYCHandler,
+	notificationHandler *NotificationHandler,
+	tournamentHandler *TournamentHandler,
 	ws *wsHandler.Handler,
+	uploadsDir string,
 ) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
+	useJSONFieldNames()
 	r := gin.New()
 	r.Use(gin.Recovery())
 
 	r.GET("/ws", ws.HandleConnection)
 
 	api := r.Group("/api/v1")
+	api.Static("/uploads", uploadsDir)
 
 	auth := api.Group("/auth")
 	{
@@ -34,44 +43,80 @@ func NewRouter(
 	protected := api.Group("")
 	protected.Use(middleware.Auth(authService))
 	{
+		read := middleware.RequireScope(domain.ScopeRead)
+		play := middleware.RequireScope(domain.ScopePlay)
+		walletScope := middleware.RequireScope(domain.ScopeWallet)
+
 		users := protected.Group("/users")
 		{
-			users.GET("/me", userHandler.GetMe)
+			users.GET("/me", read, userHandler.GetMe)
+
+			account := users.Group("/me", middleware.SessionOnly())
+			account.PATCH("", userHandler.UpdateMe)
+			account.POST("/email/verify", userHandler.VerifyEmail)
+			account.GET("/sessions", userHandler.ListSessions)
+			account.DELETE("/sessions/:id", userHandler.RevokeSession)
+			account.GET("/api-keys", userHandler.ListAPIKeys)
+			account.POST("/api-keys", userHandler.CreateAPIKey)
+			account.DELETE("/api-keys/:id", userHandler.RevokeAPIKey)
+			account.GET("/api-keys/:id/usage", userHandler.ListAPIKeyUsage)
+			account.GET("/kyc", kycHandler.GetMine)
+			account.POST("/kyc", kycHandler.Submit)
+			account.GET("/notifications", notificationHandler.List)
+			account.POST("/notifications/:id/read", notificationHandler.MarkRead)
 		}
 
 		wallet := protected.Group("/wallet")
 		{
-			wallet.GET("/balance", walletHandler.GetBalance)
-			wallet.POST("/deposit", walletHandler.Deposit)
-			wallet.POST("/withdraw", walletHandler.Withdraw)
-			wallet.GET("/transactions", walletHandler.GetTransactions)
+			wallet.GET("/balance", read, walletHandler.GetBalance)
+			wallet.POST("/deposit", walletScope, walletHandler.Deposit)
+			wallet.POST("/withdraw", walletScope, walletHandler.Withdraw)
+			wallet.GET("/transactions", read, walletHandler.GetTransactions)
 		}
 
 		poker := protected.Group("/poker/tables")
 		{
-			poker.GET("", pokerHandler.ListTables)
-			poker.GET("/:id", pokerHandler.GetTable)
-			poker.POST("/:id/join", pokerHandler.JoinTable)
-			poker.POST("/:id/leave", pokerHandler.LeaveTable)
-			poker.GET("/:id/state", pokerHandler.GetTableState)
+			poker.GET("", read, pokerHandler.ListTables)
+			poker.GET("/:id", read, pokerHandler.GetTable)
+			poker.POST("/:id/join", play, pokerHandler.JoinTable)
+			poker.POST("/:id/leave", play, pokerHandler.LeaveTable)
+			poker.POST("/:id/sit-out", play, pokerHandler.SitOut)
+			poker.POST("/:id/sit-in", play, pokerHandler.SitIn)
+			poker.GET("/:id/state", read, pokerHandler.GetTableState)
+		}
+
+		// Equity calculations are CPU-bound, so each user gets a few at a
+		// time.
+		pokerTools := protected.Group("/poker/tools", middleware.RateLimit(6*time.Second, 5))
+		{
+			pokerTools.POST("/equity", read, pokerHandler.CalculateEquity)
+		}
+
+		tournaments := protected.Group("/poker/tournaments")
+		{
+			tournaments.GET("", read, tournamentHandler.ListTournaments)
+			tournaments.GET("/:id", read, tournamentHandler.GetTournament)
+			tournaments.GET("/:id/standings", read, tournamentHandler.GetStandings)
+			tournaments.POST("/:id/register", play, tournamentHandler.Register)
+			tournaments.DELETE("/:id/register", play, tournamentHandler.Unregister)
 		}
 
 		roulette := protected.Group("/roulette")
 		{
 			tables := roulette.Group("/tables")
-			tables.GET("", rouletteHandler.ListTables)
-			tables.GET("/:id", rouletteHandler.GetTable)
-			tables.GET("/:id/current-round", rouletteHandler.GetCurrentRound)
-			tables.POST("/:id/bets", rouletteHandler.PlaceBet)
-			tables.GET("/:id/history", rouletteHandler.GetRoundHistory)
-
-			roulette.GET("/bets/me", rouletteHandler.GetMyBets)
-			roulette.GET("/rounds/:id", rouletteHandler.GetRound)
+			tables.GET("", read, rouletteHandler.ListTables)
+			tables.GET("/:id", read, rouletteHandler.GetTable)
+			tables.GET("/:id/current-round", read, rouletteHandler.GetCurrentRound)
+			tables.POST("/:id/bets", play, rouletteHandler.PlaceBet)
+			tables.GET("/:id/history", read, rouletteHandler.GetRoundHistory)
+
+			roulette.GET("/bets/me", read, rouletteHandler.GetMyBets)
+			roulette.GET("/rounds/:id", read, rouletteHandler.GetRound)
 		}
 	}
 
 	admin := api.Group("/admin")
-	admin.Use(middleware.Auth(authService), middleware.Admin())
+	admin.Use(middleware.Auth(authService), middleware.SessionOnly(), middleware.Admin())
 	{
 		pokerTables := admin.Group("/poker-tables")
 		{
@@ -82,6 +127,9 @@ func NewRouter(
 			pokerTables.DELETE("/:id", adminHandler.DeletePokerTable)
 		}
 
+		admin.POST("/tournaments", tournamentHandler.CreateTournament)
+		admin.GET("/rake", adminHandler.RakeReport)
+
 		rouletteTables := admin.Group("/roulette-tables")
 		{
 			rouletteTables.POST("", adminHandler.CreateRouletteTable)
@@ -90,6 +138,14 @@ func NewRouter(
 			rouletteTables.PUT("/:id", adminHandler.UpdateRouletteTable)
 			rouletteTables.DELETE("/:id", adminHandler.DeleteRouletteTable)
 		}
+
+		kyc := admin.Group("/kyc")
+		{
+			kyc.GET("/requests", kycHandler.ListRequests)
+			kyc.GET("/requests/:id", kycHandler.GetRequest)
+			kyc.POST("/requests/:id/review", kycHandler.Review)
+			kyc.GET("/documents/:id", kycHandler.GetDocument)
+		}
 	}
 
 	return r
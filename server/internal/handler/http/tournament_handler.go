@@ -0,0 +1,162 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+	"github.com/shopspring/decimal"
+)
+
+type TournamentHandler struct {
+	tournamentService ports.TournamentService
+}
+
+func NewTournamentHandler(tournamentService ports.TournamentService) *TournamentHandler {
+	return &TournamentHandler{tournamentService: tournamentService}
+}
+
+type createTournamentRequest struct {
+	Name          string              `json:"name" binding:"required"`
+	GameType      string              `json:"game_type" binding:"omitempty,oneof=holdem plo plo8 short_deck stud"`
+	Limit         string              `json:"betting_limit" binding:"omitempty,oneof=no_limit pot_limit fixed_limit"`
+	BuyIn         decimal.Decimal     `json:"buy_in" binding:"required"`
+	Fee           decimal.Decimal     `json:"fee"`
+	StartingChips decimal.Decimal     `json:"starting_chips" binding:"required"`
+	MaxPlayers    int                 `json:"max_players" binding:"required,min=2"`
+	Levels        []domain.BlindLevel `json:"levels" binding:"required,min=1"`
+	LevelSeconds  int                 `json:"level_seconds" binding:"required,min=1"`
+	// Payouts are percentages of the prize pool by finishing place.
+	Payouts []decimal.Decimal `json:"payouts"`
+
+	Kind                string     `json:"kind" binding:"omitempty,oneof=sit_and_go scheduled"`
+	StartsAt            *time.Time `json:"starts_at"`
+	RegistrationOpensAt *time.Time `json:"registration_opens_at"`
+	LateRegLevels       int        `json:"late_reg_levels" binding:"min=0"`
+	MaxReEntries        int        `json:"max_re_entries" binding:"min=0"`
+	TableSize           int        `json:"table_size" binding:"omitempty,min=2,max=9"`
+	BreakEvery          int        `json:"break_every" binding:"min=0"`
+	BreakSeconds        int        `json:"break_seconds" binding:"min=0"`
+}
+
+func (h *TournamentHandler) CreateTournament(c *gin.Context) {
+	var req createTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	t, err := h.tournamentService.CreateTournament(c.Request.Context(), domain.Tournament{
+		Name:          req.Name,
+		GameType:      gameTypeOrDefault(req.GameType),
+		Limit:         domain.BettingLimit(req.Limit),
+		BuyIn:         req.BuyIn,
+		Fee:           req.Fee,
+		StartingChips: req.StartingChips,
+		MaxPlayers:    req.MaxPlayers,
+		Levels:        req.Levels,
+		LevelSeconds:  req.LevelSeconds,
+		Payouts:       req.Payouts,
+
+		Kind:                domain.TournamentKind(req.Kind),
+		StartsAt:            req.StartsAt,
+		RegistrationOpensAt: req.RegistrationOpensAt,
+		LateRegLevels:       req.LateRegLevels,
+		MaxReEntries:        req.MaxReEntries,
+		TableSize:           req.TableSize,
+		BreakEvery:          req.BreakEvery,
+		BreakSeconds:        req.BreakSeconds,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, t)
+}
+
+func (h *TournamentHandler) ListTournaments(c *gin.Context) {
+	tournaments, err := h.tournamentService.ListTournaments(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, tournaments)
+}
+
+func (h *TournamentHandler) GetTournament(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid tournament id")
+		return
+	}
+
+	t, err := h.tournamentService.GetTournament(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, t)
+}
+
+func (h *TournamentHandler) GetStandings(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid tournament id")
+		return
+	}
+
+	standings, err := h.tournamentService.GetStandings(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, standings)
+}
+
+func (h *TournamentHandler) Register(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid tournament id")
+		return
+	}
+
+	entry, err := h.tournamentService.Register(c.Request.Context(), id, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, entry)
+}
+
+func (h *TournamentHandler) Unregister(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid tournament id")
+		return
+	}
+
+	if err := h.tournamentService.Unregister(c.Request.Context(), id, userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "unregistered"})
+}
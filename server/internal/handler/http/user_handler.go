@@ -2,17 +2,26 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 )
 
 type UserHandler struct {
 	userService ports.UserService
+	authService ports.AuthService
 }
 
-func NewUserHandler(userService ports.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService ports.UserService, authService ports.AuthService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		authService: authService,
+	}
 }
 
 func (h *UserHandler) GetMe(c *gin.Context) {
@@ -29,3 +38,234 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 
 	respondSuccess(c, http.StatusOK, profile)
 }
+
+// updateMeRequest is bound from JSON or, when an avatar is uploaded,
+// from multipart form fields.
+type updateMeRequest struct {
+	Username        *string `json:"username" form:"username" binding:"omitempty,min=3,max=100"`
+	Email           *string `json:"email" form:"email" binding:"omitempty,email"`
+	CurrentPassword string  `json:"current_password" form:"current_password"`
+	NewPassword     *string `json:"new_password" form:"new_password" binding:"omitempty,min=8,max=72"`
+}
+
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req updateMeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	if req.NewPassword != nil && req.CurrentPassword == "" {
+		respondInvalid(c, "current_password", "current_password is required to change password")
+		return
+	}
+
+	update := domain.ProfileUpdate{
+		Username: req.Username,
+		Email:    req.Email,
+	}
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		if fh, err := c.FormFile("avatar"); err == nil {
+			f, err := fh.Open()
+			if err != nil {
+				respondInvalid(c, "avatar", "invalid avatar upload")
+				return
+			}
+			defer f.Close()
+			update.Avatar = &domain.Upload{Reader: f, Size: fh.Size}
+		}
+	}
+
+	if req.NewPassword != nil {
+		sessionID, ok := getSessionID(c)
+		if !ok {
+			return
+		}
+		update.Password = &domain.PasswordChange{
+			Current:   req.CurrentPassword,
+			New:       *req.NewPassword,
+			SessionID: sessionID,
+		}
+	}
+
+	profile, err := h.userService.UpdateProfile(c.Request.Context(), userID, update)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req verifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	profile, err := h.userService.VerifyEmail(c.Request.Context(), userID, req.Token)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, profile)
+}
+
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, ok := getSessionID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, sessions)
+}
+
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid session id")
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "session revoked"})
+}
+
+type createAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=read wallet play"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (h *UserHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req createAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	scopes := make([]domain.APIKeyScope, len(req.Scopes))
+	for i, s := range req.Scopes {
+		scopes[i] = domain.APIKeyScope(s)
+	}
+
+	key, err := h.authService.CreateAPIKey(c.Request.Context(), userID, domain.NewAPIKey{
+		Name:      req.Name,
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusCreated, key)
+}
+
+func (h *UserHandler) ListAPIKeys(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.authService.ListAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, keys)
+}
+
+func (h *UserHandler) RevokeAPIKey(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid api key id")
+		return
+	}
+
+	if err := h.authService.RevokeAPIKey(c.Request.Context(), userID, keyID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, gin.H{"message": "api key revoked"})
+}
+
+func (h *UserHandler) ListAPIKeyUsage(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondInvalid(c, "id", "invalid api key id")
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	usage, err := h.authService.ListAPIKeyUsage(c.Request.Context(), userID, keyID, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondSuccess(c, http.StatusOK, usage)
+}
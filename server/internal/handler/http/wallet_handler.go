@@ -61,10 +61,7 @@ func (h *WalletHandler) Deposit(c *gin.Context) {
 
 	var req amountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: amount is required",
-		})
+		respondBindError(c, err)
 		return
 	}
 
@@ -85,14 +82,11 @@ func (h *WalletHandler) Withdraw(c *gin.Context) {
 
 	var req amountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, Response{
-			Success: false,
-			Error:   "invalid request: amount is required",
-		})
+		respondBindError(c, err)
 		return
 	}
 
-	wallet, err := h.walletService.Withdraw(c.Request.Context(), userID, req.Amount)
+	wallet, err := h.walletService.CashOut(c.Request.Context(), userID, req.Amount)
 	if err != nil {
 		respondError(c, err)
 		return
@@ -1,12 +1,16 @@
 package ws
 
 import (
+	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
 )
 
@@ -19,41 +23,49 @@ var upgrader = websocket.Upgrader{
 }
 
 type Handler struct {
-	hub     *Hub
-	authSvc ports.AuthService
-	logger  *slog.Logger
+	hub      *Hub
+	authSvc  ports.AuthService
+	pokerSvc ports.PokerService
+	logger   *slog.Logger
 }
 
-func NewHandler(hub *Hub, authSvc ports.AuthService, logger *slog.Logger) *Handler {
+func NewHandler(hub *Hub, authSvc ports.AuthService, pokerSvc ports.PokerService, logger *slog.Logger) *Handler {
 	return &Handler{
-		hub:     hub,
-		authSvc: authSvc,
-		logger:  logger,
+		hub:      hub,
+		authSvc:  authSvc,
+		pokerSvc: pokerSvc,
+		logger:   logger,
 	}
 }
 
 func (h *Handler) HandleConnection(c *gin.Context) {
 	token := c.Query("token")
 	if token == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+		respondError(c, http.StatusUnauthorized, domain.CodeUnauthorized, "missing token")
 		return
 	}
 
 	gameIDStr := c.Query("game_id")
 	if gameIDStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing game_id"})
+		respondError(c, http.StatusBadRequest, domain.CodeValidationFailed, "missing game_id")
 		return
 	}
 
-	claims, err := h.authSvc.ValidateToken(token)
+	claims, err := h.authSvc.Authenticate(c.Request.Context(), token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		info := domain.DescribeError(err)
+		respondError(c, info.Status, info.Code, info.Message)
+		return
+	}
+
+	if !claims.HasScope(domain.ScopeRead) && !claims.HasScope(domain.ScopePlay) {
+		respondError(c, http.StatusForbidden, domain.CodeForbidden, "api key lacks the read or play scope")
 		return
 	}
 
 	tableID, err := uuid.Parse(gameIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid game_id"})
+		respondError(c, http.StatusBadRequest, domain.CodeValidationFailed, "invalid game_id")
 		return
 	}
 
@@ -63,7 +75,30 @@ func (h *Handler) HandleConnection(c *gin.Context) {
 		return
 	}
 
-	h.hub.register(tableID, claims.UserID, conn)
+	h.hub.register(tableID, claims.UserID, claims.CredentialID(), conn)
+
+	if claims.IsAPIKey() {
+		err := h.authSvc.RecordAPIKeyUsage(c.Request.Context(), domain.APIKeyUsage{
+			APIKeyID:  claims.APIKeyID,
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			IPAddress: c.ClientIP(),
+			Status:    http.StatusSwitchingProtocols,
+		})
+		if err != nil {
+			h.logger.Error("failed to record api key usage", "api_key_id", claims.APIKeyID, "error", err)
+		}
+	}
+
+	// The credential is only checked on connect, so the connection must
+	// not outlive it.
+	if !claims.ExpiresAt.IsZero() {
+		expiry := time.AfterFunc(time.Until(claims.ExpiresAt), func() {
+			conn.Close()
+		})
+		defer expiry.Stop()
+	}
+
 	h.logger.Info("ws client connected",
 		"user_id", claims.UserID,
 		"username", claims.Username,
@@ -80,8 +115,72 @@ func (h *Handler) HandleConnection(c *gin.Context) {
 	}()
 
 	for {
-		if _, _, err := conn.ReadMessage(); err != nil {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
 			break
 		}
+		if err := h.handleMessage(c.Request.Context(), claims, tableID, data); err != nil {
+			h.sendError(tableID, claims.UserID, err)
+		}
+	}
+}
+
+func (h *Handler) handleMessage(ctx context.Context, claims domain.TokenClaims, tableID uuid.UUID, data []byte) error {
+	var msg domain.WSMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return domain.NewValidationError("message", "message must be a JSON object with a type")
+	}
+
+	if !claims.HasScope(domain.ScopePlay) {
+		return domain.ErrForbidden
+	}
+
+	switch msg.Type {
+	case domain.WSMsgSitOut:
+		return h.pokerSvc.SitOut(ctx, tableID, claims.UserID)
+	case domain.WSMsgSitIn:
+		var payload domain.WSSitIn
+		if len(msg.Payload) > 0 {
+			if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+				return domain.NewValidationError("payload", "invalid sit_in payload")
+			}
+		}
+		return h.pokerSvc.SitIn(ctx, tableID, claims.UserID, payload.WaitForBigBlind)
+	case domain.WSMsgPreAction:
+		var payload domain.WSPreActionRequest
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return domain.NewValidationError("payload", "invalid pre_action payload")
+		}
+		return h.pokerSvc.SetPreAction(ctx, tableID, claims.UserID, payload.Action)
+	case domain.WSMsgRunItTwice:
+		var payload domain.WSRunItTwiceRequest
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			return domain.NewValidationError("payload", "invalid run_it_twice payload")
+		}
+		return h.pokerSvc.RunItTwice(ctx, tableID, claims.UserID, payload.Agree)
+	case domain.WSMsgShowCards:
+		return h.pokerSvc.ShowCards(ctx, tableID, claims.UserID)
+	case domain.WSMsgMuckCards:
+		return h.pokerSvc.MuckCards(ctx, tableID, claims.UserID)
+	default:
+		return domain.NewValidationError("type", "unsupported message type")
+	}
+}
+
+func (h *Handler) sendError(tableID, userID uuid.UUID, err error) {
+	wsErr := domain.NewWSError(err)
+	if wsErr.Code == domain.CodeInternal {
+		h.logger.Error("ws message failed", "user_id", userID, "table_id", tableID, "error", err)
 	}
+
+	payload, mErr := json.Marshal(wsErr)
+	if mErr != nil {
+		h.logger.Error("failed to marshal ws error", "error", mErr)
+		return
+	}
+	h.hub.SendToPlayer(tableID, userID, domain.WSMessage{Type: domain.WSMsgError, Payload: payload})
+}
+
+func respondError(c *gin.Context, status int, code domain.ErrorCode, message string) {
+	c.JSON(status, domain.NewErrorBody(domain.ErrorInfo{Code: code, Message: message}))
 }
@@ -11,9 +11,10 @@ import (
 )
 
 type client struct {
-	ws     *websocket.Conn
-	userID uuid.UUID
-	mu     sync.Mutex
+	ws        *websocket.Conn
+	userID    uuid.UUID
+	sessionID uuid.UUID
+	mu        sync.Mutex
 }
 
 func (c *client) write(data []byte) error {
@@ -23,7 +24,7 @@ func (c *client) write(data []byte) error {
 }
 
 // Hub manages WebSocket connections grouped by table.
-// It implements ports.Broadcaster.
+// It implements ports.Broadcaster and ports.SessionTerminator.
 type Hub struct {
 	mu     sync.RWMutex
 	tables map[uuid.UUID]map[uuid.UUID]*client
@@ -37,7 +38,7 @@ func NewHub(logger *slog.Logger) *Hub {
 	}
 }
 
-func (h *Hub) register(tableID, userID uuid.UUID, ws *websocket.Conn) {
+func (h *Hub) register(tableID, userID, sessionID uuid.UUID, ws *websocket.Conn) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -49,7 +50,26 @@ func (h *Hub) register(tableID, userID uuid.UUID, ws *websocket.Conn) {
 		existing.ws.Close()
 	}
 
-	h.tables[tableID][userID] = &client{ws: ws, userID: userID}
+	h.tables[tableID][userID] = &client{ws: ws, userID: userID, sessionID: sessionID}
+}
+
+// CloseSession drops every connection opened with the given session or
+// API key. The read loops of the closed connections unregister them.
+func (h *Hub) CloseSession(sessionID uuid.UUID) {
+	h.mu.RLock()
+	var targets []*client
+	for _, conns := range h.tables {
+		for _, c := range conns {
+			if c.sessionID == sessionID {
+				targets = append(targets, c)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		c.ws.Close()
+	}
 }
 
 func (h *Hub) unregister(tableID, userID uuid.UUID) {
@@ -68,6 +88,10 @@ func (h *Hub) unregister(tableID, userID uuid.UUID) {
 }
 
 func (h *Hub) BroadcastToTable(tableID uuid.UUID, msg domain.WSMessage) {
+	h.BroadcastToTableExcept(tableID, uuid.Nil, msg)
+}
+
+func (h *Hub) BroadcastToTableExcept(tableID, exceptUserID uuid.UUID, msg domain.WSMessage) {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		h.logger.Error("failed to marshal ws message", "error", err)
@@ -76,8 +100,10 @@ func (h *Hub) BroadcastToTable(tableID uuid.UUID, msg domain.WSMessage) {
 
 	h.mu.RLock()
 	targets := make([]*client, 0, len(h.tables[tableID]))
-	for _, c := range h.tables[tableID] {
-		targets = append(targets, c)
+	for userID, c := range h.tables[tableID] {
+		if userID != exceptUserID {
+			targets = append(targets, c)
+		}
 	}
 	h.mu.RUnlock()
 
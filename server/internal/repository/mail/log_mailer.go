@@ -0,0 +1,21 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer writes outgoing mail to the log instead of delivering it.
+// It stands in for a real provider in development.
+type LogMailer struct {
+	logger *slog.Logger
+}
+
+func NewLogMailer(logger *slog.Logger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(_ context.Context, to, subject, body string) error {
+	m.logger.Info("outgoing email", "to", to, "subject", subject, "body", body)
+	return nil
+}
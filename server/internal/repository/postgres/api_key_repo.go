@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type APIKeyRepository struct {
+	db DBTX
+}
+
+func NewAPIKeyRepository(db DBTX) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at`
+
+func scanAPIKey(row pgx.Row) (domain.APIKey, error) {
+	var k domain.APIKey
+	var scopes []string
+	err := row.Scan(
+		&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash, &scopes,
+		&k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt,
+	)
+	if err != nil {
+		return k, err
+	}
+
+	for _, s := range scopes {
+		k.Scopes = append(k.Scopes, domain.APIKeyScope(s))
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key domain.APIKey) (domain.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + apiKeyColumns
+
+	scopes := make([]string, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = string(s)
+	}
+
+	created, err := scanAPIKey(r.db.QueryRow(ctx, query,
+		key.UserID, key.Name, key.Prefix, key.KeyHash, scopes, key.ExpiresAt,
+	))
+	if err != nil {
+		return created, fmt.Errorf("APIKeyRepository.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return key, domain.ErrAPIKeyNotFound
+		}
+		return key, fmt.Errorf("APIKeyRepository.FindByHash: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) FindByID(ctx context.Context, id, userID uuid.UUID) (domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1 AND user_id = $2`
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return key, domain.ErrAPIKeyNotFound
+		}
+		return key, fmt.Errorf("APIKeyRepository.FindByID: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("APIKeyRepository.FindByUserID: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []domain.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("APIKeyRepository.FindByUserID scan: %w", err)
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, rows.Err()
+}
+
+func (r *APIKeyRepository) CountActiveByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	var n int
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("APIKeyRepository.CountActiveByUserID: %w", err)
+	}
+
+	return n, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("APIKeyRepository.Revoke: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// RecordUsage appends to the key's usage log and bumps last_used_at.
+func (r *APIKeyRepository) RecordUsage(ctx context.Context, usage domain.APIKeyUsage) error {
+	query := `
+		WITH touched AS (
+			UPDATE api_keys SET last_used_at = NOW() WHERE id = $1
+		)
+		INSERT INTO api_key_usage (api_key_id, method, path, ip_address, status)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	if _, err := r.db.Exec(ctx, query,
+		usage.APIKeyID, usage.Method, usage.Path, usage.IPAddress, usage.Status,
+	); err != nil {
+		return fmt.Errorf("APIKeyRepository.RecordUsage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) FindUsage(ctx context.Context, keyID uuid.UUID, limit, offset int) ([]domain.APIKeyUsage, error) {
+	query := `
+		SELECT id, api_key_id, method, path, ip_address, status, created_at
+		FROM api_key_usage
+		WHERE api_key_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, keyID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("APIKeyRepository.FindUsage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []domain.APIKeyUsage
+	for rows.Next() {
+		var u domain.APIKeyUsage
+		if err := rows.Scan(&u.ID, &u.APIKeyID, &u.Method, &u.Path, &u.IPAddress, &u.Status, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("APIKeyRepository.FindUsage scan: %w", err)
+		}
+		usage = append(usage, u)
+	}
+
+	return usage, rows.Err()
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type AuditLogRepository struct {
+	db DBTX
+}
+
+func NewAuditLogRepository(db DBTX) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, entry domain.AuditLog) (domain.AuditLog, error) {
+	query := `
+		INSERT INTO audit_logs (actor_id, action, entity_type, entity_id, details)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, actor_id, action, entity_type, entity_id, details, created_at
+	`
+
+	details := entry.Details
+	if len(details) == 0 {
+		details = []byte("{}")
+	}
+
+	var a domain.AuditLog
+	err := r.db.QueryRow(ctx, query, entry.ActorID, entry.Action, entry.EntityType, entry.EntityID, details).Scan(
+		&a.ID, &a.ActorID, &a.Action, &a.EntityType, &a.EntityID, &a.Details, &a.CreatedAt,
+	)
+	if err != nil {
+		return a, fmt.Errorf("AuditLogRepository.Create: %w", err)
+	}
+
+	return a, nil
+}
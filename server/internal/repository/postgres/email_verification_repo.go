@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type EmailVerificationRepository struct {
+	db DBTX
+}
+
+func NewEmailVerificationRepository(db DBTX) *EmailVerificationRepository {
+	return &EmailVerificationRepository{db: db}
+}
+
+func (r *EmailVerificationRepository) Upsert(ctx context.Context, v domain.EmailVerification) (domain.EmailVerification, error) {
+	query := `
+		INSERT INTO email_verifications (user_id, email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET email = EXCLUDED.email, token_hash = EXCLUDED.token_hash,
+		    expires_at = EXCLUDED.expires_at, created_at = NOW()
+		RETURNING user_id, email, token_hash, expires_at, created_at
+	`
+
+	var ev domain.EmailVerification
+	err := r.db.QueryRow(ctx, query, v.UserID, v.Email, v.TokenHash, v.ExpiresAt).Scan(
+		&ev.UserID, &ev.Email, &ev.TokenHash, &ev.ExpiresAt, &ev.CreatedAt,
+	)
+	if err != nil {
+		return ev, fmt.Errorf("EmailVerificationRepository.Upsert: %w", err)
+	}
+
+	return ev, nil
+}
+
+func (r *EmailVerificationRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (domain.EmailVerification, error) {
+	query := `
+		SELECT user_id, email, token_hash, expires_at, created_at
+		FROM email_verifications
+		WHERE user_id = $1
+	`
+
+	var ev domain.EmailVerification
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&ev.UserID, &ev.Email, &ev.TokenHash, &ev.ExpiresAt, &ev.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ev, domain.ErrInvalidVerificationToken
+		}
+		return ev, fmt.Errorf("EmailVerificationRepository.FindByUserID: %w", err)
+	}
+
+	return ev, nil
+}
+
+func (r *EmailVerificationRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM email_verifications WHERE user_id = $1`
+
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("EmailVerificationRepository.DeleteByUserID: %w", err)
+	}
+
+	return nil
+}
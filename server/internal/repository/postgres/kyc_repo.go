@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type KYCRepository struct {
+	db DBTX
+}
+
+func NewKYCRepository(db DBTX) *KYCRepository {
+	return &KYCRepository{db: db}
+}
+
+const kycRequestColumns = `id, user_id, requested_level, status, reviewer_id, review_note, created_at, updated_at, reviewed_at`
+
+func scanKYCRequest(row pgx.Row) (domain.KYCRequest, error) {
+	var r domain.KYCRequest
+	err := row.Scan(
+		&r.ID, &r.UserID, &r.RequestedLevel, &r.Status, &r.ReviewerID, &r.ReviewNote,
+		&r.CreatedAt, &r.UpdatedAt, &r.ReviewedAt,
+	)
+	return r, err
+}
+
+func (r *KYCRepository) CreateRequest(ctx context.Context, req domain.KYCRequest) (domain.KYCRequest, error) {
+	query := `
+		INSERT INTO kyc_requests (user_id, requested_level, status)
+		VALUES ($1, $2, $3)
+		RETURNING ` + kycRequestColumns
+
+	created, err := scanKYCRequest(r.db.QueryRow(ctx, query, req.UserID, req.RequestedLevel, req.Status))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return created, domain.ErrKYCRequestPending
+		}
+		return created, fmt.Errorf("KYCRepository.CreateRequest: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *KYCRepository) FindRequestByID(ctx context.Context, id uuid.UUID) (domain.KYCRequest, error) {
+	query := `SELECT ` + kycRequestColumns + ` FROM kyc_requests WHERE id = $1`
+
+	req, err := scanKYCRequest(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return req, domain.ErrKYCRequestNotFound
+		}
+		return req, fmt.Errorf("KYCRepository.FindRequestByID: %w", err)
+	}
+
+	return req, nil
+}
+
+func (r *KYCRepository) FindLatestRequestByUserID(ctx context.Context, userID uuid.UUID) (domain.KYCRequest, error) {
+	query := `
+		SELECT ` + kycRequestColumns + `
+		FROM kyc_requests
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	req, err := scanKYCRequest(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return req, domain.ErrKYCRequestNotFound
+		}
+		return req, fmt.Errorf("KYCRepository.FindLatestRequestByUserID: %w", err)
+	}
+
+	return req, nil
+}
+
+// FindRequestsByStatus returns the least recently updated requests first,
+// so the review queue is worked in the order requests became ready.
+func (r *KYCRepository) FindRequestsByStatus(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]domain.KYCRequest, error) {
+	query := `
+		SELECT ` + kycRequestColumns + `
+		FROM kyc_requests
+		WHERE status = $1
+		ORDER BY updated_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("KYCRepository.FindRequestsByStatus: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []domain.KYCRequest
+	for rows.Next() {
+		req, err := scanKYCRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("KYCRepository.FindRequestsByStatus scan: %w", err)
+		}
+		requests = append(requests, req)
+	}
+
+	return requests, rows.Err()
+}
+
+// UpdateRequest stores a status change of an open request. Closed requests
+// are never modified and yield ErrKYCRequestClosed.
+func (r *KYCRepository) UpdateRequest(ctx context.Context, req domain.KYCRequest) (domain.KYCRequest, error) {
+	query := `
+		UPDATE kyc_requests
+		SET status = $1, reviewer_id = $2, review_note = $3, reviewed_at = $4, updated_at = NOW()
+		WHERE id = $5 AND status IN ('pending', 'info_requested')
+		RETURNING ` + kycRequestColumns
+
+	updated, err := scanKYCRequest(r.db.QueryRow(ctx, query,
+		req.Status, req.ReviewerID, req.ReviewNote, req.ReviewedAt, req.ID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return updated, domain.ErrKYCRequestClosed
+		}
+		return updated, fmt.Errorf("KYCRepository.UpdateRequest: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *KYCRepository) CreateDocument(ctx context.Context, doc domain.KYCDocument) (domain.KYCDocument, error) {
+	query := `
+		INSERT INTO kyc_documents (request_id, type, url, content_type, size)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, request_id, type, url, content_type, size, created_at
+	`
+
+	var d domain.KYCDocument
+	err := r.db.QueryRow(ctx, query, doc.RequestID, doc.Type, doc.URL, doc.ContentType, doc.Size).Scan(
+		&d.ID, &d.RequestID, &d.Type, &d.URL, &d.ContentType, &d.Size, &d.CreatedAt,
+	)
+	if err != nil {
+		return d, fmt.Errorf("KYCRepository.CreateDocument: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *KYCRepository) FindDocumentByID(ctx context.Context, id uuid.UUID) (domain.KYCDocument, error) {
+	query := `
+		SELECT id, request_id, type, url, content_type, size, created_at
+		FROM kyc_documents
+		WHERE id = $1
+	`
+
+	var d domain.KYCDocument
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&d.ID, &d.RequestID, &d.Type, &d.URL, &d.ContentType, &d.Size, &d.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return d, domain.ErrKYCDocumentNotFound
+		}
+		return d, fmt.Errorf("KYCRepository.FindDocumentByID: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *KYCRepository) FindDocumentsByRequestID(ctx context.Context, requestID uuid.UUID) ([]domain.KYCDocument, error) {
+	query := `
+		SELECT id, request_id, type, url, content_type, size, created_at
+		FROM kyc_documents
+		WHERE request_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("KYCRepository.FindDocumentsByRequestID: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []domain.KYCDocument
+	for rows.Next() {
+		var d domain.KYCDocument
+		if err := rows.Scan(
+			&d.ID, &d.RequestID, &d.Type, &d.URL, &d.ContentType, &d.Size, &d.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("KYCRepository.FindDocumentsByRequestID scan: %w", err)
+		}
+		docs = append(docs, d)
+	}
+
+	return docs, rows.Err()
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type NotificationRepository struct {
+	db DBTX
+}
+
+func NewNotificationRepository(db DBTX) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, n domain.Notification) (domain.Notification, error) {
+	query := `
+		INSERT INTO notifications (user_id, type, message)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, type, message, read_at, created_at
+	`
+
+	var created domain.Notification
+	err := r.db.QueryRow(ctx, query, n.UserID, n.Type, n.Message).Scan(
+		&created.ID, &created.UserID, &created.Type, &created.Message, &created.ReadAt, &created.CreatedAt,
+	)
+	if err != nil {
+		return created, fmt.Errorf("NotificationRepository.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Notification, error) {
+	query := `
+		SELECT id, user_id, type, message, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationRepository.FindByUserID: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []domain.Notification
+	for rows.Next() {
+		var n domain.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.Message, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("NotificationRepository.FindByUserID scan: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("NotificationRepository.MarkRead: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotificationNotFound
+	}
+
+	return nil
+}
@@ -22,7 +22,7 @@ func (r *PokerHandRepository) Create(ctx context.Context, hand domain.PokerHand)
 	query := `
 		INSERT INTO poker_hands (table_id, hand_number, pot, community_cards, stage)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at
+		RETURNING id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at, rake, second_board, winners
 	`
 
 	var h domain.PokerHand
@@ -30,7 +30,7 @@ func (r *PokerHandRepository) Create(ctx context.Context, hand domain.PokerHand)
 		hand.TableID, hand.HandNumber, hand.Pot, hand.CommunityCards, hand.Stage,
 	).Scan(
 		&h.ID, &h.TableID, &h.HandNumber, &h.Pot, &h.CommunityCards,
-		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt,
+		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt, &h.Rake, &h.SecondBoard, &h.Winners,
 	)
 	if err != nil {
 		return h, fmt.Errorf("PokerHandRepository.Create: %w", err)
@@ -41,7 +41,7 @@ func (r *PokerHandRepository) Create(ctx context.Context, hand domain.PokerHand)
 
 func (r *PokerHandRepository) FindByID(ctx context.Context, id uuid.UUID) (domain.PokerHand, error) {
 	query := `
-		SELECT id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at
+		SELECT id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at, rake, second_board, winners
 		FROM poker_hands
 		WHERE id = $1
 	`
@@ -49,7 +49,7 @@ func (r *PokerHandRepository) FindByID(ctx context.Context, id uuid.UUID) (domai
 	var h domain.PokerHand
 	err := r.db.QueryRow(ctx, query, id).Scan(
 		&h.ID, &h.TableID, &h.HandNumber, &h.Pot, &h.CommunityCards,
-		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt,
+		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt, &h.Rake, &h.SecondBoard, &h.Winners,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -64,17 +64,17 @@ func (r *PokerHandRepository) FindByID(ctx context.Context, id uuid.UUID) (domai
 func (r *PokerHandRepository) Update(ctx context.Context, hand domain.PokerHand) (domain.PokerHand, error) {
 	query := `
 		UPDATE poker_hands
-		SET pot = $1, community_cards = $2, stage = $3, winner_id = $4, ended_at = $5
-		WHERE id = $6
-		RETURNING id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at
+		SET pot = $1, community_cards = $2, stage = $3, winner_id = $4, ended_at = $5, rake = $6, second_board = $7, winners = $8
+		WHERE id = $9
+		RETURNING id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at, rake, second_board, winners
 	`
 
 	var h domain.PokerHand
 	err := r.db.QueryRow(ctx, query,
-		hand.Pot, hand.CommunityCards, hand.Stage, hand.WinnerID, hand.EndedAt, hand.ID,
+		hand.Pot, hand.CommunityCards, hand.Stage, hand.WinnerID, hand.EndedAt, hand.Rake, hand.SecondBoard, handWinners(hand.Winners), hand.ID,
 	).Scan(
 		&h.ID, &h.TableID, &h.HandNumber, &h.Pot, &h.CommunityCards,
-		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt,
+		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt, &h.Rake, &h.SecondBoard, &h.Winners,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -88,7 +88,7 @@ func (r *PokerHandRepository) Update(ctx context.Context, hand domain.PokerHand)
 
 func (r *PokerHandRepository) FindLatestByTableID(ctx context.Context, tableID uuid.UUID) (domain.PokerHand, error) {
 	query := `
-		SELECT id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at
+		SELECT id, table_id, hand_number, pot, community_cards, stage, winner_id, started_at, ended_at, rake, second_board, winners
 		FROM poker_hands
 		WHERE table_id = $1
 		ORDER BY hand_number DESC
@@ -98,7 +98,7 @@ func (r *PokerHandRepository) FindLatestByTableID(ctx context.Context, tableID u
 	var h domain.PokerHand
 	err := r.db.QueryRow(ctx, query, tableID).Scan(
 		&h.ID, &h.TableID, &h.HandNumber, &h.Pot, &h.CommunityCards,
-		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt,
+		&h.Stage, &h.WinnerID, &h.StartedAt, &h.EndedAt, &h.Rake, &h.SecondBoard, &h.Winners,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -227,3 +227,11 @@ func (r *PokerHandRepository) FindActionsByHandID(ctx context.Context, handID uu
 
 	return actions, rows.Err()
 }
+
+// handWinners stores a hand with no winners as an empty list, not null.
+func handWinners(winners []domain.WinnerInfo) []domain.WinnerInfo {
+	if winners == nil {
+		return []domain.WinnerInfo{}
+	}
+	return winners
+}
@@ -150,6 +150,16 @@ func (r *PokerPlayerRepository) UpdateStatus(ctx context.Context, playerID uuid.
 	return nil
 }
 
+func (r *PokerPlayerRepository) UpdateUsernameByUserID(ctx context.Context, userID uuid.UUID, username string) error {
+	query := `UPDATE poker_players SET username = $1 WHERE user_id = $2`
+
+	if _, err := r.db.Exec(ctx, query, username, userID); err != nil {
+		return fmt.Errorf("PokerPlayerRepository.UpdateUsernameByUserID: %w", err)
+	}
+
+	return nil
+}
+
 func (r *PokerPlayerRepository) Delete(ctx context.Context, playerID uuid.UUID) error {
 	query := `DELETE FROM poker_players WHERE id = $1`
 
@@ -20,18 +20,22 @@ func NewPokerTableRepository(db DBTX) *PokerTableRepository {
 
 func (r *PokerTableRepository) Create(ctx context.Context, table domain.PokerTable) (domain.PokerTable, error) {
 	query := `
-		INSERT INTO poker_tables (name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status, created_at
+		INSERT INTO poker_tables (name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, tournament_id,
+		                          rake_percent, rake_caps, no_flop_no_drop, chip_denomination)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id, name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, created_at, tournament_id,
+		          rake_percent, rake_caps, no_flop_no_drop, chip_denomination
 	`
 
 	var t domain.PokerTable
 	err := r.db.QueryRow(ctx, query,
-		table.Name, table.SmallBlind, table.BigBlind,
-		table.MinBuyIn, table.MaxBuyIn, table.MaxPlayers, table.Status,
+		table.Name, table.GameType, table.Limit, table.SmallBlind, table.BigBlind, table.Ante, table.AnteType, table.Straddle, table.BlindType,
+		table.MinBuyIn, table.MaxBuyIn, table.MaxPlayers, table.Status, table.TournamentID,
+		table.RakePercent, rakeCaps(table.RakeCaps), table.NoFlopNoDrop, table.ChipUnit(),
 	).Scan(
-		&t.ID, &t.Name, &t.SmallBlind, &t.BigBlind,
-		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt,
+		&t.ID, &t.Name, &t.GameType, &t.Limit, &t.SmallBlind, &t.BigBlind, &t.Ante, &t.AnteType, &t.Straddle, &t.BlindType,
+		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt, &t.TournamentID,
+		&t.RakePercent, &t.RakeCaps, &t.NoFlopNoDrop, &t.ChipDenomination,
 	)
 	if err != nil {
 		return t, fmt.Errorf("PokerTableRepository.Create: %w", err)
@@ -42,15 +46,17 @@ func (r *PokerTableRepository) Create(ctx context.Context, table domain.PokerTab
 
 func (r *PokerTableRepository) FindByID(ctx context.Context, id uuid.UUID) (domain.PokerTable, error) {
 	query := `
-		SELECT id, name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status, created_at
+		SELECT id, name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, created_at, tournament_id,
+		       rake_percent, rake_caps, no_flop_no_drop, chip_denomination
 		FROM poker_tables
 		WHERE id = $1
 	`
 
 	var t domain.PokerTable
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&t.ID, &t.Name, &t.SmallBlind, &t.BigBlind,
-		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt,
+		&t.ID, &t.Name, &t.GameType, &t.Limit, &t.SmallBlind, &t.BigBlind, &t.Ante, &t.AnteType, &t.Straddle, &t.BlindType,
+		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt, &t.TournamentID,
+		&t.RakePercent, &t.RakeCaps, &t.NoFlopNoDrop, &t.ChipDenomination,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -64,9 +70,10 @@ func (r *PokerTableRepository) FindByID(ctx context.Context, id uuid.UUID) (doma
 
 func (r *PokerTableRepository) FindActive(ctx context.Context) ([]domain.PokerTable, error) {
 	query := `
-		SELECT id, name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status, created_at
+		SELECT id, name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, created_at, tournament_id,
+		       rake_percent, rake_caps, no_flop_no_drop, chip_denomination
 		FROM poker_tables
-		WHERE status != 'closed'
+		WHERE status != 'closed' AND tournament_id IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -80,8 +87,9 @@ func (r *PokerTableRepository) FindActive(ctx context.Context) ([]domain.PokerTa
 	for rows.Next() {
 		var t domain.PokerTable
 		if err := rows.Scan(
-			&t.ID, &t.Name, &t.SmallBlind, &t.BigBlind,
-			&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt,
+			&t.ID, &t.Name, &t.GameType, &t.Limit, &t.SmallBlind, &t.BigBlind, &t.Ante, &t.AnteType, &t.Straddle, &t.BlindType,
+			&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt, &t.TournamentID,
+			&t.RakePercent, &t.RakeCaps, &t.NoFlopNoDrop, &t.ChipDenomination,
 		); err != nil {
 			return nil, fmt.Errorf("PokerTableRepository.FindActive scan: %w", err)
 		}
@@ -93,7 +101,8 @@ func (r *PokerTableRepository) FindActive(ctx context.Context) ([]domain.PokerTa
 
 func (r *PokerTableRepository) FindAll(ctx context.Context) ([]domain.PokerTable, error) {
 	query := `
-		SELECT id, name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status, created_at
+		SELECT id, name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, created_at, tournament_id,
+		       rake_percent, rake_caps, no_flop_no_drop, chip_denomination
 		FROM poker_tables
 		ORDER BY created_at DESC
 	`
@@ -108,8 +117,9 @@ func (r *PokerTableRepository) FindAll(ctx context.Context) ([]domain.PokerTable
 	for rows.Next() {
 		var t domain.PokerTable
 		if err := rows.Scan(
-			&t.ID, &t.Name, &t.SmallBlind, &t.BigBlind,
-			&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt,
+			&t.ID, &t.Name, &t.GameType, &t.Limit, &t.SmallBlind, &t.BigBlind, &t.Ante, &t.AnteType, &t.Straddle, &t.BlindType,
+			&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt, &t.TournamentID,
+			&t.RakePercent, &t.RakeCaps, &t.NoFlopNoDrop, &t.ChipDenomination,
 		); err != nil {
 			return nil, fmt.Errorf("PokerTableRepository.FindAll scan: %w", err)
 		}
@@ -122,19 +132,23 @@ func (r *PokerTableRepository) FindAll(ctx context.Context) ([]domain.PokerTable
 func (r *PokerTableRepository) Update(ctx context.Context, table domain.PokerTable) (domain.PokerTable, error) {
 	query := `
 		UPDATE poker_tables
-		SET name = $1, small_blind = $2, big_blind = $3, min_buy_in = $4, max_buy_in = $5,
-		    max_players = $6, status = $7
-		WHERE id = $8
-		RETURNING id, name, small_blind, big_blind, min_buy_in, max_buy_in, max_players, status, created_at
+		SET name = $1, game_type = $2, betting_limit = $3, small_blind = $4, big_blind = $5, ante = $6, ante_type = $7,
+		    straddle = $8, blind_type = $9, min_buy_in = $10, max_buy_in = $11, max_players = $12, status = $13,
+		    rake_percent = $14, rake_caps = $15, no_flop_no_drop = $16, chip_denomination = $17
+		WHERE id = $18
+		RETURNING id, name, game_type, betting_limit, small_blind, big_blind, ante, ante_type, straddle, blind_type, min_buy_in, max_buy_in, max_players, status, created_at, tournament_id,
+		          rake_percent, rake_caps, no_flop_no_drop, chip_denomination
 	`
 
 	var t domain.PokerTable
 	err := r.db.QueryRow(ctx, query,
-		table.Name, table.SmallBlind, table.BigBlind,
-		table.MinBuyIn, table.MaxBuyIn, table.MaxPlayers, table.Status, table.ID,
+		table.Name, table.GameType, table.Limit, table.SmallBlind, table.BigBlind, table.Ante, table.AnteType, table.Straddle, table.BlindType,
+		table.MinBuyIn, table.MaxBuyIn, table.MaxPlayers, table.Status,
+		table.RakePercent, rakeCaps(table.RakeCaps), table.NoFlopNoDrop, table.ChipUnit(), table.ID,
 	).Scan(
-		&t.ID, &t.Name, &t.SmallBlind, &t.BigBlind,
-		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt,
+		&t.ID, &t.Name, &t.GameType, &t.Limit, &t.SmallBlind, &t.BigBlind, &t.Ante, &t.AnteType, &t.Straddle, &t.BlindType,
+		&t.MinBuyIn, &t.MaxBuyIn, &t.MaxPlayers, &t.Status, &t.CreatedAt, &t.TournamentID,
+		&t.RakePercent, &t.RakeCaps, &t.NoFlopNoDrop, &t.ChipDenomination,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -157,3 +171,11 @@ func (r *PokerTableRepository) UpdateStatus(ctx context.Context, id uuid.UUID, s
 	}
 	return nil
 }
+
+// rakeCaps stores a table without caps as an empty list rather than null.
+func rakeCaps(caps []domain.RakeCap) []domain.RakeCap {
+	if caps == nil {
+		return []domain.RakeCap{}
+	}
+	return caps
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+type RakeRepository struct {
+	db DBTX
+}
+
+func NewRakeRepository(db DBTX) *RakeRepository {
+	return &RakeRepository{db: db}
+}
+
+// Credit records the rake from a hand and adds it to the house rake
+// account in the same statement.
+func (r *RakeRepository) Credit(ctx context.Context, entry domain.RakeEntry) (domain.RakeEntry, error) {
+	query := `
+		WITH entry AS (
+			INSERT INTO rake_entries (hand_id, table_id, amount)
+			VALUES ($1, $2, $3)
+			RETURNING id, hand_id, table_id, amount, created_at
+		), credit AS (
+			UPDATE house_accounts
+			SET balance = balance + $3, updated_at = NOW()
+			WHERE name = $4
+		)
+		SELECT id, hand_id, table_id, amount, created_at FROM entry
+	`
+
+	var e domain.RakeEntry
+	err := r.db.QueryRow(ctx, query, entry.HandID, entry.TableID, entry.Amount, domain.HouseRakeAccount).Scan(
+		&e.ID, &e.HandID, &e.TableID, &e.Amount, &e.CreatedAt,
+	)
+	if err != nil {
+		return e, fmt.Errorf("RakeRepository.Credit: %w", err)
+	}
+
+	return e, nil
+}
+
+// Report sums the rake by table and UTC day over [From, To).
+func (r *RakeRepository) Report(ctx context.Context, filter domain.RakeFilter) ([]domain.RakeReportRow, error) {
+	query := `
+		SELECT e.table_id, t.name, date_trunc('day', e.created_at AT TIME ZONE 'UTC') AS day,
+		       COUNT(*), SUM(e.amount)
+		FROM rake_entries e
+		JOIN poker_tables t ON t.id = e.table_id
+		WHERE e.created_at >= $1 AND e.created_at < $2
+		  AND ($3::uuid IS NULL OR e.table_id = $3)
+		GROUP BY e.table_id, t.name, day
+		ORDER BY day, t.name
+	`
+
+	rows, err := r.db.Query(ctx, query, filter.From, filter.To, filter.TableID)
+	if err != nil {
+		return nil, fmt.Errorf("RakeRepository.Report: %w", err)
+	}
+	defer rows.Close()
+
+	var report []domain.RakeReportRow
+	for rows.Next() {
+		var row domain.RakeReportRow
+		if err := rows.Scan(&row.TableID, &row.TableName, &row.Day, &row.Hands, &row.Amount); err != nil {
+			return nil, fmt.Errorf("RakeRepository.Report scan: %w", err)
+		}
+		report = append(report, row)
+	}
+
+	return report, rows.Err()
+}
+
+func (r *RakeRepository) HouseBalance(ctx context.Context, account string) (decimal.Decimal, error) {
+	query := `SELECT balance FROM house_accounts WHERE name = $1`
+
+	var balance decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, account).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return decimal.Zero, nil
+		}
+		return decimal.Zero, fmt.Errorf("RakeRepository.HouseBalance: %w", err)
+	}
+
+	return balance, nil
+}
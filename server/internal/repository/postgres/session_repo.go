@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type SessionRepository struct {
+	db DBTX
+}
+
+func NewSessionRepository(db DBTX) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session domain.Session) (domain.Session, error) {
+	query := `
+		INSERT INTO sessions (user_id, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+	`
+
+	var s domain.Session
+	err := r.db.QueryRow(ctx, query,
+		session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt,
+	).Scan(
+		&s.ID, &s.UserID, &s.UserAgent, &s.IPAddress,
+		&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt,
+	)
+	if err != nil {
+		return s, fmt.Errorf("SessionRepository.Create: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *SessionRepository) FindByID(ctx context.Context, id uuid.UUID) (domain.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	var s domain.Session
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&s.ID, &s.UserID, &s.UserAgent, &s.IPAddress,
+		&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s, domain.ErrSessionNotFound
+		}
+		return s, fmt.Errorf("SessionRepository.FindByID: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *SessionRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("SessionRepository.FindActiveByUserID: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []domain.Session
+	for rows.Next() {
+		var s domain.Session
+		if err := rows.Scan(
+			&s.ID, &s.UserID, &s.UserAgent, &s.IPAddress,
+			&s.CreatedAt, &s.LastSeenAt, &s.ExpiresAt, &s.RevokedAt,
+		); err != nil {
+			return nil, fmt.Errorf("SessionRepository.FindActiveByUserID scan: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *SessionRepository) Touch(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE sessions SET last_seen_at = NOW() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("SessionRepository.Touch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	query := `UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("SessionRepository.Revoke: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllExcept(ctx context.Context, userID, exceptID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		UPDATE sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND id != $2 AND revoked_at IS NULL
+		RETURNING id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, exceptID)
+	if err != nil {
+		return nil, fmt.Errorf("SessionRepository.RevokeAllExcept: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("SessionRepository.RevokeAllExcept scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
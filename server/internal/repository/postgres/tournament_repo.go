@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+type TournamentRepository struct {
+	db DBTX
+}
+
+func NewTournamentRepository(db DBTX) *TournamentRepository {
+	return &TournamentRepository{db: db}
+}
+
+const tournamentColumns = `id, name, kind, game_type, betting_limit, buy_in, fee, starting_chips, max_players, levels, level_seconds, payouts, status, table_id, created_at, started_at, ended_at,
+	starts_at, registration_opens_at, late_reg_levels, max_re_entries, table_size, break_every, break_seconds`
+
+const entryColumns = `id, tournament_id, user_id, username, entry_number, position, prize, eliminated_at, created_at`
+
+func scanTournament(row pgx.Row) (domain.Tournament, error) {
+	var t domain.Tournament
+	err := row.Scan(
+		&t.ID, &t.Name, &t.Kind, &t.GameType, &t.Limit, &t.BuyIn, &t.Fee, &t.StartingChips, &t.MaxPlayers,
+		&t.Levels, &t.LevelSeconds, &t.Payouts, &t.Status, &t.TableID, &t.CreatedAt, &t.StartedAt, &t.EndedAt,
+		&t.StartsAt, &t.RegistrationOpensAt, &t.LateRegLevels, &t.MaxReEntries, &t.TableSize, &t.BreakEvery, &t.BreakSeconds,
+	)
+	return t, err
+}
+
+func scanEntry(row pgx.Row) (domain.TournamentEntry, error) {
+	var e domain.TournamentEntry
+	err := row.Scan(
+		&e.ID, &e.TournamentID, &e.UserID, &e.Username, &e.EntryNumber,
+		&e.Position, &e.Prize, &e.EliminatedAt, &e.CreatedAt,
+	)
+	return e, err
+}
+
+func (r *TournamentRepository) Create(ctx context.Context, t domain.Tournament) (domain.Tournament, error) {
+	query := `
+		INSERT INTO tournaments (name, kind, game_type, betting_limit, buy_in, fee, starting_chips, max_players, levels, level_seconds, payouts, status,
+		                         starts_at, registration_opens_at, late_reg_levels, max_re_entries, table_size, break_every, break_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING ` + tournamentColumns
+
+	created, err := scanTournament(r.db.QueryRow(ctx, query,
+		t.Name, t.Kind, t.GameType, t.Limit, t.BuyIn, t.Fee, t.StartingChips, t.MaxPlayers,
+		t.Levels, t.LevelSeconds, t.Payouts, t.Status,
+		t.StartsAt, t.RegistrationOpensAt, t.LateRegLevels, t.MaxReEntries, t.TableSize, t.BreakEvery, t.BreakSeconds,
+	))
+	if err != nil {
+		return created, fmt.Errorf("TournamentRepository.Create: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *TournamentRepository) FindByID(ctx context.Context, id uuid.UUID) (domain.Tournament, error) {
+	query := `SELECT ` + tournamentColumns + ` FROM tournaments WHERE id = $1`
+
+	t, err := scanTournament(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return t, domain.ErrTournamentNotFound
+		}
+		return t, fmt.Errorf("TournamentRepository.FindByID: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *TournamentRepository) FindByStatus(ctx context.Context, statuses ...domain.TournamentStatus) ([]domain.Tournament, error) {
+	query := `SELECT ` + tournamentColumns + ` FROM tournaments WHERE status = ANY($1) ORDER BY created_at DESC`
+
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+
+	rows, err := r.db.Query(ctx, query, names)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentRepository.FindByStatus: %w", err)
+	}
+	defer rows.Close()
+
+	var tournaments []domain.Tournament
+	for rows.Next() {
+		t, err := scanTournament(rows)
+		if err != nil {
+			return nil, fmt.Errorf("TournamentRepository.FindByStatus scan: %w", err)
+		}
+		tournaments = append(tournaments, t)
+	}
+
+	return tournaments, rows.Err()
+}
+
+func (r *TournamentRepository) Update(ctx context.Context, t domain.Tournament) (domain.Tournament, error) {
+	query := `
+		UPDATE tournaments
+		SET status = $1, table_id = $2, started_at = $3, ended_at = $4
+		WHERE id = $5
+		RETURNING ` + tournamentColumns
+
+	updated, err := scanTournament(r.db.QueryRow(ctx, query, t.Status, t.TableID, t.StartedAt, t.EndedAt, t.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return updated, domain.ErrTournamentNotFound
+		}
+		return updated, fmt.Errorf("TournamentRepository.Update: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *TournamentRepository) CreateEntry(ctx context.Context, e domain.TournamentEntry) (domain.TournamentEntry, error) {
+	query := `
+		INSERT INTO tournament_entries (tournament_id, user_id, username, entry_number)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tournament_id, user_id, entry_number) DO NOTHING
+		RETURNING ` + entryColumns
+
+	created, err := scanEntry(r.db.QueryRow(ctx, query, e.TournamentID, e.UserID, e.Username, e.EntryNumber))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return created, domain.ErrAlreadyRegistered
+		}
+		return created, fmt.Errorf("TournamentRepository.CreateEntry: %w", err)
+	}
+
+	return created, nil
+}
+
+func (r *TournamentRepository) FindEntries(ctx context.Context, tournamentID uuid.UUID) ([]domain.TournamentEntry, error) {
+	query := `SELECT ` + entryColumns + ` FROM tournament_entries WHERE tournament_id = $1 ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentRepository.FindEntries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.TournamentEntry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("TournamentRepository.FindEntries scan: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *TournamentRepository) UpdateEntry(ctx context.Context, e domain.TournamentEntry) error {
+	query := `
+		UPDATE tournament_entries
+		SET position = $1, prize = $2, eliminated_at = $3
+		WHERE id = $4
+	`
+
+	tag, err := r.db.Exec(ctx, query, e.Position, e.Prize, e.EliminatedAt, e.ID)
+	if err != nil {
+		return fmt.Errorf("TournamentRepository.UpdateEntry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotRegistered
+	}
+
+	return nil
+}
+
+func (r *TournamentRepository) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM tournament_entries WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("TournamentRepository.DeleteEntry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotRegistered
+	}
+
+	return nil
+}
@@ -3,8 +3,11 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
 )
 
 type TransactionRepository struct {
@@ -82,3 +85,20 @@ func (r *TransactionRepository) FindByWalletID(ctx context.Context, filter domai
 
 	return transactions, nil
 }
+
+// SumSince adds up the amounts of the wallet's transactions of one type
+// made at or after since.
+func (r *TransactionRepository) SumSince(ctx context.Context, walletID uuid.UUID, referenceType string, since time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE wallet_id = $1 AND reference_type = $2 AND created_at >= $3
+	`
+
+	var sum decimal.Decimal
+	if err := r.db.QueryRow(ctx, query, walletID, referenceType, since).Scan(&sum); err != nil {
+		return decimal.Zero, fmt.Errorf("TransactionRepository.SumSince: %w", err)
+	}
+
+	return sum, nil
+}
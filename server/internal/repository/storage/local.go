@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage keeps uploads on the local filesystem under dir and serves
+// them from baseURL, e.g. "/api/v1/uploads".
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage.NewLocalStorage: %w", err)
+	}
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStorage) Save(_ context.Context, key string, r io.Reader) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", fmt.Errorf("LocalStorage.Save: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("LocalStorage.Save mkdir: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("LocalStorage.Save create: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("LocalStorage.Save write: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("LocalStorage.Save close: %w", err)
+	}
+
+	return s.baseURL + "/" + filepath.ToSlash(filepath.Clean(key)), nil
+}
+
+func (s *LocalStorage) Open(_ context.Context, url string) (io.ReadCloser, error) {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok {
+		return nil, fmt.Errorf("LocalStorage.Open: unknown url %q", url)
+	}
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, fmt.Errorf("LocalStorage.Open: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("LocalStorage.Open: %w", err)
+	}
+
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, url string) error {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok {
+		return nil
+	}
+
+	path, err := s.path(key)
+	if err != nil {
+		return fmt.Errorf("LocalStorage.Delete: %w", err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("LocalStorage.Delete: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+	return filepath.Join(s.dir, cleaned), nil
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+const (
+	maxAPIKeysPerUser = 10
+
+	// apiKeyDisplayLength is how much of the key, prefix included, is kept
+	// in clear text so users can tell their keys apart.
+	apiKeyDisplayLength = 12
+)
+
+func (s *Service) CreateAPIKey(ctx context.Context, userID uuid.UUID, req domain.NewAPIKey) (domain.CreatedAPIKey, error) {
+	if len(req.Scopes) == 0 {
+		return domain.CreatedAPIKey{}, domain.ErrInvalidAPIKeyScope
+	}
+
+	scopes := make([]domain.APIKeyScope, 0, len(req.Scopes))
+	seen := make(map[domain.APIKeyScope]bool, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !scope.IsValid() {
+			return domain.CreatedAPIKey{}, domain.ErrInvalidAPIKeyScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return domain.CreatedAPIKey{}, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	n, err := s.apiKeyRepo.CountActiveByUserID(ctx, userID)
+	if err != nil {
+		return domain.CreatedAPIKey{}, fmt.Errorf("AuthService.CreateAPIKey: %w", err)
+	}
+	if n >= maxAPIKeysPerUser {
+		return domain.CreatedAPIKey{}, domain.ErrAPIKeyLimitReached
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.CreatedAPIKey{}, fmt.Errorf("AuthService.CreateAPIKey generate: %w", err)
+	}
+	raw := domain.APIKeyPrefix + hex.EncodeToString(secret)
+
+	key, err := s.apiKeyRepo.Create(ctx, domain.APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    raw[:apiKeyDisplayLength],
+		KeyHash:   hashAPIKey(raw),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		return domain.CreatedAPIKey{}, fmt.Errorf("AuthService.CreateAPIKey: %w", err)
+	}
+
+	return domain.CreatedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]domain.APIKey, error) {
+	keys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("AuthService.ListAPIKeys: %w", err)
+	}
+	return keys, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	if err := s.apiKeyRepo.Revoke(ctx, keyID, userID); err != nil {
+		return fmt.Errorf("AuthService.RevokeAPIKey: %w", err)
+	}
+
+	s.terminator.CloseSession(keyID)
+	return nil
+}
+
+func (s *Service) ListAPIKeyUsage(ctx context.Context, userID, keyID uuid.UUID, limit, offset int) ([]domain.APIKeyUsage, error) {
+	if _, err := s.apiKeyRepo.FindByID(ctx, keyID, userID); err != nil {
+		return nil, fmt.Errorf("AuthService.ListAPIKeyUsage: %w", err)
+	}
+
+	usage, err := s.apiKeyRepo.FindUsage(ctx, keyID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("AuthService.ListAPIKeyUsage: %w", err)
+	}
+	return usage, nil
+}
+
+func (s *Service) RecordAPIKeyUsage(ctx context.Context, usage domain.APIKeyUsage) error {
+	usage.Path = truncate(usage.Path, 255)
+	if err := s.apiKeyRepo.RecordUsage(ctx, usage); err != nil {
+		return fmt.Errorf("AuthService.RecordAPIKeyUsage: %w", err)
+	}
+	return nil
+}
+
+// authenticateAPIKey resolves an API key to claims. Keys never carry
+// admin rights, whatever the owner's role.
+func (s *Service) authenticateAPIKey(ctx context.Context, raw string) (domain.TokenClaims, error) {
+	key, err := s.apiKeyRepo.FindByHash(ctx, hashAPIKey(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return domain.TokenClaims{}, domain.ErrInvalidToken
+		}
+		return domain.TokenClaims{}, fmt.Errorf("AuthService.Authenticate: %w", err)
+	}
+
+	if !key.IsActive(time.Now()) {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, key.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.TokenClaims{}, domain.ErrInvalidToken
+		}
+		return domain.TokenClaims{}, fmt.Errorf("AuthService.Authenticate: %w", err)
+	}
+
+	claims := domain.TokenClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}
+	if key.ExpiresAt != nil {
+		claims.ExpiresAt = *key.ExpiresAt
+	}
+
+	return claims, nil
+}
+
+func hashAPIKey(raw string) string {
+	h := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(h[:])
+}
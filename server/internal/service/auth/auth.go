@@ -18,29 +18,41 @@ import (
 )
 
 type Service struct {
-	pool       *pgxpool.Pool
-	userRepo   ports.UserRepository
-	userFn     func(db postgres.DBTX) ports.UserRepository
-	walletFn   func(db postgres.DBTX) ports.WalletRepository
-	jwtSecret  []byte
-	tokenTTL   time.Duration
+	pool        *pgxpool.Pool
+	userRepo    ports.UserRepository
+	sessionRepo ports.SessionRepository
+	apiKeyRepo  ports.APIKeyRepository
+	userFn      func(db postgres.DBTX) ports.UserRepository
+	walletFn    func(db postgres.DBTX) ports.WalletRepository
+	sessionFn   func(db postgres.DBTX) ports.SessionRepository
+	terminator  ports.SessionTerminator
+	jwtSecret   []byte
+	tokenTTL    time.Duration
 }
 
 func NewService(
 	pool *pgxpool.Pool,
 	userRepo ports.UserRepository,
+	sessionRepo ports.SessionRepository,
+	apiKeyRepo ports.APIKeyRepository,
 	userFn func(db postgres.DBTX) ports.UserRepository,
 	walletFn func(db postgres.DBTX) ports.WalletRepository,
+	sessionFn func(db postgres.DBTX) ports.SessionRepository,
+	terminator ports.SessionTerminator,
 	jwtSecret string,
 	tokenTTL time.Duration,
 ) *Service {
 	return &Service{
-		pool:      pool,
-		userRepo:  userRepo,
-		userFn:    userFn,
-		walletFn:  walletFn,
-		jwtSecret: []byte(jwtSecret),
-		tokenTTL:  tokenTTL,
+		pool:        pool,
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		apiKeyRepo:  apiKeyRepo,
+		userFn:      userFn,
+		walletFn:    walletFn,
+		sessionFn:   sessionFn,
+		terminator:  terminator,
+		jwtSecret:   []byte(jwtSecret),
+		tokenTTL:    tokenTTL,
 	}
 }
 
@@ -83,7 +95,7 @@ func (s *Service) Register(ctx context.Context, username, email, password string
 	return user, nil
 }
 
-func (s *Service) Login(ctx context.Context, email, password string) (domain.TokenPair, error) {
+func (s *Service) Login(ctx context.Context, email, password string, client domain.ClientInfo) (domain.TokenPair, error) {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
@@ -96,19 +108,33 @@ func (s *Service) Login(ctx context.Context, email, password string) (domain.Tok
 		return domain.TokenPair{}, domain.ErrInvalidCredentials
 	}
 
-	now := time.Now()
+	session, err := s.sessionRepo.Create(ctx, domain.Session{
+		UserID:    user.ID,
+		UserAgent: truncate(client.UserAgent, maxUserAgentLength),
+		IPAddress: client.IPAddress,
+		ExpiresAt: time.Now().Add(s.tokenTTL),
+	})
+	if err != nil {
+		return domain.TokenPair{}, fmt.Errorf("AuthService.Login create session: %w", err)
+	}
+
+	return s.issueToken(user, session)
+}
+
+func (s *Service) issueToken(user domain.User, session domain.Session) (domain.TokenPair, error) {
 	claims := jwt.MapClaims{
 		"sub":      user.ID.String(),
+		"sid":      session.ID.String(),
 		"username": user.Username,
 		"is_admin": user.IsAdmin,
-		"iat":      now.Unix(),
-		"exp":      now.Add(s.tokenTTL).Unix(),
+		"iat":      session.CreatedAt.Unix(),
+		"exp":      session.ExpiresAt.Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signed, err := token.SignedString(s.jwtSecret)
 	if err != nil {
-		return domain.TokenPair{}, fmt.Errorf("AuthService.Login sign token: %w", err)
+		return domain.TokenPair{}, fmt.Errorf("AuthService.issueToken sign token: %w", err)
 	}
 
 	return domain.TokenPair{
@@ -144,12 +170,25 @@ func (s *Service) ValidateToken(tokenString string) (domain.TokenClaims, error)
 		return domain.TokenClaims{}, domain.ErrInvalidToken
 	}
 
+	sid, _ := claims["sid"].(string)
+	sessionID, err := uuid.Parse(sid)
+	if err != nil {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
 	username, _ := claims["username"].(string)
 	isAdmin, _ := claims["is_admin"].(bool)
 
+	var expiresAt time.Time
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		expiresAt = exp.Time
+	}
+
 	return domain.TokenClaims{
-		UserID:   userID,
-		Username: username,
-		IsAdmin:  isAdmin,
+		UserID:    userID,
+		Username:  username,
+		IsAdmin:   isAdmin,
+		SessionID: sessionID,
+		ExpiresAt: expiresAt,
 	}, nil
 }
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+const (
+	maxUserAgentLength = 512
+
+	// lastSeenResolution limits how often Authenticate writes last_seen_at
+	// for a busy session.
+	lastSeenResolution = time.Minute
+)
+
+// Authenticate accepts a session JWT or an API key. For a JWT it checks
+// that its session is still active: revoked or expired sessions are
+// rejected even if the JWT itself has not expired yet.
+func (s *Service) Authenticate(ctx context.Context, tokenString string) (domain.TokenClaims, error) {
+	if strings.HasPrefix(tokenString, domain.APIKeyPrefix) {
+		return s.authenticateAPIKey(ctx, tokenString)
+	}
+
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return domain.TokenClaims{}, err
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.TokenClaims{}, domain.ErrInvalidToken
+		}
+		return domain.TokenClaims{}, fmt.Errorf("AuthService.Authenticate: %w", err)
+	}
+
+	now := time.Now()
+	if session.UserID != claims.UserID || !session.IsActive(now) {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	if now.Sub(session.LastSeenAt) > lastSeenResolution {
+		if err := s.sessionRepo.Touch(ctx, session.ID); err != nil {
+			return domain.TokenClaims{}, fmt.Errorf("AuthService.Authenticate: %w", err)
+		}
+	}
+
+	return claims, nil
+}
+
+func (s *Service) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]domain.Session, error) {
+	sessions, err := s.sessionRepo.FindActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("AuthService.ListSessions: %w", err)
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID, userID); err != nil {
+		return fmt.Errorf("AuthService.RevokeSession: %w", err)
+	}
+
+	s.terminator.CloseSession(sessionID)
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
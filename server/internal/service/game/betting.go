@@ -13,8 +13,13 @@ type BettingPlayer struct {
 	HasActed     bool
 	IsAllIn      bool
 	IsFolded     bool
+	// ActedAt is the bet the player last acted facing. Their action is
+	// only reopened once the bet has grown by a full raise since.
+	ActedAt decimal.Decimal
 }
 
+// BettingState is one street of betting. BigBlind is the street's smallest
+// bet, which on fixed-limit turns and rivers is the big bet.
 type BettingState struct {
 	Players    []BettingPlayer
 	CurrentBet decimal.Decimal
@@ -22,9 +27,10 @@ type BettingState struct {
 	PotSize    decimal.Decimal
 	CurrentIdx int
 	BigBlind   decimal.Decimal
+	Limit      domain.BettingLimit
 }
 
-func NewBettingState(players []BettingPlayer, bigBlind decimal.Decimal, potSize decimal.Decimal) BettingState {
+func NewBettingState(players []BettingPlayer, bigBlind decimal.Decimal, potSize decimal.Decimal, limit domain.BettingLimit) BettingState {
 	return BettingState{
 		Players:    players,
 		CurrentBet: decimal.Zero,
@@ -32,6 +38,7 @@ func NewBettingState(players []BettingPlayer, bigBlind decimal.Decimal, potSize
 		PotSize:    potSize,
 		CurrentIdx: 0,
 		BigBlind:   bigBlind,
+		Limit:      limit,
 	}
 }
 
@@ -56,6 +63,15 @@ func ValidateAction(state BettingState, playerID uuid.UUID, action domain.Action
 		return state, domain.ErrInvalidAction
 	}
 
+	next, err := applyAction(state, idx, action, amount)
+	if err != nil {
+		return state, err
+	}
+	next.Players[idx].ActedAt = next.CurrentBet
+	return next, nil
+}
+
+func applyAction(state BettingState, idx int, action domain.ActionType, amount decimal.Decimal) (BettingState, error) {
 	switch action {
 	case domain.ActionFold:
 		return applyFold(state, idx), nil
@@ -74,6 +90,23 @@ func ValidateAction(state BettingState, playerID uuid.UUID, action domain.Action
 	}
 }
 
+// actionReopened reports whether the player at idx may raise. A player
+// who has acted may not re-raise an incomplete all-in unless the all-ins
+// since they acted add up to a full raise.
+func actionReopened(state BettingState, idx int) bool {
+	p := state.Players[idx]
+	return !p.HasActed || state.CurrentBet.Sub(p.ActedAt).GreaterThanOrEqual(state.MinRaise)
+}
+
+// raiseBounds is the structure's raise bounds for the player at idx, with
+// no raise allowed while their action is closed.
+func raiseBounds(state BettingState, idx int) (decimal.Decimal, decimal.Decimal, bool) {
+	if !actionReopened(state, idx) {
+		return decimal.Zero, decimal.Zero, false
+	}
+	return structureFor(state.Limit).RaiseBounds(state, idx)
+}
+
 func applyFold(state BettingState, idx int) BettingState {
 	newPlayers := copyPlayers(state.Players)
 	newPlayers[idx] = BettingPlayer{
@@ -92,6 +125,7 @@ func applyFold(state BettingState, idx int) BettingState {
 		PotSize:    state.PotSize,
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}
 }
 
@@ -117,6 +151,7 @@ func applyCheck(state BettingState, idx int) (BettingState, error) {
 		PotSize:    state.PotSize,
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}, nil
 }
 
@@ -151,6 +186,7 @@ func applyCall(state BettingState, idx int) (BettingState, error) {
 		PotSize:    state.PotSize.Add(callAmount),
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}, nil
 }
 
@@ -159,7 +195,16 @@ func applyBet(state BettingState, idx int, amount decimal.Decimal) (BettingState
 		return state, domain.ErrInvalidAction
 	}
 
-	if amount.LessThan(state.BigBlind) {
+	structure := structureFor(state.Limit)
+	minTotal, maxTotal, ok := raiseBounds(state, idx)
+	if !ok {
+		return state, domain.ErrInvalidAction
+	}
+	if structure.Implicit() {
+		amount = minTotal
+	}
+
+	if amount.LessThan(minTotal) {
 		return state, domain.ErrInvalidBetAmount
 	}
 
@@ -167,6 +212,9 @@ func applyBet(state BettingState, idx int, amount decimal.Decimal) (BettingState
 	if amount.GreaterThan(player.Stack) {
 		return state, domain.ErrInsufficientStack
 	}
+	if amount.GreaterThan(maxTotal) {
+		return state, domain.ErrInvalidBetAmount
+	}
 
 	isAllIn := amount.Equal(player.Stack)
 
@@ -189,6 +237,7 @@ func applyBet(state BettingState, idx int, amount decimal.Decimal) (BettingState
 		PotSize:    state.PotSize.Add(amount),
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}, nil
 }
 
@@ -197,8 +246,17 @@ func applyRaise(state BettingState, idx int, totalAmount decimal.Decimal) (Betti
 		return state, domain.ErrInvalidAction
 	}
 
+	structure := structureFor(state.Limit)
+	minTotal, maxTotal, ok := raiseBounds(state, idx)
+	if !ok {
+		return state, domain.ErrInvalidAction
+	}
+	if structure.Implicit() {
+		totalAmount = minTotal
+	}
+
 	raiseBy := totalAmount.Sub(state.CurrentBet)
-	if raiseBy.LessThan(state.MinRaise) {
+	if totalAmount.LessThan(minTotal) {
 		return state, domain.ErrInvalidBetAmount
 	}
 
@@ -207,6 +265,9 @@ func applyRaise(state BettingState, idx int, totalAmount decimal.Decimal) (Betti
 	if toCall.GreaterThan(player.Stack) {
 		return state, domain.ErrInsufficientStack
 	}
+	if totalAmount.GreaterThan(maxTotal) {
+		return state, domain.ErrInvalidBetAmount
+	}
 
 	isAllIn := toCall.Equal(player.Stack)
 
@@ -229,6 +290,7 @@ func applyRaise(state BettingState, idx int, totalAmount decimal.Decimal) (Betti
 		PotSize:    state.PotSize.Add(toCall),
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}, nil
 }
 
@@ -236,6 +298,12 @@ func applyAllIn(state BettingState, idx int) (BettingState, error) {
 	player := state.Players[idx]
 	allInAmount := player.Stack
 	newTotalBet := player.BetThisRound.Add(allInAmount)
+	if newTotalBet.GreaterThan(state.CurrentBet) {
+		_, maxTotal, ok := raiseBounds(state, idx)
+		if !ok || newTotalBet.GreaterThan(maxTotal) {
+			return state, domain.ErrInvalidBetAmount
+		}
+	}
 
 	newPlayers := copyPlayers(state.Players)
 	newPlayers[idx] = BettingPlayer{
@@ -265,9 +333,55 @@ func applyAllIn(state BettingState, idx int) (BettingState, error) {
 		PotSize:    state.PotSize.Add(allInAmount),
 		CurrentIdx: NextPlayer(newPlayers, idx),
 		BigBlind:   state.BigBlind,
+		Limit:      state.Limit,
 	}, nil
 }
 
+// ComputeLegalActions lists what the player at CurrentIdx may do, using the
+// same rules as ValidateAction.
+func ComputeLegalActions(state BettingState) domain.LegalActions {
+	var legal domain.LegalActions
+	if state.CurrentIdx < 0 || state.CurrentIdx >= len(state.Players) {
+		return legal
+	}
+
+	player := state.Players[state.CurrentIdx]
+	if player.IsFolded || player.IsAllIn {
+		return legal
+	}
+
+	legal.Actions = append(legal.Actions, domain.ActionFold)
+
+	toCall := state.CurrentBet.Sub(player.BetThisRound)
+	if toCall.LessThanOrEqual(decimal.Zero) {
+		legal.Actions = append(legal.Actions, domain.ActionCheck)
+	} else {
+		legal.Actions = append(legal.Actions, domain.ActionCall)
+		legal.CallAmount = decimal.Min(toCall, player.Stack)
+		legal.CallIsAllIn = toCall.GreaterThanOrEqual(player.Stack)
+	}
+
+	total := stackTotal(state, state.CurrentIdx)
+	minTotal, maxTotal, canRaise := raiseBounds(state, state.CurrentIdx)
+	if canRaise && minTotal.LessThanOrEqual(maxTotal) {
+		action := domain.ActionRaise
+		if state.CurrentBet.IsZero() {
+			action = domain.ActionBet
+		}
+		legal.Actions = append(legal.Actions, action)
+		legal.MinAmount = minTotal
+		legal.MaxAmount = maxTotal
+		legal.MinIsAllIn = minTotal.Equal(total)
+	}
+
+	if player.Stack.IsPositive() && ((canRaise && total.LessThanOrEqual(maxTotal)) || total.LessThanOrEqual(state.CurrentBet)) {
+		legal.Actions = append(legal.Actions, domain.ActionAllIn)
+		legal.AllInAmount = total
+	}
+
+	return legal
+}
+
 func IsBettingComplete(state BettingState) bool {
 	active := 0
 	for _, p := range state.Players {
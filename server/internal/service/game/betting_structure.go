@@ -0,0 +1,85 @@
+package game
+
+import (
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// fixedLimitBets is the number of bets allowed on a fixed-limit street: a
+// bet and three raises.
+const fixedLimitBets = 4
+
+// BettingStructure sizes bets and raises for a betting limit. Bounds are
+// totals for the street in front of the player and never exceed what they
+// have.
+type BettingStructure interface {
+	// RaiseBounds returns the smallest and largest total the player at idx
+	// may bet or raise to, or false if the street allows no more raises.
+	RaiseBounds(state BettingState, idx int) (minTotal, maxTotal decimal.Decimal, ok bool)
+	// Implicit reports whether the structure sets bet and raise sizes
+	// itself, so the amount sent with the action is ignored.
+	Implicit() bool
+}
+
+func structureFor(limit domain.BettingLimit) BettingStructure {
+	switch limit {
+	case domain.LimitPot:
+		return potLimit{}
+	case domain.LimitFixed:
+		return fixedLimit{}
+	default:
+		return noLimit{}
+	}
+}
+
+type noLimit struct{}
+
+func (noLimit) RaiseBounds(state BettingState, idx int) (decimal.Decimal, decimal.Decimal, bool) {
+	return openingMin(state), stackTotal(state, idx), true
+}
+
+func (noLimit) Implicit() bool { return false }
+
+// potLimit caps a bet or raise at the current bet plus the pot once the
+// player has called.
+type potLimit struct{}
+
+func (potLimit) RaiseBounds(state BettingState, idx int) (decimal.Decimal, decimal.Decimal, bool) {
+	player := state.Players[idx]
+	toCall := decimal.Max(state.CurrentBet.Sub(player.BetThisRound), decimal.Zero)
+	potMax := state.CurrentBet.Add(state.PotSize).Add(toCall)
+	return openingMin(state), decimal.Min(stackTotal(state, idx), potMax), true
+}
+
+func (potLimit) Implicit() bool { return false }
+
+// fixedLimit bets and raises in steps of the street's bet size, BigBlind
+// in the betting state, up to fixedLimitBets a street. The cap is lifted
+// when only two players are left.
+type fixedLimit struct{}
+
+func (fixedLimit) RaiseBounds(state BettingState, idx int) (decimal.Decimal, decimal.Decimal, bool) {
+	bets := state.CurrentBet.Div(state.BigBlind).Floor()
+	if bets.IntPart() >= fixedLimitBets && ActivePlayerCount(state) > 2 {
+		return decimal.Zero, decimal.Zero, false
+	}
+
+	total := bets.Add(decimal.NewFromInt(1)).Mul(state.BigBlind)
+	stack := stackTotal(state, idx)
+	return total, decimal.Min(total, stack), true
+}
+
+func (fixedLimit) Implicit() bool { return true }
+
+// openingMin is the smallest bet, a big blind, or the smallest raise.
+func openingMin(state BettingState) decimal.Decimal {
+	if state.CurrentBet.IsZero() {
+		return state.BigBlind
+	}
+	return state.CurrentBet.Add(state.MinRaise)
+}
+
+func stackTotal(state BettingState, idx int) decimal.Decimal {
+	player := state.Players[idx]
+	return player.BetThisRound.Add(player.Stack)
+}
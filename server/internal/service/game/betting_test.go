@@ -0,0 +1,294 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// seatPlayers makes betting players with the given stacks, none of whom
+// has bet yet.
+func seatPlayers(stacks ...int64) []BettingPlayer {
+	players := make([]BettingPlayer, len(stacks))
+	for i, stack := range stacks {
+		players[i] = BettingPlayer{PlayerID: uuid.New(), Stack: decimal.NewFromInt(stack)}
+	}
+	return players
+}
+
+// facing has the player at idx face a bet of currentBet, of which they
+// have put in bet.
+func facing(players []BettingPlayer, limit domain.BettingLimit, bigBlind, pot, currentBet, minRaise int64, idx int, bet int64) BettingState {
+	dec := decimal.NewFromInt
+	state := NewBettingState(players, dec(bigBlind), dec(pot), limit)
+	state.CurrentBet = dec(currentBet)
+	state.MinRaise = dec(minRaise)
+	state.CurrentIdx = idx
+	state.Players[idx].BetThisRound = dec(bet)
+	return state
+}
+
+func TestComputeLegalActions(t *testing.T) {
+	dec := decimal.NewFromInt
+	folded := seatPlayers(1000, 1000)
+	folded[0].IsFolded = true
+
+	tests := []struct {
+		name     string
+		state    BettingState
+		actions  []domain.ActionType
+		call     int64
+		callAll  bool
+		min, max int64
+		minAll   bool
+		allIn    int64
+	}{
+		{
+			name:    "unopened pot",
+			state:   facing(seatPlayers(1000, 1000), domain.LimitNone, 10, 15, 0, 10, 0, 0),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCheck, domain.ActionBet, domain.ActionAllIn},
+			min:     10, max: 1000, allIn: 1000,
+		},
+		{
+			name:    "facing a bet",
+			state:   facing(seatPlayers(1000, 1000), domain.LimitNone, 10, 150, 50, 50, 0, 0),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCall, domain.ActionRaise, domain.ActionAllIn},
+			call:    50, min: 100, max: 1000, allIn: 1000,
+		},
+		{
+			name:    "big blind checks its option",
+			state:   facing(seatPlayers(1000, 1000), domain.LimitNone, 10, 20, 10, 10, 1, 10),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCheck, domain.ActionRaise, domain.ActionAllIn},
+			min:     20, max: 1010, allIn: 1010,
+		},
+		{
+			name:    "stack short of a call",
+			state:   facing(seatPlayers(30, 1000), domain.LimitNone, 10, 150, 50, 50, 0, 0),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCall, domain.ActionAllIn},
+			call:    30, callAll: true, allIn: 30,
+		},
+		{
+			name:    "stack short of a full raise",
+			state:   facing(seatPlayers(80, 1000), domain.LimitNone, 10, 150, 50, 50, 0, 0),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCall, domain.ActionAllIn},
+			call:    50, allIn: 80,
+		},
+		{
+			name:    "stack exactly a min raise",
+			state:   facing(seatPlayers(100, 1000), domain.LimitNone, 10, 150, 50, 50, 0, 0),
+			actions: []domain.ActionType{domain.ActionFold, domain.ActionCall, domain.ActionRaise, domain.ActionAllIn},
+			call:    50, min: 100, max: 100, minAll: true, allIn: 100,
+		},
+		{
+			name:  "folded player",
+			state: facing(folded, domain.LimitNone, 10, 0, 0, 10, 0, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legal := ComputeLegalActions(tt.state)
+			if !slices.Equal(legal.Actions, tt.actions) {
+				t.Fatalf("actions = %v, want %v", legal.Actions, tt.actions)
+			}
+			if !legal.CallAmount.Equal(dec(tt.call)) || legal.CallIsAllIn != tt.callAll {
+				t.Errorf("call = %s (all-in %v), want %d (all-in %v)", legal.CallAmount, legal.CallIsAllIn, tt.call, tt.callAll)
+			}
+			if !legal.MinAmount.Equal(dec(tt.min)) || !legal.MaxAmount.Equal(dec(tt.max)) || legal.MinIsAllIn != tt.minAll {
+				t.Errorf("raise = %s to %s (min all-in %v), want %d to %d (%v)", legal.MinAmount, legal.MaxAmount, legal.MinIsAllIn, tt.min, tt.max, tt.minAll)
+			}
+			if !legal.AllInAmount.Equal(dec(tt.allIn)) {
+				t.Errorf("all-in = %s, want %d", legal.AllInAmount, tt.allIn)
+			}
+		})
+	}
+}
+
+func TestPotLimitMaximum(t *testing.T) {
+	tests := []struct {
+		name  string
+		state BettingState
+		want  int64
+	}{
+		{
+			name:  "opening bet is the pot",
+			state: facing(seatPlayers(1000, 1000), domain.LimitPot, 10, 100, 0, 10, 0, 0),
+			want:  100,
+		},
+		{
+			name:  "raise calls then adds the pot",
+			state: facing(seatPlayers(1000, 1000), domain.LimitPot, 10, 150, 50, 50, 0, 0),
+			want:  250,
+		},
+		{
+			name:  "preflop raise over the blinds",
+			state: facing(seatPlayers(1000, 1000, 1000), domain.LimitPot, 10, 15, 10, 10, 2, 0),
+			want:  35,
+		},
+		{
+			name:  "small blind counts what it has in",
+			state: facing(seatPlayers(1000, 1000, 1000), domain.LimitPot, 10, 15, 10, 10, 0, 5),
+			want:  30,
+		},
+		{
+			name:  "capped by the stack",
+			state: facing(seatPlayers(120, 1000), domain.LimitPot, 10, 150, 50, 50, 0, 0),
+			want:  120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legal := ComputeLegalActions(tt.state)
+			if !legal.MaxAmount.Equal(decimal.NewFromInt(tt.want)) {
+				t.Fatalf("max = %s, want %d", legal.MaxAmount, tt.want)
+			}
+
+			player := tt.state.Players[tt.state.CurrentIdx].PlayerID
+			action := domain.ActionRaise
+			if tt.state.CurrentBet.IsZero() {
+				action = domain.ActionBet
+			}
+			if _, err := ValidateAction(tt.state, player, action, legal.MaxAmount); err != nil {
+				t.Errorf("%s to the maximum: %v", action, err)
+			}
+			if _, err := ValidateAction(tt.state, player, action, legal.MaxAmount.Add(decimal.NewFromInt(1))); err == nil {
+				t.Errorf("%s over the maximum was allowed", action)
+			}
+		})
+	}
+}
+
+func TestFixedLimitCap(t *testing.T) {
+	headsUp := seatPlayers(1000, 1000, 1000)
+	headsUp[2].IsFolded = true
+
+	tests := []struct {
+		name     string
+		state    BettingState
+		canRaise bool
+		to       int64
+	}{
+		{
+			name:     "bet is one big blind",
+			state:    facing(seatPlayers(1000, 1000, 1000), domain.LimitFixed, 10, 0, 0, 10, 0, 0),
+			canRaise: true, to: 10,
+		},
+		{
+			name:     "raise is one more bet",
+			state:    facing(seatPlayers(1000, 1000, 1000), domain.LimitFixed, 10, 50, 20, 10, 0, 0),
+			canRaise: true, to: 30,
+		},
+		{
+			name:     "fourth bet caps the street",
+			state:    facing(seatPlayers(1000, 1000, 1000), domain.LimitFixed, 10, 70, 30, 10, 0, 0),
+			canRaise: true, to: 40,
+		},
+		{
+			name:  "no fifth bet",
+			state: facing(seatPlayers(1000, 1000, 1000), domain.LimitFixed, 10, 90, 40, 10, 0, 0),
+		},
+		{
+			name:     "heads up lifts the cap",
+			state:    facing(headsUp, domain.LimitFixed, 10, 90, 40, 10, 0, 0),
+			canRaise: true, to: 50,
+		},
+		{
+			name:     "big bet on later streets",
+			state:    facing(seatPlayers(1000, 1000, 1000), domain.LimitFixed, 20, 40, 20, 20, 0, 0),
+			canRaise: true, to: 40,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legal := ComputeLegalActions(tt.state)
+			canRaise := legal.Allows(domain.ActionRaise) || legal.Allows(domain.ActionBet)
+			if canRaise != tt.canRaise {
+				t.Fatalf("actions = %v, raise allowed %v, want %v", legal.Actions, canRaise, tt.canRaise)
+			}
+			if !canRaise {
+				return
+			}
+			if !legal.MinAmount.Equal(decimal.NewFromInt(tt.to)) || !legal.MaxAmount.Equal(legal.MinAmount) {
+				t.Errorf("raise = %s to %s, want exactly %d", legal.MinAmount, legal.MaxAmount, tt.to)
+			}
+
+			action := domain.ActionRaise
+			if tt.state.CurrentBet.IsZero() {
+				action = domain.ActionBet
+			}
+			// The amount sent is ignored: fixed limit sets it.
+			next, err := ValidateAction(tt.state, tt.state.Players[0].PlayerID, action, decimal.NewFromInt(999))
+			if err != nil {
+				t.Fatalf("%s: %v", action, err)
+			}
+			if !next.CurrentBet.Equal(decimal.NewFromInt(tt.to)) {
+				t.Errorf("bet after %s = %s, want %d", action, next.CurrentBet, tt.to)
+			}
+		})
+	}
+}
+
+// TestIncompleteAllInReopening plays out the TDA rule: an all-in short of a
+// full raise does not reopen the betting to players who already acted,
+// unless such all-ins add up to a full raise since they did.
+func TestIncompleteAllInReopening(t *testing.T) {
+	dec := decimal.NewFromInt
+	type step struct {
+		action domain.ActionType
+		amount int64
+	}
+
+	tests := []struct {
+		name     string
+		stacks   []int64
+		steps    []step
+		canRaise bool
+	}{
+		{
+			name:     "full raise reopens",
+			stacks:   []int64{1000, 1000, 1000},
+			steps:    []step{{domain.ActionBet, 100}, {domain.ActionRaise, 200}, {domain.ActionCall, 0}},
+			canRaise: true,
+		},
+		{
+			name:   "short all-in does not reopen",
+			stacks: []int64{1000, 150, 1000},
+			steps:  []step{{domain.ActionBet, 100}, {domain.ActionAllIn, 0}, {domain.ActionCall, 0}},
+		},
+		{
+			name:     "short all-ins adding up to a raise reopen",
+			stacks:   []int64{1000, 150, 210},
+			steps:    []step{{domain.ActionBet, 100}, {domain.ActionAllIn, 0}, {domain.ActionAllIn, 0}},
+			canRaise: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewBettingState(seatPlayers(tt.stacks...), dec(10), dec(0), domain.LimitNone)
+			for _, s := range tt.steps {
+				next, err := ValidateAction(state, state.Players[state.CurrentIdx].PlayerID, s.action, dec(s.amount))
+				if err != nil {
+					t.Fatalf("%s %d: %v", s.action, s.amount, err)
+				}
+				state = next
+			}
+			if state.CurrentIdx != 0 {
+				t.Fatalf("action is on player %d, want the opener", state.CurrentIdx)
+			}
+
+			legal := ComputeLegalActions(state)
+			if got := legal.Allows(domain.ActionRaise); got != tt.canRaise {
+				t.Fatalf("actions = %v, raise allowed %v, want %v", legal.Actions, got, tt.canRaise)
+			}
+			if _, err := ValidateAction(state, state.Players[0].PlayerID, domain.ActionRaise, legal.MaxAmount); (err == nil) != tt.canRaise {
+				t.Errorf("raise error = %v, want allowed %v", err, tt.canRaise)
+			}
+		})
+	}
+}
@@ -0,0 +1,581 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+const (
+	maxEquityHands      = 9
+	defaultEquityTrials = 100_000
+	maxEquityTrials     = 1_000_000
+
+	// maxSampleEvals is the most five-card evaluations a request samples
+	// boards with: trials times hands times the evaluations per hand.
+	maxSampleEvals = 20_000_000
+
+	// maxExactEvals is the most five-card evaluations a request is worked
+	// out exactly with. Heads-up hold'em before the flop takes 3,424,608.
+	maxExactEvals = 4_000_000
+
+	// Tables work out equities on their event loop between streets, so
+	// they only enumerate small runouts and sample fewer boards.
+	tableExactEvals   = 100_000
+	tableEquityTrials = 10_000
+
+	// maxDealAttempts is how often a trial redraws hands from ranges that
+	// keep sharing cards before giving up.
+	maxDealAttempts = 1000
+)
+
+type EquityService struct{}
+
+func NewEquityService() *EquityService {
+	return &EquityService{}
+}
+
+// CalculateEquity works out how each range fares against the others.
+func (s *EquityService) CalculateEquity(ctx context.Context, req domain.EquityRequest) (domain.EquityResult, error) {
+	if req.GameType == "" {
+		req.GameType = domain.GameHoldem
+	}
+	if !req.GameType.IsValid() || req.GameType == domain.GameStud {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w", domain.ErrInvalidGameType)
+	}
+	if len(req.Ranges) < 2 || len(req.Ranges) > maxEquityHands {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w",
+			domain.NewValidationError("ranges", fmt.Sprintf("between 2 and %d ranges are needed", maxEquityHands)))
+	}
+	if req.Method != "" && req.Method != domain.EquityExact && req.Method != domain.EquityMonteCarlo {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w",
+			domain.NewValidationError("method", "method must be exact or monte_carlo"))
+	}
+	if req.Trials < 0 || req.Trials > maxEquityTrials {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w",
+			domain.NewValidationError("trials", fmt.Sprintf("trials must be between 0 and %d", maxEquityTrials)))
+	}
+	variant := VariantFor(req.GameType)
+	ranges := make([][][]domain.Card, len(req.Ranges))
+	for i, r := range req.Ranges {
+		hands, err := ParseHandRange(r, variant)
+		if err != nil {
+			return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w",
+				domain.NewValidationError(fmt.Sprintf("ranges[%d]", i), err.Error()))
+		}
+		ranges[i] = hands
+	}
+
+	calc, err := newEquityCalc(req.GameType, ranges, req.Board, req.Dead)
+	if err != nil {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w", err)
+	}
+
+	maxTrials := maxSampleEvals / (len(ranges) * calc.scoreCost)
+	trials := req.Trials
+	switch {
+	case trials == 0:
+		trials = min(defaultEquityTrials, maxTrials)
+	case trials > maxTrials && req.Method != domain.EquityExact:
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w",
+			domain.NewValidationError("trials", fmt.Sprintf("at most %d trials can be run for these ranges", maxTrials)))
+	}
+
+	result, err := calc.run(ctx, req.Method, maxExactEvals, trials)
+	if err != nil {
+		return domain.EquityResult{}, fmt.Errorf("EquityService.CalculateEquity: %w", err)
+	}
+	for i := range result.Hands {
+		result.Hands[i].Range = req.Ranges[i]
+	}
+	return result, nil
+}
+
+// tableEquity works out the equity of the hands in an all-in pot on the
+// board dealt so far. Folded hands were never shown, so they stay live.
+func tableEquity(gameType domain.GameType, hands [][]domain.Card, board []domain.Card) (domain.EquityResult, error) {
+	ranges := make([][][]domain.Card, len(hands))
+	for i, hand := range hands {
+		ranges[i] = [][]domain.Card{hand}
+	}
+
+	calc, err := newEquityCalc(gameType, ranges, board, nil)
+	if err != nil {
+		return domain.EquityResult{}, err
+	}
+
+	result, err := calc.run(context.Background(), "", tableExactEvals, tableEquityTrials)
+	if err != nil {
+		return domain.EquityResult{}, err
+	}
+	for i, hand := range hands {
+		result.Hands[i].Range = strings.ReplaceAll(domain.CardsToString(hand), ",", "")
+	}
+	return result, nil
+}
+
+// equityScorer scores a hand on a full board: its high score and, in hi-lo
+// games, its low score, or math.MaxInt32 without a qualifying low.
+type equityScorer func(hole, board []CardCode) (high, low int32)
+
+// equityScorerFor returns the game's scorer and how many evaluations it
+// makes a hand.
+func equityScorerFor(gameType domain.GameType) (equityScorer, int) {
+	switch gameType {
+	case domain.GamePLO:
+		return omahaEquityScore, 60
+	case domain.GamePLO8:
+		return omahaHiLoEquityScore, 60
+	case domain.GameShort:
+		return shortDeckEquityScore, 21
+	default:
+		return holdemEquityScore, 1
+	}
+}
+
+func holdemEquityScore(hole, board []CardCode) (int32, int32) {
+	var buf [maxEvalSize]CardCode
+	cards := append(append(buf[:0], hole...), board...)
+	return EvaluateCodes(cards), math.MaxInt32
+}
+
+func omahaEquityScore(hole, board []CardCode) (int32, int32) {
+	high := int32(math.MaxInt32)
+	forEachOmahaHand(hole, board, func(hand []CardCode) {
+		high = min(high, EvaluateCodes(hand))
+	})
+	return high, math.MaxInt32
+}
+
+func omahaHiLoEquityScore(hole, board []CardCode) (int32, int32) {
+	high, low := int32(math.MaxInt32), int32(math.MaxInt32)
+	forEachOmahaHand(hole, board, func(hand []CardCode) {
+		high = min(high, EvaluateCodes(hand))
+		if score, ok := lowCodeScore(hand); ok {
+			low = min(low, score)
+		}
+	})
+	return high, low
+}
+
+// shortDeckWheel is A-6-7-8-9 as rank bits.
+const shortDeckWheel = 1<<12 | 0b1111<<4
+
+func shortDeckEquityScore(hole, board []CardCode) (int32, int32) {
+	var buf [maxEvalSize]CardCode
+	cards := append(append(buf[:0], hole...), board...)
+
+	high := int32(math.MaxInt32)
+	forEachFiveCards(cards, func(hand []CardCode) {
+		var ranks uint16
+		for _, c := range hand {
+			ranks |= 1 << (c >> 2)
+		}
+		high = min(high, shortDeckScore(EvaluateCodes(hand), ranks == shortDeckWheel))
+	})
+	return high, math.MaxInt32
+}
+
+// lowCodeScore scores five cards as an eight-or-better low on the same
+// scale as evaluateLow.
+func lowCodeScore(hand []CardCode) (int32, bool) {
+	var seen uint16
+	for _, c := range hand {
+		v := int(c>>2) + 2
+		if v == 14 {
+			v = 1
+		}
+		if v > 8 || seen&(1<<v) != 0 {
+			return 0, false
+		}
+		seen |= 1 << v
+	}
+
+	var score int32
+	for v := 8; v >= 1; v-- {
+		if seen&(1<<v) != 0 {
+			score = score<<4 | int32(v)
+		}
+	}
+	return score, true
+}
+
+// equityCalc deals out boards for hands drawn from each range and tallies
+// how each does.
+type equityCalc struct {
+	score     equityScorer
+	scoreCost int
+	ranges    [][][]CardCode
+	// masks holds the card bits of each hand in ranges.
+	masks [][]uint64
+	board []CardCode
+	// deck holds the cards neither on the board nor dead.
+	deck   []CardCode
+	toCome int
+
+	high, low  []int32
+	wins, ties []int64
+	shares     []float64
+	trials     int64
+}
+
+// newEquityCalc checks the board and dead cards and drops the hands that
+// use them from each range.
+func newEquityCalc(gameType domain.GameType, ranges [][][]domain.Card, board, dead []domain.Card) (*equityCalc, error) {
+	if len(board) > boardSize || len(board) == 1 || len(board) == 2 {
+		return nil, domain.NewValidationError("board", "the board must have 0, 3, 4 or 5 cards")
+	}
+
+	inDeck := make(map[CardCode]bool)
+	var deck []CardCode
+	for _, c := range VariantFor(gameType).Deck() {
+		inDeck[EncodeCard(c)] = true
+		deck = append(deck, EncodeCard(c))
+	}
+
+	var used uint64
+	mark := func(field string, cards []domain.Card) error {
+		for _, c := range cards {
+			code := EncodeCard(c)
+			if !inDeck[code] {
+				return domain.NewValidationError(field, fmt.Sprintf("%s is not in the deck", c))
+			}
+			if used&(1<<code) != 0 {
+				return domain.NewValidationError(field, fmt.Sprintf("%s is used twice", c))
+			}
+			used |= 1 << code
+		}
+		return nil
+	}
+	if err := mark("board", board); err != nil {
+		return nil, err
+	}
+	if err := mark("dead", dead); err != nil {
+		return nil, err
+	}
+
+	n := len(ranges)
+	calc := &equityCalc{
+		ranges: make([][][]CardCode, n),
+		masks:  make([][]uint64, n),
+		board:  EncodeCards(nil, board),
+		toCome: boardSize - len(board),
+		high:   make([]int32, n),
+		low:    make([]int32, n),
+		wins:   make([]int64, n),
+		ties:   make([]int64, n),
+		shares: make([]float64, n),
+	}
+	calc.score, calc.scoreCost = equityScorerFor(gameType)
+	calc.deck = availableCards(nil, deck, used)
+
+	for i, hands := range ranges {
+		for _, hand := range hands {
+			codes := EncodeCards(nil, hand)
+			mask := codeMask(codes)
+			if mask&used != 0 {
+				continue
+			}
+			calc.ranges[i] = append(calc.ranges[i], codes)
+			calc.masks[i] = append(calc.masks[i], mask)
+		}
+		if len(calc.ranges[i]) == 0 {
+			return nil, domain.NewValidationError(fmt.Sprintf("ranges[%d]", i), "every hand in the range uses a card on the board or dead")
+		}
+	}
+
+	return calc, nil
+}
+
+// run works out the equities by method, enumerating when no method is
+// given and that takes at most maxExact evaluations.
+func (c *equityCalc) run(ctx context.Context, method domain.EquityMethod, maxExact int64, trials int) (domain.EquityResult, error) {
+	exact := c.exactCost(maxExact) <= maxExact
+	switch method {
+	case domain.EquityExact:
+		if !exact {
+			return domain.EquityResult{}, domain.NewValidationError("method", "too many deals to work out exactly, use monte_carlo")
+		}
+	case "":
+		method = domain.EquityMonteCarlo
+		if exact {
+			method = domain.EquityExact
+		}
+	}
+
+	var err error
+	if method == domain.EquityExact {
+		err = c.enumerate(ctx)
+	} else {
+		err = c.sample(ctx, trials)
+	}
+	if err != nil {
+		return domain.EquityResult{}, err
+	}
+	if c.trials == 0 {
+		return domain.EquityResult{}, domain.NewValidationError("ranges", "the ranges share too many cards to deal hands from all of them")
+	}
+
+	return c.result(method), nil
+}
+
+// exactCost counts the evaluations enumerate would make, counting hands
+// that share cards too, up to just over limit.
+func (c *equityCalc) exactCost(limit int64) int64 {
+	holeCards := 0
+	cost := int64(len(c.ranges) * c.scoreCost)
+	for _, hands := range c.ranges {
+		holeCards += len(hands[0])
+		cost *= int64(len(hands))
+		if cost > limit {
+			return limit + 1
+		}
+	}
+
+	// Multiplying before dividing keeps each step a whole binomial.
+	left := len(c.deck) - holeCards
+	for i := range c.toCome {
+		cost = cost * int64(left-i) / int64(i+1)
+		if cost > limit {
+			return limit + 1
+		}
+	}
+	return cost
+}
+
+// enumerate deals every board to every set of hands that share no cards.
+func (c *equityCalc) enumerate(ctx context.Context) error {
+	holes := make([][]CardCode, len(c.ranges))
+	board := make([]CardCode, len(c.board)+c.toCome)
+	copy(board, c.board)
+	avail := make([]CardCode, 0, len(c.deck))
+
+	var assign func(p int, used uint64) error
+	assign = func(p int, used uint64) error {
+		if p == len(c.ranges) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			avail = availableCards(avail[:0], c.deck, used)
+			forEachDraw(avail, board[len(c.board):], func() {
+				c.settle(holes, board)
+			})
+			return nil
+		}
+
+		for i, hand := range c.ranges[p] {
+			if mask := c.masks[p][i]; mask&used == 0 {
+				holes[p] = hand
+				if err := assign(p+1, used|mask); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	return assign(0, 0)
+}
+
+// sample deals trials random boards, each to hands drawn at random from
+// the ranges.
+func (c *equityCalc) sample(ctx context.Context, trials int) error {
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	holes := make([][]CardCode, len(c.ranges))
+	board := make([]CardCode, len(c.board)+c.toCome)
+	copy(board, c.board)
+	drawn := board[len(c.board):]
+	avail := make([]CardCode, 0, len(c.deck))
+
+	for t := range trials {
+		if t%4096 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		used, ok := c.dealHands(rng, holes)
+		if !ok {
+			return nil
+		}
+
+		avail = availableCards(avail[:0], c.deck, used)
+		for i := range drawn {
+			j := i + rng.IntN(len(avail)-i)
+			avail[i], avail[j] = avail[j], avail[i]
+			drawn[i] = avail[i]
+		}
+		c.settle(holes, board)
+	}
+	return nil
+}
+
+// dealHands draws a hand from each range, redrawing all of them when two
+// share a card so every set of hands is equally likely.
+func (c *equityCalc) dealHands(rng *rand.Rand, holes [][]CardCode) (uint64, bool) {
+	for range maxDealAttempts {
+		var used uint64
+		ok := true
+		for p, hands := range c.ranges {
+			i := rng.IntN(len(hands))
+			if c.masks[p][i]&used != 0 {
+				ok = false
+				break
+			}
+			holes[p] = hands[i]
+			used |= c.masks[p][i]
+		}
+		if ok {
+			return used, true
+		}
+	}
+	return 0, false
+}
+
+// settle scores one deal. Each pot, or half pot in hi-lo games, is shared
+// equally between the hands that tie for it.
+func (c *equityCalc) settle(holes [][]CardCode, board []CardCode) {
+	bestHigh, bestLow := int32(math.MaxInt32), int32(math.MaxInt32)
+	for p, hole := range holes {
+		c.high[p], c.low[p] = c.score(hole, board)
+		bestHigh = min(bestHigh, c.high[p])
+		bestLow = min(bestLow, c.low[p])
+	}
+
+	highWinners, lowWinners := 0, 0
+	for p := range holes {
+		if c.high[p] == bestHigh {
+			highWinners++
+		}
+		if c.low[p] == bestLow {
+			lowWinners++
+		}
+	}
+
+	highPot := 1.0
+	if bestLow != math.MaxInt32 {
+		highPot = 0.5
+	}
+	for p := range holes {
+		share := 0.0
+		if c.high[p] == bestHigh {
+			share += highPot / float64(highWinners)
+		}
+		if bestLow != math.MaxInt32 && c.low[p] == bestLow {
+			share += 0.5 / float64(lowWinners)
+		}
+
+		c.shares[p] += share
+		switch {
+		case share == 1:
+			c.wins[p]++
+		case share > 0:
+			c.ties[p]++
+		}
+	}
+	c.trials++
+}
+
+func (c *equityCalc) result(method domain.EquityMethod) domain.EquityResult {
+	n := float64(c.trials)
+	hands := make([]domain.HandEquity, len(c.ranges))
+	for p := range hands {
+		hands[p] = domain.HandEquity{
+			Combos: len(c.ranges[p]),
+			Win:    float64(c.wins[p]) / n,
+			Tie:    float64(c.ties[p]) / n,
+			Lose:   float64(c.trials-c.wins[p]-c.ties[p]) / n,
+			Equity: c.shares[p] / n,
+		}
+	}
+	return domain.EquityResult{Hands: hands, Method: method, Trials: c.trials}
+}
+
+// availableCards appends the cards of deck not in used to dst.
+func availableCards(dst, deck []CardCode, used uint64) []CardCode {
+	for _, code := range deck {
+		if used&(1<<code) == 0 {
+			dst = append(dst, code)
+		}
+	}
+	return dst
+}
+
+func codeMask(codes []CardCode) uint64 {
+	var mask uint64
+	for _, c := range codes {
+		mask |= 1 << c
+	}
+	return mask
+}
+
+// forEachDraw fills drawn with every combination of cards from avail in
+// turn, calling fn after each.
+func forEachDraw(avail, drawn []CardCode, fn func()) {
+	var draw func(start, i int)
+	draw = func(start, i int) {
+		if i == len(drawn) {
+			fn()
+			return
+		}
+		for j := start; j <= len(avail)-(len(drawn)-i); j++ {
+			drawn[i] = avail[j]
+			draw(j+1, i+1)
+		}
+	}
+	draw(0, 0)
+}
+
+// broadcastEquity sends the equity of each hand in an all-in pot once the
+// hands are turned face up and after each street of the runout. Stud has
+// no shared board to run out, so its tables send none.
+func (h *TableHub) broadcastEquity() {
+	if h.state.Hand != nil {
+		h.broadcastRunEquity(0, h.state.Hand.Hand.Stage)
+	}
+}
+
+// broadcastRunEquity sends the equities on the board dealt so far of run,
+// which is 0 unless the board is run twice.
+func (h *TableHub) broadcastRunEquity(run int, stage domain.GameStage) {
+	hand := h.state.Hand
+	if hand == nil || !hand.Exposed || h.state.Table.GameType == domain.GameStud {
+		return
+	}
+
+	var userIDs []uuid.UUID
+	var hands [][]domain.Card
+	for _, id := range h.activePlayerIDs() {
+		if p := h.state.FindPlayerByID(id); p != nil {
+			userIDs = append(userIDs, p.UserID)
+			hands = append(hands, hand.PlayerHands[id])
+		}
+	}
+	if len(hands) < 2 {
+		return
+	}
+
+	result, err := tableEquity(h.state.Table.GameType, hands, hand.CommunityCards)
+	if err != nil {
+		h.logger.Error("equity calculation failed", "error", err, "hand_id", hand.Hand.ID)
+		return
+	}
+
+	equities := make(map[uuid.UUID]domain.HandEquity, len(userIDs))
+	for i, id := range userIDs {
+		equities[id] = result.Hands[i]
+	}
+	msg := h.buildMessage(domain.WSMsgEquity, domain.WSEquity{
+		HandID:   hand.Hand.ID,
+		Stage:    stage,
+		Run:      run,
+		Method:   result.Method,
+		Equities: equities,
+	})
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+}
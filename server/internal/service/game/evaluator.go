@@ -1,36 +1,54 @@
 package game
 
 import (
-	"github.com/chehsunliu/poker"
+	"math"
+	"sort"
+	"strings"
+
 	"github.com/jokeoa/goigaming/internal/core/domain"
 )
 
 type HandRank struct {
 	Score int32
 	Name  string
+	// Cards are the five cards that make the hand, Category its class and
+	// Description its name with kickers, for clients and hand histories.
+	Cards       []domain.Card
+	Category    domain.HandCategory
+	Description string
 }
 
-func toLibCard(c domain.Card) poker.Card {
-	rankStr := string(c.Rank)
-	suitStr := string(c.Suit)
-	return poker.NewCard(rankStr + suitStr)
-}
+// HandEvaluator ranks a player's best hand from their hole cards and the
+// board, following the rules of a game variant.
+type HandEvaluator func(holeCards, community []domain.Card) HandRank
 
-func toLibCards(cards []domain.Card) []poker.Card {
-	result := make([]poker.Card, len(cards))
-	for i, c := range cards {
-		result[i] = toLibCard(c)
-	}
-	return result
-}
+// LowEvaluator ranks a player's best low, or reports false if they have
+// none that qualifies. As with HandRank, lower scores are better.
+type LowEvaluator func(holeCards, community []domain.Card) (HandRank, bool)
 
+// EvaluateHand ranks the best five of five to seven cards. Callers that
+// only need the score should use EvaluateCodes, which is much cheaper.
 func EvaluateHand(cards []domain.Card) HandRank {
-	libCards := toLibCards(cards)
-	score := poker.Evaluate(libCards)
-	return HandRank{
-		Score: score,
-		Name:  poker.RankString(score),
+	if len(cards) < 5 || len(cards) > maxEvalSize {
+		return HandRank{Score: math.MaxInt32}
 	}
+
+	var buf [maxEvalSize]CardCode
+	best := HandRank{Score: EvaluateCodes(EncodeCards(buf[:0], cards))}
+	forEachFiveCards(cards, func(hand []domain.Card) {
+		if best.Cards == nil && EvaluateCodes(EncodeCards(buf[:0], hand)) == best.Score {
+			best.Cards = append([]domain.Card(nil), hand...)
+		}
+	})
+	return best.describe(categoryForScore(best.Score))
+}
+
+// describe fills in the rank's category and names.
+func (r HandRank) describe(category domain.HandCategory) HandRank {
+	r.Category = category
+	r.Name = categoryNames[category]
+	r.Description = describeHand(category, r.Cards)
+	return r
 }
 
 func BestHand(holeCards, community []domain.Card) HandRank {
@@ -40,6 +58,237 @@ func BestHand(holeCards, community []domain.Card) HandRank {
 	return EvaluateHand(all)
 }
 
+// BestOmahaHand ranks the best hand made of exactly two hole cards and
+// three board cards.
+func BestOmahaHand(holeCards, community []domain.Card) HandRank {
+	best := int32(math.MaxInt32)
+	var bestHand [5]domain.Card
+	var codes [5]CardCode
+	forEachOmahaHand(holeCards, community, func(hand []domain.Card) {
+		if score := EvaluateCodes(EncodeCards(codes[:0], hand)); score < best {
+			best = score
+			copy(bestHand[:], hand)
+		}
+	})
+	if best == math.MaxInt32 {
+		return HandRank{Score: best}
+	}
+	return EvaluateHand(bestHand[:])
+}
+
+// BestOmahaLow ranks the best eight-or-better low made of exactly two hole
+// cards and three board cards.
+func BestOmahaLow(holeCards, community []domain.Card) (HandRank, bool) {
+	best := HandRank{Score: math.MaxInt32}
+	found := false
+	forEachOmahaHand(holeCards, community, func(hand []domain.Card) {
+		if rank, ok := evaluateLow(hand); ok && rank.Score < best.Score {
+			best = rank
+			found = true
+		}
+	})
+	return best, found
+}
+
+// BestShortDeckHand ranks the best five of the seven cards under six-plus
+// rules: a flush beats a full house and A-6-7-8-9 is the lowest straight.
+func BestShortDeckHand(holeCards, community []domain.Card) HandRank {
+	all := make([]domain.Card, 0, len(holeCards)+len(community))
+	all = append(all, holeCards...)
+	all = append(all, community...)
+
+	best := int32(math.MaxInt32)
+	var bestHand [5]domain.Card
+	var codes [5]CardCode
+	forEachFiveCards(all, func(hand []domain.Card) {
+		if len(hand) != 5 {
+			return
+		}
+		if score := shortDeckScore(EvaluateCodes(EncodeCards(codes[:0], hand)), isShortDeckWheel(hand)); score < best {
+			best = score
+			copy(bestHand[:], hand)
+		}
+	})
+	if best == math.MaxInt32 {
+		return HandRank{Score: best}
+	}
+	return evaluateShortDeck(bestHand[:])
+}
+
+// Bounds of each category's scores.
+const (
+	scoreStraightFlushMax int32 = 10
+	scoreFourOfAKindMax   int32 = 166
+	scoreFullHouseMin     int32 = 167
+	scoreFullHouseMax     int32 = 322
+	scoreFlushMax         int32 = 1599
+	scoreStraightMax      int32 = 1609
+	scoreThreeOfAKindMax  int32 = 2467
+	scoreTwoPairMax       int32 = 3325
+	scorePairMax          int32 = 6185
+)
+
+// evaluateShortDeck ranks a five-card hand under six-plus rules.
+func evaluateShortDeck(hand []domain.Card) HandRank {
+	rank := EvaluateHand(hand)
+	if isShortDeckWheel(hand) {
+		category := domain.HandStraight
+		if rank.Score <= scoreFlushMax {
+			category = domain.HandStraightFlush
+		}
+		rank = rank.describe(category)
+	}
+	rank.Score = shortDeckScore(rank.Score, isShortDeckWheel(hand))
+	return rank
+}
+
+// shortDeckScore re-scores a five-card hand for six-plus. Flushes move
+// ahead of full houses by swapping the two score ranges, and A-6-7-8-9,
+// the wheel, takes the slot below the ten-high straight, which no short
+// deck can otherwise make.
+func shortDeckScore(score int32, wheel bool) int32 {
+	if wheel {
+		if score <= scoreFlushMax {
+			return scoreStraightFlushMax - 4
+		}
+		return scoreStraightMax - 4
+	}
+
+	fullHouses := scoreFullHouseMax - scoreFullHouseMin + 1
+	flushes := scoreFlushMax - scoreFullHouseMax
+	switch {
+	case score >= scoreFullHouseMin && score <= scoreFullHouseMax:
+		score += flushes
+	case score > scoreFullHouseMax && score <= scoreFlushMax:
+		score -= fullHouses
+	}
+	return score
+}
+
+func isShortDeckWheel(hand []domain.Card) bool {
+	var seen [5]bool
+	for _, c := range hand {
+		switch c.Rank {
+		case domain.RankAce:
+			seen[0] = true
+		case domain.RankSix:
+			seen[1] = true
+		case domain.RankSeven:
+			seen[2] = true
+		case domain.RankEight:
+			seen[3] = true
+		case domain.RankNine:
+			seen[4] = true
+		default:
+			return false
+		}
+	}
+	return seen == [5]bool{true, true, true, true, true}
+}
+
+// forEachFiveCards calls fn with every five-card combination of cards. The
+// slice is reused between calls.
+func forEachFiveCards[T any](cards []T, fn func(hand []T)) {
+	n := len(cards)
+	if n < 5 {
+		fn(cards)
+		return
+	}
+
+	hand := make([]T, 5)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = cards[a], cards[b], cards[c], cards[d], cards[e]
+						fn(hand)
+					}
+				}
+			}
+		}
+	}
+}
+
+// forEachOmahaHand calls fn with every two-plus-three card combination. The
+// slice is reused between calls.
+func forEachOmahaHand[T any](holeCards, community []T, fn func(hand []T)) {
+	hand := make([]T, 5)
+	for i := 0; i < len(holeCards); i++ {
+		for j := i + 1; j < len(holeCards); j++ {
+			hand[0], hand[1] = holeCards[i], holeCards[j]
+			for a := 0; a < len(community); a++ {
+				for b := a + 1; b < len(community); b++ {
+					for c := b + 1; c < len(community); c++ {
+						hand[2], hand[3], hand[4] = community[a], community[b], community[c]
+						fn(hand)
+					}
+				}
+			}
+		}
+	}
+}
+
+// evaluateLow scores five cards as an eight-or-better low: five different
+// ranks from ace to eight. The score reads the ranks from the highest down,
+// four bits each, so a smaller score is a better low.
+func evaluateLow(cards []domain.Card) (HandRank, bool) {
+	var seen [9]bool
+	values := make([]int, 0, len(cards))
+	for _, c := range cards {
+		v := lowValue(c.Rank)
+		if v == 0 || seen[v] {
+			return HandRank{}, false
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(values)))
+
+	var score int32
+	names := make([]string, len(values))
+	for i, v := range values {
+		score = score<<4 | int32(v)
+		names[i] = lowRankNames[v]
+	}
+
+	name := strings.Join(names, "-") + " low"
+	return HandRank{
+		Score:       score,
+		Name:        name,
+		Cards:       append([]domain.Card(nil), cards...),
+		Category:    domain.HandLow,
+		Description: name,
+	}, true
+}
+
+var lowRankNames = [9]string{"", "A", "2", "3", "4", "5", "6", "7", "8"}
+
+// lowValue is a card's value for low hands, with the ace lowest. Ranks
+// above eight are worth zero as they cannot make an eight-or-better low.
+func lowValue(r domain.Rank) int {
+	switch r {
+	case domain.RankAce:
+		return 1
+	case domain.RankTwo:
+		return 2
+	case domain.RankThree:
+		return 3
+	case domain.RankFour:
+		return 4
+	case domain.RankFive:
+		return 5
+	case domain.RankSix:
+		return 6
+	case domain.RankSeven:
+		return 7
+	case domain.RankEight:
+		return 8
+	default:
+		return 0
+	}
+}
+
 func CompareHands(hands []HandRank) []int {
 	if len(hands) == 0 {
 		return nil
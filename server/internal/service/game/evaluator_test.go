@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// TestBestShortDeckHandRanking lists six-plus hands from best to worst.
+func TestBestShortDeckHandRanking(t *testing.T) {
+	tests := []struct {
+		name     string
+		hole     []string
+		board    []string
+		category domain.HandCategory
+	}{
+		{"ace-high straight flush", []string{"Ah", "Kh"}, []string{"Qh", "Jh", "Th", "6c", "7d"}, domain.HandStraightFlush},
+		{"wheel straight flush", []string{"Ah", "6h"}, []string{"7h", "8h", "9h", "Kc", "Kd"}, domain.HandStraightFlush},
+		{"four of a kind", []string{"Kh", "Ks"}, []string{"Kc", "Kd", "7s", "8c", "9d"}, domain.HandFourOfAKind},
+		{"flush", []string{"Ah", "Th"}, []string{"6h", "8h", "Jh", "Kc", "Kd"}, domain.HandFlush},
+		{"weaker flush", []string{"Qh", "Th"}, []string{"6h", "8h", "Jh", "Kc", "Kd"}, domain.HandFlush},
+		{"full house", []string{"Kh", "Ks"}, []string{"Kc", "7d", "7s", "8c", "9d"}, domain.HandFullHouse},
+		{"ten-high straight", []string{"6c", "Td"}, []string{"7h", "8s", "9d", "Kc", "Ks"}, domain.HandStraight},
+		{"wheel straight", []string{"Ac", "6d"}, []string{"7h", "8s", "9d", "Kc", "Ks"}, domain.HandStraight},
+		{"three of a kind", []string{"Kh", "Qd"}, []string{"Kc", "Ks", "7d", "8h", "Tc"}, domain.HandThreeOfAKind},
+		{"two pair", []string{"Kh", "Qd"}, []string{"Kc", "Qs", "7d", "8h", "6c"}, domain.HandTwoPair},
+		{"pair", []string{"Kh", "Qd"}, []string{"Kc", "Js", "7d", "8h", "6c"}, domain.HandPair},
+		{"high card", []string{"Ah", "Qd"}, []string{"Kc", "Js", "7d", "8h", "6c"}, domain.HandHighCard},
+	}
+
+	var prev HandRank
+	for i, tt := range tests {
+		rank := BestShortDeckHand(mustCards(t, tt.hole...), mustCards(t, tt.board...))
+		if rank.Category != tt.category {
+			t.Errorf("%s: category = %s, want %s", tt.name, rank.Category, tt.category)
+		}
+		if i > 0 && rank.Score <= prev.Score {
+			t.Errorf("%s scores %d, not worse than %s at %d", tt.name, rank.Score, tests[i-1].name, prev.Score)
+		}
+		prev = rank
+	}
+}
@@ -5,12 +5,18 @@ import (
 )
 
 var validTransitions = map[domain.GameStage][]domain.GameStage{
-	domain.StageWaiting:  {domain.StagePreflop},
+	domain.StageWaiting:  {domain.StagePreflop, domain.StageThirdStreet},
 	domain.StagePreflop:  {domain.StageFlop, domain.StageShowdown, domain.StageComplete},
 	domain.StageFlop:     {domain.StageTurn, domain.StageShowdown, domain.StageComplete},
 	domain.StageTurn:     {domain.StageRiver, domain.StageShowdown, domain.StageComplete},
 	domain.StageRiver:    {domain.StageShowdown, domain.StageComplete},
 	domain.StageShowdown: {domain.StageComplete},
+
+	domain.StageThirdStreet:   {domain.StageFourthStreet, domain.StageShowdown, domain.StageComplete},
+	domain.StageFourthStreet:  {domain.StageFifthStreet, domain.StageShowdown, domain.StageComplete},
+	domain.StageFifthStreet:   {domain.StageSixthStreet, domain.StageShowdown, domain.StageComplete},
+	domain.StageSixthStreet:   {domain.StageSeventhStreet, domain.StageShowdown, domain.StageComplete},
+	domain.StageSeventhStreet: {domain.StageShowdown, domain.StageComplete},
 }
 
 type GameFSM struct {
@@ -60,6 +66,16 @@ func (f GameFSM) NextStage() domain.GameStage {
 		return domain.StageRiver
 	case domain.StageRiver:
 		return domain.StageShowdown
+	case domain.StageThirdStreet:
+		return domain.StageFourthStreet
+	case domain.StageFourthStreet:
+		return domain.StageFifthStreet
+	case domain.StageFifthStreet:
+		return domain.StageSixthStreet
+	case domain.StageSixthStreet:
+		return domain.StageSeventhStreet
+	case domain.StageSeventhStreet:
+		return domain.StageShowdown
 	case domain.StageShowdown:
 		return domain.StageComplete
 	default:
@@ -0,0 +1,22 @@
+package game
+
+import (
+	"context"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// gameEngine plays the hands of one kind of game for a TableHub. The hub
+// owns seating, turns, timeouts and payouts; the engine deals the cards
+// and moves the hand on once a betting round is complete.
+type gameEngine interface {
+	startHand(ctx context.Context) error
+	advanceStage(ctx context.Context)
+}
+
+func newGameEngine(hub *TableHub) gameEngine {
+	if hub.state.Table.GameType == domain.GameStud {
+		return &studEngine{TableHub: hub}
+	}
+	return &holdemEngine{TableHub: hub, variant: VariantFor(hub.state.Table.GameType)}
+}
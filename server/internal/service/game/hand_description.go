@@ -0,0 +1,146 @@
+package game
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// Upper bounds of each category's scores, best category first.
+var categoryBounds = []struct {
+	max      int32
+	category domain.HandCategory
+}{
+	{scoreStraightFlushMax, domain.HandStraightFlush},
+	{scoreFourOfAKindMax, domain.HandFourOfAKind},
+	{scoreFullHouseMax, domain.HandFullHouse},
+	{scoreFlushMax, domain.HandFlush},
+	{scoreStraightMax, domain.HandStraight},
+	{scoreThreeOfAKindMax, domain.HandThreeOfAKind},
+	{scoreTwoPairMax, domain.HandTwoPair},
+	{scorePairMax, domain.HandPair},
+}
+
+// categoryForScore is the category of a standard high-hand score.
+func categoryForScore(score int32) domain.HandCategory {
+	for _, b := range categoryBounds {
+		if score <= b.max {
+			return b.category
+		}
+	}
+	return domain.HandHighCard
+}
+
+var categoryNames = map[domain.HandCategory]string{
+	domain.HandStraightFlush: "Straight Flush",
+	domain.HandFourOfAKind:   "Four of a Kind",
+	domain.HandFullHouse:     "Full House",
+	domain.HandFlush:         "Flush",
+	domain.HandStraight:      "Straight",
+	domain.HandThreeOfAKind:  "Three of a Kind",
+	domain.HandTwoPair:       "Two Pair",
+	domain.HandPair:          "Pair",
+	domain.HandHighCard:      "High Card",
+}
+
+var rankNames = map[int]string{
+	2: "Two", 3: "Three", 4: "Four", 5: "Five", 6: "Six", 7: "Seven", 8: "Eight",
+	9: "Nine", 10: "Ten", 11: "Jack", 12: "Queen", 13: "King", 14: "Ace",
+}
+
+func rankPlural(v int) string {
+	if v == 6 {
+		return "Sixes"
+	}
+	return rankNames[v] + "s"
+}
+
+// describeHand names a five-card hand of the given category with its
+// kickers, such as "two pair, Aces and Kings, Queen kicker".
+func describeHand(category domain.HandCategory, cards []domain.Card) string {
+	groups := rankGroups(cards)
+
+	switch category {
+	case domain.HandStraightFlush:
+		low, high := straightEnds(groups)
+		if high == 14 {
+			return "royal flush"
+		}
+		return "straight flush, " + rankNames[low] + " to " + rankNames[high]
+	case domain.HandFourOfAKind:
+		return "four of a kind, " + rankPlural(groups[0].value) + kickers(groups[1:])
+	case domain.HandFullHouse:
+		return "full house, " + rankPlural(groups[0].value) + " full of " + rankPlural(groups[1].value)
+	case domain.HandFlush:
+		return "flush, " + joinRanks(groups)
+	case domain.HandStraight:
+		low, high := straightEnds(groups)
+		return "straight, " + rankNames[low] + " to " + rankNames[high]
+	case domain.HandThreeOfAKind:
+		return "three of a kind, " + rankPlural(groups[0].value) + kickers(groups[1:])
+	case domain.HandTwoPair:
+		return "two pair, " + rankPlural(groups[0].value) + " and " + rankPlural(groups[1].value) + kickers(groups[2:])
+	case domain.HandPair:
+		return "pair of " + rankPlural(groups[0].value) + kickers(groups[1:])
+	default:
+		if len(groups) == 0 {
+			return ""
+		}
+		return "high card, " + rankNames[groups[0].value] + kickers(groups[1:])
+	}
+}
+
+type rankGroup struct {
+	value int
+	count int
+}
+
+// rankGroups groups cards by rank, the biggest groups first and higher
+// ranks first within a size.
+func rankGroups(cards []domain.Card) []rankGroup {
+	counts := make(map[int]int, len(cards))
+	for _, c := range cards {
+		counts[c.Rank.Value()]++
+	}
+
+	groups := make([]rankGroup, 0, len(counts))
+	for v, n := range counts {
+		groups = append(groups, rankGroup{value: v, count: n})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].count != groups[j].count {
+			return groups[i].count > groups[j].count
+		}
+		return groups[i].value > groups[j].value
+	})
+	return groups
+}
+
+// straightEnds returns the bottom and top of a straight, playing the ace
+// low in A-2-3-4-5 and in the short deck's A-6-7-8-9.
+func straightEnds(groups []rankGroup) (int, int) {
+	high, low := groups[0].value, groups[len(groups)-1].value
+	if high == 14 && groups[1].value != 13 {
+		return 14, groups[1].value
+	}
+	return low, high
+}
+
+func kickers(groups []rankGroup) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	if len(groups) == 1 {
+		return ", " + rankNames[groups[0].value] + " kicker"
+	}
+	return ", " + joinRanks(groups) + " kickers"
+}
+
+func joinRanks(groups []rankGroup) string {
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = rankNames[g.value]
+	}
+	return strings.Join(names, "-")
+}
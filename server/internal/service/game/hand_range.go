@@ -0,0 +1,234 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+var rangeRanks = []domain.Rank{
+	domain.RankTwo, domain.RankThree, domain.RankFour, domain.RankFive, domain.RankSix,
+	domain.RankSeven, domain.RankEight, domain.RankNine, domain.RankTen, domain.RankJack,
+	domain.RankQueen, domain.RankKing, domain.RankAce,
+}
+
+var rangeSuits = []domain.Suit{domain.SuitSpades, domain.SuitHearts, domain.SuitDiamonds, domain.SuitClubs}
+
+// ParseHandRange lists the hands a range holds, each once. A range is a
+// comma-separated list of exact hands, such as "AsKs", and for two-card
+// games of hand classes: "QQ", "AK", "AKs" and "AKo", "TT+" for tens or
+// better, "ATs+" for AT suited up to AK suited, and spans like "22-55" or
+// "A2s-A5s". Hands with a card missing from the variant's deck are
+// rejected.
+func ParseHandRange(s string, variant Variant) ([][]domain.Card, error) {
+	inDeck := make(map[domain.Card]bool)
+	for _, c := range variant.Deck() {
+		inDeck[c] = true
+	}
+
+	var hands [][]domain.Card
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		parsed, err := parseRangePart(part, variant.HoleCards)
+		if err != nil {
+			return nil, err
+		}
+		for _, hand := range parsed {
+			for _, c := range hand {
+				if !inDeck[c] {
+					return nil, fmt.Errorf("%q: %s is not in the deck", part, c)
+				}
+			}
+			if key := handKey(hand); !seen[key] {
+				seen[key] = true
+				hands = append(hands, hand)
+			}
+		}
+	}
+
+	if len(hands) == 0 {
+		return nil, fmt.Errorf("%q holds no hands", s)
+	}
+	return hands, nil
+}
+
+func parseRangePart(part string, holeCards int) ([][]domain.Card, error) {
+	if hand, ok := parseExactHand(part, holeCards); ok {
+		return [][]domain.Card{hand}, nil
+	}
+	if holeCards != 2 {
+		return nil, fmt.Errorf("%q is not a hand of %d cards", part, holeCards)
+	}
+
+	if from, to, ok := strings.Cut(part, "-"); ok {
+		return parseClassSpan(part, from, to)
+	}
+	if class, ok := strings.CutSuffix(part, "+"); ok {
+		c, err := parseHandClass(class)
+		if err != nil {
+			return nil, err
+		}
+		top := c
+		if c.high == c.low {
+			top.high, top.low = len(rangeRanks)-1, len(rangeRanks)-1
+		} else {
+			top.low = c.high - 1
+		}
+		return classSpan(c, top), nil
+	}
+
+	c, err := parseHandClass(part)
+	if err != nil {
+		return nil, err
+	}
+	return c.hands(), nil
+}
+
+// parseExactHand reads a hand written card by card, such as "AsKd".
+func parseExactHand(part string, holeCards int) ([]domain.Card, bool) {
+	if len(part) != 2*holeCards {
+		return nil, false
+	}
+
+	hand := make([]domain.Card, holeCards)
+	for i := range hand {
+		c, err := domain.ParseCard(part[2*i : 2*i+2])
+		if err != nil {
+			return nil, false
+		}
+		hand[i] = c
+	}
+	return hand, len(hand) == len(uniqueCards(hand))
+}
+
+// handClass is a pair or two ranks, by index into rangeRanks, with or
+// without a suitedness.
+type handClass struct {
+	high, low int
+	suited    byte // 's', 'o' or 0 for both
+}
+
+func parseHandClass(s string) (handClass, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return handClass{}, fmt.Errorf("%q is not a hand class", s)
+	}
+
+	high, low := rankIndex(s[0]), rankIndex(s[1])
+	if high < 0 || low < 0 {
+		return handClass{}, fmt.Errorf("%q is not a hand class", s)
+	}
+	if low > high {
+		high, low = low, high
+	}
+
+	c := handClass{high: high, low: low}
+	if len(s) == 3 {
+		c.suited = s[2]
+		if (c.suited != 's' && c.suited != 'o') || high == low {
+			return handClass{}, fmt.Errorf("%q is not a hand class", s)
+		}
+	}
+	return c, nil
+}
+
+// parseClassSpan reads a span of pairs, such as "22-55", or of hands with
+// the same top card, such as "A2s-A5s".
+func parseClassSpan(part, from, to string) ([][]domain.Card, error) {
+	a, err := parseHandClass(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := parseHandClass(to)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := a.high == a.low && b.high == b.low
+	kickers := a.high == b.high && a.high != a.low && b.high != b.low && a.suited == b.suited
+	if !pairs && !kickers {
+		return nil, fmt.Errorf("%q is not a span of pairs or of kickers", part)
+	}
+	if pairs && a.high > b.high {
+		a, b = b, a
+	}
+	if kickers && a.low > b.low {
+		a, b = b, a
+	}
+	return classSpan(a, b), nil
+}
+
+// classSpan lists the hands of every class from a up to b, which share a
+// shape: both pairs, or the same top card with the kicker moving.
+func classSpan(a, b handClass) [][]domain.Card {
+	var hands [][]domain.Card
+	for c := a; ; {
+		hands = append(hands, c.hands()...)
+		if c == b {
+			return hands
+		}
+		if c.high == c.low {
+			c.high++
+			c.low++
+		} else {
+			c.low++
+		}
+		if c.high >= len(rangeRanks) {
+			return hands
+		}
+	}
+}
+
+// hands lists the two-card hands of the class.
+func (c handClass) hands() [][]domain.Card {
+	high, low := rangeRanks[c.high], rangeRanks[c.low]
+
+	var hands [][]domain.Card
+	for i, s1 := range rangeSuits {
+		for j, s2 := range rangeSuits {
+			switch {
+			case c.high == c.low && j <= i:
+				continue
+			case c.suited == 's' && i != j, c.suited == 'o' && i == j:
+				continue
+			}
+			hands = append(hands, []domain.Card{{Rank: high, Suit: s1}, {Rank: low, Suit: s2}})
+		}
+	}
+	return hands
+}
+
+func rankIndex(b byte) int {
+	for i, r := range rangeRanks {
+		if string(r) == strings.ToUpper(string(b)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// handKey names a hand regardless of the order of its cards.
+func handKey(hand []domain.Card) string {
+	var mask uint64
+	for _, c := range hand {
+		mask |= 1 << EncodeCard(c)
+	}
+	return fmt.Sprint(mask)
+}
+
+func uniqueCards(cards []domain.Card) []domain.Card {
+	seen := make(map[domain.Card]bool, len(cards))
+	unique := make([]domain.Card, 0, len(cards))
+	for _, c := range cards {
+		if !seen[c] {
+			seen[c] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,557 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// boardSize is the number of community cards on a full board.
+const boardSize = 5
+
+// holdemEngine deals the community-card games: hold'em, Omaha and their
+// variants.
+type holdemEngine struct {
+	*TableHub
+	variant Variant
+}
+
+func (h *holdemEngine) startHand(ctx context.Context) error {
+	serverSeed, err := h.rngSvc.GenerateServerSeed()
+	if err != nil {
+		return fmt.Errorf("generate server seed: %w", err)
+	}
+
+	seats := h.handSeats()
+	if len(seats) < 2 {
+		return domain.ErrMinPlayersRequired
+	}
+
+	h.state.HandCount++
+	h.state.DealerSeat = nextOccupiedSeat(seats, h.state.DealerSeat)
+	h.refillTimeBanks()
+
+	deck := h.rngSvc.ShuffleDeck(h.variant.Deck(), serverSeed, "default", h.state.HandCount)
+
+	hand := domain.PokerHand{
+		ID:         uuid.New(),
+		TableID:    h.state.Table.ID,
+		HandNumber: h.state.HandCount,
+		Pot:        decimal.Zero,
+		Stage:      domain.StagePreflop,
+	}
+
+	bettingPlayers := make([]BettingPlayer, 0, len(seats))
+	playerHands := make(map[uuid.UUID][]domain.Card)
+	cumulativeBets := make(map[uuid.UUID]decimal.Decimal)
+	deckIdx := 0
+
+	for _, seat := range seats {
+		p := h.state.Players[seat]
+		if p.IsSittingOut() {
+			updated := *p
+			updated.Status = domain.PlayerStatusActive
+			updated.WaitingForBigBlind = false
+			h.state.Players[seat] = &updated
+			p = &updated
+		}
+
+		holeCards := append([]domain.Card(nil), deck[deckIdx:deckIdx+h.variant.HoleCards]...)
+		deckIdx += h.variant.HoleCards
+		playerHands[p.ID] = holeCards
+		cumulativeBets[p.ID] = decimal.Zero
+
+		bettingPlayers = append(bettingPlayers, BettingPlayer{
+			PlayerID:     p.ID,
+			Stack:        p.Stack,
+			BetThisRound: decimal.Zero,
+			HasActed:     false,
+			IsAllIn:      false,
+			IsFolded:     false,
+		})
+	}
+
+	betting := NewBettingState(bettingPlayers, h.betSize(domain.StagePreflop), decimal.Zero, h.bettingLimit())
+
+	dealerIdx := dealerIndex(seats, h.state.DealerSeat)
+	sbIdx, bbIdx := h.blindIndices(seats)
+	if sbIdx >= 0 {
+		h.markMissedBlinds(seats[sbIdx], seats[bbIdx])
+		h.state.SmallBlindSeat = seats[sbIdx]
+		h.state.BigBlindSeat = seats[bbIdx]
+	}
+
+	dead := make(map[uuid.UUID]decimal.Decimal)
+	betting = h.postBlinds(betting, sbIdx, bbIdx)
+	betting = h.postMissedBlinds(betting, sbIdx, bbIdx, dead)
+	betting = h.postAntes(betting, bbIdx, dead)
+	betting, straddleIdx := h.postStraddle(betting, sbIdx, bbIdx)
+
+	betting.CurrentIdx = h.preflopFirstToAct(betting.Players, dealerIdx, sbIdx, bbIdx, straddleIdx)
+
+	fsm, err := NewGameFSM().Transition(domain.StagePreflop)
+	if err != nil {
+		return fmt.Errorf("FSM transition to preflop: %w", err)
+	}
+
+	h.state.Hand = &HandState{
+		Hand:           hand,
+		FSM:            fsm,
+		Deck:           deck[deckIdx:],
+		CommunityCards: nil,
+		PlayerHands:    playerHands,
+		Betting:        betting,
+		CumulativeBets: cumulativeBets,
+		DeadMoney:      dead,
+		ServerSeed:     serverSeed,
+		SeedHash:       h.rngSvc.HashSeed(serverSeed),
+		ClientSeed:     "default",
+		Nonce:          h.state.HandCount,
+		ActionOrder:    0,
+		PreActions:     make(map[uuid.UUID]domain.PreAction),
+	}
+
+	if _, err := h.handRepo.Create(ctx, hand); err != nil {
+		h.logger.Error("failed to persist hand", "error", err)
+	}
+
+	for id, cards := range playerHands {
+		var userID uuid.UUID
+		for _, p := range h.state.Players {
+			if p.ID == id {
+				userID = p.UserID
+				break
+			}
+		}
+		h.sendCardsDealt(userID, hand.ID, cards)
+	}
+
+	h.broadcastNewHand()
+	h.broadcastPotUpdate()
+	h.startTurn(ctx)
+
+	return nil
+}
+
+func (h *holdemEngine) advanceStage(ctx context.Context) {
+	h.exposeAllInHands()
+	if h.offerRunItTwice() {
+		return
+	}
+	if h.runsTwice() {
+		h.doShowdown(ctx)
+		return
+	}
+
+	handState := h.state.Hand
+	nextStage := handState.FSM.NextStage()
+
+	if nextStage == domain.StageShowdown {
+		h.doShowdown(ctx)
+		return
+	}
+
+	newFSM, err := handState.FSM.Transition(nextStage)
+	if err != nil {
+		h.logger.Error("FSM transition failed", "error", err, "to", nextStage)
+		return
+	}
+
+	switch nextStage {
+	case domain.StageFlop:
+		h.dealCommunity(3)
+	case domain.StageTurn, domain.StageRiver:
+		h.dealCommunity(1)
+	}
+
+	h.state.Hand.FSM = newFSM
+	h.state.Hand.Hand.Stage = nextStage
+	h.clearPreActions()
+	canAct := h.openBettingRound(h.betSize(nextStage))
+
+	h.broadcastCommunityCards()
+	h.broadcastEquity()
+
+	if !canAct {
+		h.advanceStage(ctx)
+		return
+	}
+
+	h.startTurn(ctx)
+}
+
+func (h *holdemEngine) doShowdown(ctx context.Context) {
+	handState := h.state.Hand
+	h.bankBets()
+
+	if h.runsTwice() {
+		h.runTwice()
+	} else {
+		h.runOut()
+	}
+
+	for handState.FSM.Stage() != domain.StageRiver && handState.FSM.Stage() != domain.StageShowdown {
+		nextStage := handState.FSM.NextStage()
+		if nextStage == domain.StageShowdown {
+			break
+		}
+		newFSM, err := handState.FSM.Transition(nextStage)
+		if err != nil {
+			break
+		}
+		handState.FSM = newFSM
+	}
+
+	newFSM, _ := handState.FSM.Transition(domain.StageShowdown)
+	h.state.Hand.FSM = newFSM
+
+	h.settleShowdown(ctx, h.variant)
+}
+
+// runOut deals the rest of the board.
+func (h *holdemEngine) runOut() {
+	for len(h.state.Hand.CommunityCards) < boardSize {
+		dealt := len(h.state.Hand.CommunityCards)
+		if dealt == 0 {
+			h.dealCommunity(3)
+		} else {
+			h.dealCommunity(1)
+		}
+		if len(h.state.Hand.CommunityCards) == dealt {
+			return
+		}
+	}
+}
+
+// runTwice deals the rest of the board twice from the same deck, keeping
+// the first runout as the community cards and the second as SecondBoard.
+func (h *holdemEngine) runTwice() {
+	hand := h.state.Hand
+	shared := append([]domain.Card(nil), hand.CommunityCards...)
+
+	h.runOutStreets(1)
+	first := hand.CommunityCards
+
+	hand.CommunityCards = append([]domain.Card(nil), shared...)
+	h.runOutStreets(2)
+	hand.SecondBoard = hand.CommunityCards
+
+	hand.CommunityCards = first
+	hand.Hand.CommunityCards = domain.CardsToString(first)
+	hand.Hand.SecondBoard = domain.CardsToString(hand.SecondBoard)
+}
+
+// runOutStreets deals the rest of the board for one run a street at a
+// time, sending each street and the equities on it.
+func (h *holdemEngine) runOutStreets(run int) {
+	for len(h.state.Hand.CommunityCards) < boardSize {
+		dealt := len(h.state.Hand.CommunityCards)
+		if dealt == 0 {
+			h.dealCommunity(3)
+		} else {
+			h.dealCommunity(1)
+		}
+		if len(h.state.Hand.CommunityCards) == dealt {
+			return
+		}
+
+		stage := boardStage(len(h.state.Hand.CommunityCards))
+		h.broadcastRunout(run, stage, h.state.Hand.CommunityCards)
+		h.broadcastRunEquity(run, stage)
+	}
+}
+
+// boardStage is the street a board of n cards has reached.
+func boardStage(n int) domain.GameStage {
+	switch n {
+	case 3:
+		return domain.StageFlop
+	case 4:
+		return domain.StageTurn
+	default:
+		return domain.StageRiver
+	}
+}
+
+// dealCommunity burns a card and deals count cards to the board, as a
+// live dealer would, so the deck order replays the same deal.
+func (h *holdemEngine) dealCommunity(count int) {
+	if h.state.Hand == nil || len(h.state.Hand.Deck) < count+1 {
+		return
+	}
+
+	h.state.Hand.BurnCards = append(h.state.Hand.BurnCards, h.state.Hand.Deck[0])
+	cards := h.state.Hand.Deck[1 : count+1]
+	h.state.Hand.Deck = h.state.Hand.Deck[count+1:]
+	h.state.Hand.CommunityCards = append(h.state.Hand.CommunityCards, cards...)
+	h.state.Hand.Hand.CommunityCards = domain.CardsToString(h.state.Hand.CommunityCards)
+}
+
+func (h *holdemEngine) bettingLimit() domain.BettingLimit {
+	if h.state.Table.Limit == "" {
+		return h.state.Table.GameType.DefaultLimit()
+	}
+	return h.state.Table.Limit
+}
+
+// betSize is the smallest bet on a street: the big blind, doubled on the
+// turn and river of fixed-limit games.
+func (h *holdemEngine) betSize(stage domain.GameStage) decimal.Decimal {
+	bb := h.state.Table.BigBlind
+	if h.bettingLimit() == domain.LimitFixed && (stage == domain.StageTurn || stage == domain.StageRiver) {
+		return bb.Mul(decimal.NewFromInt(2))
+	}
+	return bb
+}
+
+// postBlinds posts the blinds the table's structure has, skipping any
+// index of -1.
+func (h *holdemEngine) postBlinds(betting BettingState, sbIdx, bbIdx int) BettingState {
+	if sbIdx >= 0 {
+		betting = h.postLive(betting, sbIdx, h.state.Table.SmallBlind)
+	}
+	if bbIdx >= 0 {
+		betting = h.postLive(betting, bbIdx, h.state.Table.BigBlind)
+	}
+	return betting
+}
+
+// blindIndices places the blinds for the table's blind structure. A
+// button blind is a big blind posted by the dealer; an ante-only table
+// posts no blinds. Blinds the structure lacks are -1.
+func (h *holdemEngine) blindIndices(seats []int) (sbIdx, bbIdx int) {
+	switch h.state.Table.BlindType {
+	case domain.BlindsButton:
+		return -1, dealerIndex(seats, h.state.DealerSeat)
+	case domain.BlindsAnteOnly:
+		return -1, -1
+	default:
+		return blindPositions(seats, h.state.DealerSeat)
+	}
+}
+
+// postMissedBlinds collects what returning players owe: a missed big blind
+// is posted live and a missed small blind goes into the pot dead. Players
+// in the blinds this hand owe nothing more.
+func (h *holdemEngine) postMissedBlinds(betting BettingState, sbIdx, bbIdx int, dead map[uuid.UUID]decimal.Decimal) BettingState {
+	for i, bp := range betting.Players {
+		p := h.state.FindPlayerByID(bp.PlayerID)
+		if p == nil || !p.OwesBlinds() {
+			continue
+		}
+
+		owesSB, owesBB := p.MissedSmallBlind, p.MissedBigBlind
+		updated := *p
+		updated.MissedSmallBlind = false
+		updated.MissedBigBlind = false
+		h.state.Players[p.SeatNumber] = &updated
+
+		if i == sbIdx || i == bbIdx {
+			continue
+		}
+		if owesBB {
+			betting = h.postLive(betting, i, h.state.Table.BigBlind)
+		}
+		if owesSB {
+			betting = h.postDead(betting, i, h.state.Table.SmallBlind, dead)
+		}
+	}
+
+	return betting
+}
+
+// postAntes collects the table's antes: one from every player dealt in, or
+// a single one from the big blind. They come after the blinds, so a short
+// stack's chips play live first.
+func (h *holdemEngine) postAntes(betting BettingState, bbIdx int, dead map[uuid.UUID]decimal.Decimal) BettingState {
+	ante := h.state.Table.Ante
+	if !ante.IsPositive() {
+		return betting
+	}
+
+	switch h.state.Table.AnteType {
+	case domain.AntePerPlayer:
+		for i := range betting.Players {
+			betting = h.postDead(betting, i, ante, dead)
+		}
+	case domain.AnteBigBlind:
+		if bbIdx >= 0 {
+			betting = h.postDead(betting, bbIdx, ante, dead)
+		}
+	}
+
+	return betting
+}
+
+// postStraddle has the table's straddle seat post two big blinds live. A
+// full straddle sets the size of the next raise like a big blind does. It
+// returns the straddler's index, or -1 if nobody straddled.
+func (h *holdemEngine) postStraddle(betting BettingState, sbIdx, bbIdx int) (BettingState, int) {
+	n := len(betting.Players)
+	if n < 3 || sbIdx < 0 {
+		return betting, -1
+	}
+
+	var idx int
+	switch h.state.Table.Straddle {
+	case domain.StraddleUTG:
+		idx = (bbIdx + 1) % n
+	case domain.StraddleButton:
+		idx = (sbIdx + n - 1) % n
+	default:
+		return betting, -1
+	}
+	if betting.Players[idx].IsAllIn {
+		return betting, -1
+	}
+
+	amount := h.state.Table.StraddleAmount()
+	betting = h.postLive(betting, idx, amount)
+	if betting.Players[idx].BetThisRound.Equal(amount) {
+		betting.MinRaise = amount
+	}
+
+	return betting, idx
+}
+
+// preflopFirstToAct is the seat after the big blind, or after a UTG
+// straddle. A button straddle acts last, so the action starts with the
+// small blind. Without a big blind the action starts left of the dealer.
+func (h *holdemEngine) preflopFirstToAct(players []BettingPlayer, dealerIdx, sbIdx, bbIdx, straddleIdx int) int {
+	n := len(players)
+	last := bbIdx
+	if last < 0 {
+		last = dealerIdx
+	}
+	start := (last + 1) % n
+	if straddleIdx >= 0 {
+		switch h.state.Table.Straddle {
+		case domain.StraddleUTG:
+			start = (straddleIdx + 1) % n
+		case domain.StraddleButton:
+			start = sbIdx
+		}
+	}
+
+	if !players[start].IsFolded && !players[start].IsAllIn {
+		return start
+	}
+	return NextPlayer(players, start)
+}
+
+// markMissedBlinds charges the sitting-out players the blinds skipped past
+// on their way from last hand's seats to this hand's.
+func (h *holdemEngine) markMissedBlinds(sbSeat, bbSeat int) {
+	if h.state.BigBlindSeat == 0 {
+		return
+	}
+
+	for seat, p := range h.state.Players {
+		if !p.IsSittingOut() {
+			continue
+		}
+		missedSB := seatBetween(seat, h.state.SmallBlindSeat, sbSeat)
+		missedBB := seatBetween(seat, h.state.BigBlindSeat, bbSeat)
+		if !missedSB && !missedBB {
+			continue
+		}
+
+		updated := *p
+		updated.MissedSmallBlind = updated.MissedSmallBlind || missedSB
+		updated.MissedBigBlind = updated.MissedBigBlind || missedBB
+		h.state.Players[seat] = &updated
+	}
+}
+
+// handSeats returns the seats to deal into the next hand, in order.
+// Players waiting for the big blind are dealt in once it would pass them,
+// or straight away if the table would otherwise be short.
+func (h *holdemEngine) handSeats() []int {
+	var ready, waiting []int
+	for seat, p := range h.state.Players {
+		switch {
+		case !p.IsSittingOut():
+			ready = append(ready, seat)
+		case p.WaitingForBigBlind:
+			waiting = append(waiting, seat)
+		}
+	}
+	sort.Ints(ready)
+
+	if len(ready) < 2 {
+		seats := append(ready, waiting...)
+		sort.Ints(seats)
+		return seats
+	}
+	if len(waiting) == 0 {
+		return ready
+	}
+	if h.state.BigBlindSeat == 0 {
+		// Without the usual blinds there is no big blind to wait for.
+		if h.state.Table.BlindType == domain.BlindsAnteOnly || h.state.Table.BlindType == domain.BlindsButton {
+			seats := append(ready, waiting...)
+			sort.Ints(seats)
+			return seats
+		}
+		return ready
+	}
+
+	_, bbIdx := blindPositions(ready, nextOccupiedSeat(ready, h.state.DealerSeat))
+	seats := ready
+	for _, seat := range waiting {
+		if seatBetween(seat, h.state.BigBlindSeat, ready[bbIdx]) {
+			seats = append(seats, seat)
+		}
+	}
+	sort.Ints(seats)
+	return seats
+}
+
+func blindPositions(seats []int, dealerSeat int) (sbIdx, bbIdx int) {
+	n := len(seats)
+	dealerSeatIdx := dealerIndex(seats, dealerSeat)
+
+	if n == 2 {
+		return dealerSeatIdx, (dealerSeatIdx + 1) % n
+	}
+	return (dealerSeatIdx + 1) % n, (dealerSeatIdx + 2) % n
+}
+
+// seatBetween reports whether seat lies strictly between from and to going
+// clockwise round the table.
+func seatBetween(seat, from, to int) bool {
+	switch {
+	case from < to:
+		return seat > from && seat < to
+	case from > to:
+		return seat > from || seat < to
+	default:
+		return seat != from
+	}
+}
+
+func (h *holdemEngine) broadcastRunout(run int, stage domain.GameStage, cards []domain.Card) {
+	payload := map[string]any{
+		"cards": cards,
+		"stage": stage,
+		"run":   run,
+	}
+	msg := h.buildMessage(domain.WSMsgCommunity, payload)
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+}
+
+func (h *holdemEngine) broadcastCommunityCards() {
+	if h.state.Hand == nil {
+		return
+	}
+	payload := map[string]any{
+		"cards": h.state.Hand.CommunityCards,
+		"stage": h.state.Hand.FSM.Stage(),
+	}
+	msg := h.buildMessage(domain.WSMsgCommunity, payload)
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+}
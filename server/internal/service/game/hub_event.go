@@ -15,6 +15,13 @@ const (
 	EventStartHand
 	EventTimerExpired
 	EventShutdown
+	EventPlayerRename
+	EventSitOut
+	EventSitIn
+	EventPreAction
+	EventRunItTwice
+	EventShowCards
+	EventMuckCards
 )
 
 type HubEvent struct {
@@ -26,6 +33,12 @@ type HubEvent struct {
 	SeatNum  int
 	BuyIn    decimal.Decimal
 	Username string
+	// WaitForBigBlind is set on EventSitIn by players who would rather
+	// wait for the big blind than post the blinds they missed.
+	WaitForBigBlind bool
+	PreAction       domain.PreActionType
+	// Agree answers a run it twice offer on EventRunItTwice.
+	Agree    bool
 	ResultCh chan HubResult
 }
 
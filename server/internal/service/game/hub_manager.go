@@ -4,7 +4,6 @@ import (
 	"context"
 	"log/slog"
 	"sync"
-	"time"
 
 	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
@@ -14,34 +13,37 @@ import (
 type HubManager struct {
 	mu          sync.RWMutex
 	hubs        map[uuid.UUID]*TableHub
-	turnTimeout time.Duration
+	clock       TurnClock
 	broadcaster ports.Broadcaster
 	walletSvc   ports.WalletService
 	rngSvc      ports.RNGService
 	handRepo    ports.PokerHandRepository
 	playerRepo  ports.PokerPlayerRepository
+	rakeRepo    ports.RakeRepository
 	logger      *slog.Logger
 	baseCtx     context.Context
 }
 
 func NewHubManager(
 	baseCtx context.Context,
-	turnTimeout time.Duration,
+	clock TurnClock,
 	broadcaster ports.Broadcaster,
 	walletSvc ports.WalletService,
 	rngSvc ports.RNGService,
 	handRepo ports.PokerHandRepository,
 	playerRepo ports.PokerPlayerRepository,
+	rakeRepo ports.RakeRepository,
 	logger *slog.Logger,
 ) *HubManager {
 	return &HubManager{
 		hubs:        make(map[uuid.UUID]*TableHub),
-		turnTimeout: turnTimeout,
+		clock:       clock,
 		broadcaster: broadcaster,
 		walletSvc:   walletSvc,
 		rngSvc:      rngSvc,
 		handRepo:    handRepo,
 		playerRepo:  playerRepo,
+		rakeRepo:    rakeRepo,
 		logger:      logger,
 		baseCtx:     baseCtx,
 	}
@@ -55,18 +57,48 @@ func (m *HubManager) GetOrCreateHub(ctx context.Context, table domain.PokerTable
 		return hub
 	}
 
-	hub := NewTableHub(
+	hub := m.newHub(table)
+	m.run(ctx, hub)
+	return hub
+}
+
+// openTournamentTable opens one of a tournament's tables with its first
+// players already seated. The director deals its hands.
+func (m *HubManager) openTournamentTable(ctx context.Context, table domain.PokerTable, director *TournamentDirector, players []domain.PokerPlayer) *TableHub {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hub := m.newHub(table)
+	hub.director = director
+	for _, p := range players {
+		p.TableID = table.ID
+		p.Status = domain.PlayerStatusActive
+		p.TimeBank = m.clock.TimeBank
+		hub.state.Players[p.SeatNumber] = &p
+	}
+
+	m.run(ctx, hub)
+	return hub
+}
+
+func (m *HubManager) newHub(table domain.PokerTable) *TableHub {
+	return NewTableHub(
 		table,
-		m.turnTimeout,
+		m.clock,
 		m.broadcaster,
 		m.walletSvc,
 		m.rngSvc,
 		m.handRepo,
 		m.playerRepo,
+		m.rakeRepo,
 		m.logger,
 	)
+}
 
-	m.hubs[table.ID] = hub
+// run registers hub and runs it until it stops. The caller must hold mu.
+func (m *HubManager) run(ctx context.Context, hub *TableHub) {
+	tableID := hub.state.Table.ID
+	m.hubs[tableID] = hub
 
 	runCtx := m.baseCtx
 	if runCtx == nil {
@@ -75,11 +107,9 @@ func (m *HubManager) GetOrCreateHub(ctx context.Context, table domain.PokerTable
 	go func() {
 		hub.Run(runCtx)
 		m.mu.Lock()
-		delete(m.hubs, table.ID)
+		delete(m.hubs, tableID)
 		m.mu.Unlock()
 	}()
-
-	return hub
 }
 
 func (m *HubManager) GetHub(tableID uuid.UUID) *TableHub {
@@ -104,6 +134,21 @@ func (m *HubManager) RemoveHub(tableID uuid.UUID) {
 	}
 }
 
+// RenamePlayer implements ports.PlayerRenamer. Hubs where the user is not
+// seated ignore the event.
+func (m *HubManager) RenamePlayer(userID uuid.UUID, username string) {
+	m.mu.RLock()
+	hubs := make([]*TableHub, 0, len(m.hubs))
+	for _, hub := range m.hubs {
+		hubs = append(hubs, hub)
+	}
+	m.mu.RUnlock()
+
+	for _, hub := range hubs {
+		_ = hub.Send(HubEvent{Type: EventPlayerRename, UserID: userID, Username: username})
+	}
+}
+
 func (m *HubManager) ShutdownAll() {
 	m.mu.Lock()
 	hubsCopy := make(map[uuid.UUID]*TableHub, len(m.hubs))
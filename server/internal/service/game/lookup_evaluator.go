@@ -0,0 +1,413 @@
+package game
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// CardCode is a card packed into a byte for the evaluator: the rank from
+// 0 (two) to 12 (ace) times four, plus the suit.
+type CardCode uint8
+
+// EncodeCard packs a card for EvaluateCodes.
+func EncodeCard(c domain.Card) CardCode {
+	return CardCode(rankCode(c.Rank)<<2 | suitCode(c.Suit))
+}
+
+// EncodeCards packs cards into dst, which is grown if it is too small, and
+// returns it.
+func EncodeCards(dst []CardCode, cards []domain.Card) []CardCode {
+	dst = dst[:0]
+	for _, c := range cards {
+		dst = append(dst, EncodeCard(c))
+	}
+	return dst
+}
+
+// rankCode numbers ranks from 0 for a two up to 12 for an ace.
+func rankCode(r domain.Rank) int {
+	switch r {
+	case domain.RankTwo:
+		return 0
+	case domain.RankThree:
+		return 1
+	case domain.RankFour:
+		return 2
+	case domain.RankFive:
+		return 3
+	case domain.RankSix:
+		return 4
+	case domain.RankSeven:
+		return 5
+	case domain.RankEight:
+		return 6
+	case domain.RankNine:
+		return 7
+	case domain.RankTen:
+		return 8
+	case domain.RankJack:
+		return 9
+	case domain.RankQueen:
+		return 10
+	case domain.RankKing:
+		return 11
+	default:
+		return 12
+	}
+}
+
+func suitCode(s domain.Suit) int {
+	switch s {
+	case domain.SuitSpades:
+		return 0
+	case domain.SuitHearts:
+		return 1
+	case domain.SuitDiamonds:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// EvaluateCodes scores the best five of five to seven cards, lower being
+// better, on the same 1 to 7462 scale as HandRank. It does not allocate.
+// Any other number of cards scores math.MaxInt32.
+//
+// Each card adds its code's key to a sum that counts the cards of each
+// suit and, in base five, of each rank. A suit with five or more cards is
+// a flush, which nothing else those cards make can beat, and is looked up
+// by its ranks. Any other hand is looked up by its rank counts, which the
+// base-five sums of the low and high ranks number with no gaps.
+func EvaluateCodes(cards []CardCode) int32 {
+	n := len(cards)
+	if n < minEvalSize || n > maxEvalSize {
+		return math.MaxInt32
+	}
+
+	var sum uint64
+	for _, c := range cards {
+		sum += cardKeys[c]
+	}
+
+	if suits := sum >> suitShift; (suits+0x3333)&0x8888 != 0 {
+		return int32(flushScores[flushRanks(cards, suits)])
+	}
+
+	idx := highRankOffsets[n-minEvalSize][sum>>highShift&highMask] + uint32(lowRankIndex[sum&lowMask])
+	return int32(rankCountScores[idx])
+}
+
+// flushRanks returns the rank bits of the suit with five or more cards.
+func flushRanks(cards []CardCode, suits uint64) uint16 {
+	s := CardCode(0)
+	for suits&0xF < 5 {
+		suits >>= 4
+		s++
+	}
+
+	var ranks uint16
+	for _, c := range cards {
+		if c&3 == s {
+			ranks |= 1 << (c >> 2)
+		}
+	}
+	return ranks
+}
+
+const (
+	numRanks    = 13
+	maxPerRank  = 4
+	minEvalSize = 5
+	maxEvalSize = 7
+
+	// rankCounts is how many ways there are to hold five, six and seven
+	// cards across the ranks.
+	rankCounts = 6175 + 18395 + 49205
+
+	// The low ranks are two to eight and the high ranks nine to ace. Their
+	// base-five counts and the suit counts, four bits each, share a key.
+	lowRanks  = 7
+	highRanks = numRanks - lowRanks
+	lowKeys   = 78125 // 5^7
+	highKeys  = 15625 // 5^6
+	lowMask   = 1<<17 - 1
+	highShift = 20
+	highMask  = 1<<14 - 1
+	suitShift = 48
+)
+
+// The tables are sized to every value their index can take, whether used
+// or not, so that EvaluateCodes needs no bounds checks.
+var (
+	cardKeys [1 << 8]uint64
+
+	// lowRankIndex numbers each count of the low ranks among those with
+	// as many cards. highRankOffsets[n-minEvalSize] is where the hands of
+	// n cards with each count of the high ranks start in rankCountScores.
+	lowRankIndex    [lowMask + 1]uint16
+	highRankOffsets [maxEvalSize - minEvalSize + 1][highMask + 1]uint32
+
+	// flushScores holds the best flush of every set of five or more ranks
+	// of one suit.
+	flushScores [1 << numRanks]int16
+
+	// rankCountScores holds the best hand of every five, six and seven
+	// cards of mixed suits.
+	rankCountScores [rankCounts]int16
+)
+
+func init() {
+	buildCardKeys()
+	buildRankCountIndex()
+	buildFlushScores()
+	buildRankCountScores()
+}
+
+func buildCardKeys() {
+	for code := range numRanks * 4 {
+		r, s := code>>2, code&3
+		key := uint64(1) << (suitShift + 4*s)
+		if r < lowRanks {
+			key += pow5(r)
+		} else {
+			key += pow5(r-lowRanks) << highShift
+		}
+		cardKeys[code] = key
+	}
+}
+
+func pow5(n int) uint64 {
+	p := uint64(1)
+	for range n {
+		p *= 5
+	}
+	return p
+}
+
+func buildRankCountIndex() {
+	// Number the low counts within each total, and count how many there
+	// are of each total.
+	var lowTotals [maxEvalSize + 1]uint16
+	for key := range lowKeys {
+		total := digitSum(key)
+		if total <= maxEvalSize {
+			lowRankIndex[key] = lowTotals[total]
+			lowTotals[total]++
+		}
+	}
+
+	offset := 0
+	for n := minEvalSize; n <= maxEvalSize; n++ {
+		for key := range highKeys {
+			total := digitSum(key)
+			if total <= n {
+				highRankOffsets[n-minEvalSize][key] = uint32(offset)
+				offset += int(lowTotals[n-total])
+			}
+		}
+	}
+	if offset != rankCounts {
+		panic("game: rank count table size mismatch")
+	}
+}
+
+// digitSum adds up the base-five digits of key.
+func digitSum(key int) int {
+	sum := 0
+	for ; key > 0; key /= 5 {
+		sum += key % 5
+	}
+	return sum
+}
+
+// rankCountIndex numbers counts of n cards as EvaluateCodes does.
+func rankCountIndex(counts *[numRanks]uint8, n int) int {
+	low, high := 0, 0
+	for r := numRanks - 1; r >= 0; r-- {
+		if r < lowRanks {
+			low = low*5 + int(counts[r])
+		} else {
+			high = high*5 + int(counts[r])
+		}
+	}
+	return int(highRankOffsets[n-minEvalSize][high]) + int(lowRankIndex[low])
+}
+
+// The lookup tables are filled from a list of every distinct five-card
+// hand, best first, so that the scores match the usual 1 to 7462 scale:
+// straight flushes, quads, full houses, flushes, straights, trips, two
+// pair, one pair and high cards, each ordered by its ranks from the most
+// significant down.
+
+func buildFlushScores() {
+	straights := straightMasks()
+	for i, mask := range straights {
+		flushScores[mask] = int16(1 + i)
+	}
+	for i, mask := range plainMasks(straights) {
+		flushScores[mask] = int16(scoreFullHouseMax + 1 + int32(i))
+	}
+
+	// Six and seven card flushes play their best five.
+	for mask := 0; mask < len(flushScores); mask++ {
+		if bits.OnesCount(uint(mask)) <= 5 {
+			continue
+		}
+		best := int16(0)
+		for rest := mask; rest != 0; rest &= rest - 1 {
+			score := flushScores[mask&^(rest&-rest)]
+			if best == 0 || score < best {
+				best = score
+			}
+		}
+		flushScores[mask] = best
+	}
+}
+
+func buildRankCountScores() {
+	score := int16(0)
+	set := func(counts [numRanks]uint8) {
+		score++
+		rankCountScores[rankCountIndex(&counts, 5)] = score
+	}
+	single := func(ranks ...int) [numRanks]uint8 {
+		var counts [numRanks]uint8
+		for _, r := range ranks {
+			counts[r]++
+		}
+		return counts
+	}
+
+	score = int16(scoreFourOfAKindMax - 156)
+	for q := numRanks - 1; q >= 0; q-- {
+		for k := numRanks - 1; k >= 0; k-- {
+			if k != q {
+				set(single(q, q, q, q, k))
+			}
+		}
+	}
+	for t := numRanks - 1; t >= 0; t-- {
+		for p := numRanks - 1; p >= 0; p-- {
+			if p != t {
+				set(single(t, t, t, p, p))
+			}
+		}
+	}
+
+	straights := straightMasks()
+	score = int16(scoreFlushMax)
+	for _, mask := range straights {
+		set(maskCounts(mask))
+	}
+	for t := numRanks - 1; t >= 0; t-- {
+		for a := numRanks - 1; a >= 0; a-- {
+			for b := a - 1; b >= 0; b-- {
+				if a != t && b != t {
+					set(single(t, t, t, a, b))
+				}
+			}
+		}
+	}
+	for hi := numRanks - 1; hi >= 0; hi-- {
+		for lo := hi - 1; lo >= 0; lo-- {
+			for k := numRanks - 1; k >= 0; k-- {
+				if k != hi && k != lo {
+					set(single(hi, hi, lo, lo, k))
+				}
+			}
+		}
+	}
+	for p := numRanks - 1; p >= 0; p-- {
+		for a := numRanks - 1; a >= 0; a-- {
+			for b := a - 1; b >= 0; b-- {
+				for c := b - 1; c >= 0; c-- {
+					if a != p && b != p && c != p {
+						set(single(p, p, a, b, c))
+					}
+				}
+			}
+		}
+	}
+	for _, mask := range plainMasks(straights) {
+		set(maskCounts(mask))
+	}
+
+	// Six and seven cards play their best five.
+	for n := 6; n <= maxEvalSize; n++ {
+		forEachRankCount(n, func(counts *[numRanks]uint8) {
+			best := int16(0)
+			for r := range counts {
+				if counts[r] == 0 {
+					continue
+				}
+				counts[r]--
+				s := rankCountScores[rankCountIndex(counts, n-1)]
+				counts[r]++
+				if best == 0 || s < best {
+					best = s
+				}
+			}
+			rankCountScores[rankCountIndex(counts, n)] = best
+		})
+	}
+}
+
+// straightMasks lists the rank bits of the ten straights, ace high first
+// and the wheel last.
+func straightMasks() []int {
+	masks := make([]int, 0, 10)
+	for top := numRanks - 1; top >= 4; top-- {
+		masks = append(masks, 0b11111<<(top-4))
+	}
+	return append(masks, 1<<(numRanks-1)|0b1111)
+}
+
+// plainMasks lists every set of five ranks that is not a straight, best
+// first.
+func plainMasks(straights []int) []int {
+	isStraight := make(map[int]bool, len(straights))
+	for _, m := range straights {
+		isStraight[m] = true
+	}
+
+	var masks []int
+	for mask := 1<<numRanks - 1; mask > 0; mask-- {
+		if bits.OnesCount(uint(mask)) == 5 && !isStraight[mask] {
+			masks = append(masks, mask)
+		}
+	}
+	return masks
+}
+
+func maskCounts(mask int) [numRanks]uint8 {
+	var counts [numRanks]uint8
+	for r := range counts {
+		if mask&(1<<r) != 0 {
+			counts[r] = 1
+		}
+	}
+	return counts
+}
+
+// forEachRankCount calls fn with every way to hold n cards across the
+// ranks, at most four of each. The array is reused between calls.
+func forEachRankCount(n int, fn func(counts *[numRanks]uint8)) {
+	var counts [numRanks]uint8
+	var place func(r, left int)
+	place = func(r, left int) {
+		if r == numRanks {
+			if left == 0 {
+				fn(&counts)
+			}
+			return
+		}
+		for c := 0; c <= maxPerRank && c <= left; c++ {
+			counts[r] = uint8(c)
+			place(r+1, left-c)
+		}
+		counts[r] = 0
+	}
+	place(0, n)
+}
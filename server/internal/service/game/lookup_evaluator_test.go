@@ -0,0 +1,124 @@
+package game
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/chehsunliu/poker"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// libraryDeck pairs each card's code with the reference library's card.
+func libraryDeck() ([]CardCode, []poker.Card) {
+	deck := domain.FullDeck()
+	codes := make([]CardCode, len(deck))
+	lib := make([]poker.Card, len(deck))
+	for i, c := range deck {
+		codes[i] = EncodeCard(c)
+		lib[i] = poker.NewCard(c.String())
+	}
+	return codes, lib
+}
+
+func TestEvaluateCodesMatchesLibraryFiveCards(t *testing.T) {
+	codes, lib := libraryDeck()
+	n := len(codes)
+
+	hand := make([]CardCode, 5)
+	ref := make([]poker.Card, 5)
+	checked := 0
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = codes[a], codes[b], codes[c], codes[d], codes[e]
+						ref[0], ref[1], ref[2], ref[3], ref[4] = lib[a], lib[b], lib[c], lib[d], lib[e]
+						if got, want := EvaluateCodes(hand), poker.Evaluate(ref); got != want {
+							t.Fatalf("EvaluateCodes(%v) = %d, want %d", ref, got, want)
+						}
+						checked++
+					}
+				}
+			}
+		}
+	}
+
+	if checked != 2598960 {
+		t.Fatalf("checked %d hands, want 2598960", checked)
+	}
+}
+
+func TestEvaluateCodesMatchesLibrarySampled(t *testing.T) {
+	codes, lib := libraryDeck()
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	samples := 1_000_000
+	if testing.Short() {
+		samples = 50_000
+	}
+
+	for _, size := range []int{6, 7} {
+		hand := make([]CardCode, size)
+		ref := make([]poker.Card, size)
+		for range samples {
+			perm := rng.Perm(len(codes))
+			for i := range size {
+				hand[i], ref[i] = codes[perm[i]], lib[perm[i]]
+			}
+			if got, want := EvaluateCodes(hand), poker.Evaluate(ref); got != want {
+				t.Fatalf("EvaluateCodes(%v) = %d, want %d", ref, got, want)
+			}
+		}
+	}
+}
+
+func TestEvaluateCodesRejectsOtherSizes(t *testing.T) {
+	codes, _ := libraryDeck()
+	for _, size := range []int{0, 1, 4, 8, 9} {
+		if got := EvaluateCodes(codes[:size]); got != math.MaxInt32 {
+			t.Errorf("EvaluateCodes with %d cards = %d, want %d", size, got, int32(math.MaxInt32))
+		}
+	}
+}
+
+func TestEncodeCard(t *testing.T) {
+	seen := make(map[CardCode]bool)
+	for _, c := range domain.FullDeck() {
+		code := EncodeCard(c)
+		if want := CardCode((c.Rank.Value()-2)*4 + suitCode(c.Suit)); code != want {
+			t.Errorf("EncodeCard(%s) = %d, want %d", c, code, want)
+		}
+		if seen[code] {
+			t.Errorf("EncodeCard(%s) = %d, already used", c, code)
+		}
+		seen[code] = true
+	}
+}
+
+// BenchmarkEvaluateCodes scores random seven-card hands. The evaluator is
+// meant to manage at least 50 million a second, under 20 ns each.
+func BenchmarkEvaluateCodes(b *testing.B) {
+	codes, _ := libraryDeck()
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	hands := make([][7]CardCode, 4096)
+	for i := range hands {
+		perm := rng.Perm(len(codes))
+		for j := range hands[i] {
+			hands[i][j] = codes[perm[j]]
+		}
+	}
+
+	b.ReportAllocs()
+	var sink int32
+	i := 0
+	for b.Loop() {
+		sink += EvaluateCodes(hands[i&(len(hands)-1)][:])
+		i++
+	}
+	if sink == 0 {
+		b.Fatal("no hands scored")
+	}
+}
@@ -7,5 +7,6 @@ import (
 
 type NoopBroadcaster struct{}
 
-func (n *NoopBroadcaster) BroadcastToTable(_ uuid.UUID, _ domain.WSMessage) {}
-func (n *NoopBroadcaster) SendToPlayer(_, _ uuid.UUID, _ domain.WSMessage)  {}
+func (n *NoopBroadcaster) BroadcastToTable(_ uuid.UUID, _ domain.WSMessage)          {}
+func (n *NoopBroadcaster) BroadcastToTableExcept(_, _ uuid.UUID, _ domain.WSMessage) {}
+func (n *NoopBroadcaster) SendToPlayer(_, _ uuid.UUID, _ domain.WSMessage)           {}
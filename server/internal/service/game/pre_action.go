@@ -0,0 +1,190 @@
+package game
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// resolvePreAction turns a queued pre-action into the action to play now,
+// or reports false if it no longer applies.
+func resolvePreAction(pre domain.PreAction, legal domain.LegalActions) (domain.ActionType, bool) {
+	canCheck := legal.Allows(domain.ActionCheck)
+
+	switch pre.Type {
+	case domain.PreActionCheckFold:
+		if canCheck {
+			return domain.ActionCheck, true
+		}
+		return domain.ActionFold, true
+	case domain.PreActionCheck:
+		if canCheck {
+			return domain.ActionCheck, true
+		}
+	case domain.PreActionCallExact:
+		if legal.Allows(domain.ActionCall) && legal.CallAmount.Equal(pre.Amount) {
+			return domain.ActionCall, true
+		}
+	case domain.PreActionCallAny:
+		if canCheck {
+			return domain.ActionCheck, true
+		}
+		if legal.Allows(domain.ActionCall) {
+			return domain.ActionCall, true
+		}
+	case domain.PreActionFold:
+		return domain.ActionFold, true
+	}
+
+	return "", false
+}
+
+// startTurn hands the action to the player at CurrentIdx, playing their
+// pre-action straight away if they queued one.
+func (h *TableHub) startTurn(ctx context.Context) {
+	if h.runPreAction(ctx) {
+		return
+	}
+
+	h.resetTimer()
+	h.broadcastTurnChanged()
+}
+
+func (h *TableHub) handlePreAction(ctx context.Context, userID uuid.UUID, action domain.PreActionType) error {
+	if h.state.Hand == nil {
+		return domain.ErrGameNotStarted
+	}
+
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return domain.ErrPlayerNotFound
+	}
+
+	if action == "" {
+		delete(h.state.Hand.PreActions, player.ID)
+		h.sendPreAction(userID, nil)
+		return nil
+	}
+	if !action.IsValid() {
+		return domain.ErrInvalidPreAction
+	}
+
+	betting := h.state.Hand.Betting
+	idx := -1
+	for i, bp := range betting.Players {
+		if bp.PlayerID == player.ID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return domain.ErrPlayerNotFound
+	}
+
+	bp := betting.Players[idx]
+	if bp.IsFolded || bp.IsAllIn {
+		return domain.ErrInvalidAction
+	}
+
+	pre := domain.PreAction{Type: action}
+	toCall := decimal.Min(betting.CurrentBet.Sub(bp.BetThisRound), bp.Stack)
+	switch action {
+	case domain.PreActionCheck:
+		if toCall.IsPositive() {
+			return domain.ErrInvalidPreAction
+		}
+	case domain.PreActionCallExact:
+		if !toCall.IsPositive() {
+			return domain.ErrInvalidPreAction
+		}
+		pre.Amount = toCall
+	}
+
+	h.state.Hand.PreActions[player.ID] = pre
+	h.sendPreAction(userID, &pre)
+
+	if betting.CurrentIdx == idx {
+		h.runPreAction(ctx)
+	}
+
+	return nil
+}
+
+// runPreAction plays the queued action of the player to act, if any, and
+// reports whether it did.
+func (h *TableHub) runPreAction(ctx context.Context) bool {
+	hand := h.state.Hand
+	if hand == nil || hand.Betting.CurrentIdx >= len(hand.Betting.Players) {
+		return false
+	}
+
+	playerID := hand.Betting.Players[hand.Betting.CurrentIdx].PlayerID
+	pre, ok := hand.PreActions[playerID]
+	if !ok {
+		return false
+	}
+	delete(hand.PreActions, playerID)
+
+	player := h.state.FindPlayerByID(playerID)
+	if player == nil {
+		return false
+	}
+	h.sendPreAction(player.UserID, nil)
+
+	action, ok := resolvePreAction(pre, ComputeLegalActions(hand.Betting))
+	if !ok {
+		return false
+	}
+
+	if err := h.handlePlayerAction(ctx, player.UserID, action, decimal.Zero); err != nil {
+		h.logger.Error("queued pre-action failed", "action", action, "error", err)
+		return false
+	}
+	return true
+}
+
+// dropStalePreActions clears the pre-actions a bet has made unsafe: a
+// check that would now face a bet, or a call for a different amount.
+func (h *TableHub) dropStalePreActions() {
+	betting := h.state.Hand.Betting
+	for _, bp := range betting.Players {
+		pre, ok := h.state.Hand.PreActions[bp.PlayerID]
+		if !ok {
+			continue
+		}
+
+		toCall := decimal.Min(betting.CurrentBet.Sub(bp.BetThisRound), bp.Stack)
+		stale := bp.IsFolded || bp.IsAllIn
+		switch pre.Type {
+		case domain.PreActionCheck:
+			stale = stale || toCall.IsPositive()
+		case domain.PreActionCallExact:
+			stale = stale || !toCall.Equal(pre.Amount)
+		}
+
+		if stale {
+			h.dropPreAction(bp.PlayerID)
+		}
+	}
+}
+
+// clearPreActions drops every pre-action when a new betting round starts.
+func (h *TableHub) clearPreActions() {
+	for playerID := range h.state.Hand.PreActions {
+		h.dropPreAction(playerID)
+	}
+}
+
+func (h *TableHub) dropPreAction(playerID uuid.UUID) {
+	delete(h.state.Hand.PreActions, playerID)
+	if p := h.state.FindPlayerByID(playerID); p != nil {
+		h.sendPreAction(p.UserID, nil)
+	}
+}
+
+func (h *TableHub) sendPreAction(userID uuid.UUID, pre *domain.PreAction) {
+	msg := h.buildMessage(domain.WSMsgPreActionSet, domain.WSPreActionUpdated{PreAction: pre})
+	h.broadcaster.SendToPlayer(h.state.Table.ID, userID, msg)
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"context"
+
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// takeRake deducts the house's rake from the pots before they are paid
+// out, main pot first, and records it on the hand. Hands that end before
+// the flop at no-flop-no-drop tables are not raked.
+func (h *TableHub) takeRake(pots []domain.Pot) []domain.Pot {
+	table := h.state.Table
+	if table.NoFlopNoDrop && !h.sawFlop() {
+		return pots
+	}
+
+	total := decimal.Zero
+	for _, pot := range pots {
+		total = total.Add(pot.Amount)
+	}
+
+	rake := table.RakeFor(total, len(h.state.Hand.Betting.Players))
+	if !rake.IsPositive() {
+		return pots
+	}
+
+	raked := make([]domain.Pot, len(pots))
+	left := rake
+	for i, pot := range pots {
+		take := decimal.Min(left, pot.Amount)
+		pot.Amount = pot.Amount.Sub(take)
+		left = left.Sub(take)
+		raked[i] = pot
+	}
+
+	h.state.Hand.Hand.Rake = rake
+	return raked
+}
+
+// sawFlop reports whether the hand got past the first betting round: the
+// flop, or fourth street in stud.
+func (h *TableHub) sawFlop() bool {
+	switch h.state.Hand.FSM.Stage() {
+	case domain.StagePreflop, domain.StageThirdStreet:
+		return false
+	default:
+		return true
+	}
+}
+
+// creditRake credits the rake taken from the hand to the house.
+func (h *TableHub) creditRake(ctx context.Context) {
+	hand := h.state.Hand.Hand
+	if !hand.Rake.IsPositive() {
+		return
+	}
+
+	_, err := h.rakeRepo.Credit(ctx, domain.RakeEntry{
+		HandID:  hand.ID,
+		TableID: hand.TableID,
+		Amount:  hand.Rake,
+	})
+	if err != nil {
+		h.logger.Error("CRITICAL: failed to credit rake",
+			"hand_id", hand.ID, "amount", hand.Rake, "error", err)
+	}
+}
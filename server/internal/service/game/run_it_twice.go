@@ -0,0 +1,110 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+)
+
+// runItTwiceTimeout is how long the players in an all-in pot have to agree
+// to run the board twice. Silence counts as a refusal.
+const runItTwiceTimeout = 10 * time.Second
+
+// RunItTwice is a run it twice offer. Answers are keyed by player ID.
+type RunItTwice struct {
+	Answers map[uuid.UUID]bool
+	Decided bool
+	Agreed  bool
+}
+
+// offerRunItTwice asks the players whether to run the board twice once
+// betting is over with everyone all-in before the river. It reports false
+// if there is nothing to ask, in which case the hand carries on. The offer
+// is made at most once a hand.
+func (h *holdemEngine) offerRunItTwice() bool {
+	hand := h.state.Hand
+	if hand.RunItTwice != nil || len(hand.CommunityCards) >= boardSize || !h.allInRunout() {
+		return false
+	}
+
+	hand.RunItTwice = &RunItTwice{Answers: make(map[uuid.UUID]bool)}
+
+	offer := domain.WSRunItTwiceOffer{Timeout: runItTwiceTimeout.Seconds()}
+	for _, id := range h.activePlayerIDs() {
+		if p := h.state.FindPlayerByID(id); p != nil {
+			offer.UserIDs = append(offer.UserIDs, p.UserID)
+		}
+	}
+
+	h.stopTimer()
+	h.turnTimer = time.NewTimer(runItTwiceTimeout)
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, h.buildMessage(domain.WSMsgRunItTwiceAsk, offer))
+	return true
+}
+
+func (h *TableHub) runItTwicePending() bool {
+	return h.state.Hand != nil && h.state.Hand.RunItTwice != nil && !h.state.Hand.RunItTwice.Decided
+}
+
+func (h *TableHub) handleRunItTwice(ctx context.Context, userID uuid.UUID, agree bool) error {
+	if !h.runItTwicePending() {
+		return domain.ErrNoRunItTwiceOffer
+	}
+
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return domain.ErrPlayerNotFound
+	}
+
+	inHand := false
+	for _, id := range h.activePlayerIDs() {
+		if id == player.ID {
+			inHand = true
+			break
+		}
+	}
+	if !inHand {
+		return domain.ErrNoRunItTwiceOffer
+	}
+
+	h.state.Hand.RunItTwice.Answers[player.ID] = agree
+	h.countRunItTwiceAnswers(ctx)
+	return nil
+}
+
+// countRunItTwiceAnswers settles the offer as soon as anyone still in the
+// hand refuses or all of them have agreed.
+func (h *TableHub) countRunItTwiceAnswers(ctx context.Context) {
+	answers := h.state.Hand.RunItTwice.Answers
+	all := true
+	for _, id := range h.activePlayerIDs() {
+		agreed, ok := answers[id]
+		if ok && !agreed {
+			h.decideRunItTwice(ctx, false)
+			return
+		}
+		all = all && ok
+	}
+	if all {
+		h.decideRunItTwice(ctx, true)
+	}
+}
+
+// decideRunItTwice closes the offer and deals the rest of the hand.
+func (h *TableHub) decideRunItTwice(ctx context.Context, agreed bool) {
+	h.stopTimer()
+	h.state.Hand.RunItTwice.Decided = true
+	h.state.Hand.RunItTwice.Agreed = agreed
+
+	msg := h.buildMessage(domain.WSMsgRunItTwiceSet, domain.WSRunItTwiceDecided{Agreed: agreed})
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+
+	h.engine.advanceStage(ctx)
+}
+
+// runsTwice reports whether the players agreed to run the board twice.
+func (h *TableHub) runsTwice() bool {
+	return h.state.Hand.RunItTwice != nil && h.state.Hand.RunItTwice.Agreed
+}
@@ -47,14 +47,9 @@ func NewService(
 }
 
 func (s *Service) CreateTable(ctx context.Context, table domain.PokerTable) (domain.PokerTable, error) {
-	if table.SmallBlind.LessThanOrEqual(decimal.Zero) || table.BigBlind.LessThanOrEqual(decimal.Zero) {
-		return domain.PokerTable{}, domain.ErrInvalidBetAmount
-	}
-	if table.MinBuyIn.LessThanOrEqual(decimal.Zero) || table.MaxBuyIn.LessThan(table.MinBuyIn) {
-		return domain.PokerTable{}, domain.ErrInvalidBuyIn
-	}
-	if table.MaxPlayers < 2 || table.MaxPlayers > 9 {
-		return domain.PokerTable{}, fmt.Errorf("max players must be between 2 and 9")
+	table, err := PrepareTable(table)
+	if err != nil {
+		return domain.PokerTable{}, err
 	}
 
 	table.Status = domain.TableStatusWaiting
@@ -67,6 +62,78 @@ func (s *Service) CreateTable(ctx context.Context, table domain.PokerTable) (dom
 	return created, nil
 }
 
+// PrepareTable fills in the defaults for a table's game settings and
+// checks that they make sense together.
+func PrepareTable(table domain.PokerTable) (domain.PokerTable, error) {
+	if table.SmallBlind.LessThanOrEqual(decimal.Zero) || table.BigBlind.LessThanOrEqual(decimal.Zero) {
+		return table, domain.ErrInvalidBetAmount
+	}
+	if table.MinBuyIn.LessThanOrEqual(decimal.Zero) || table.MaxBuyIn.LessThan(table.MinBuyIn) {
+		return table, domain.ErrInvalidBuyIn
+	}
+	if table.MaxPlayers < 2 || table.MaxPlayers > 9 {
+		return table, domain.ErrInvalidMaxPlayers
+	}
+	if table.GameType == "" {
+		table.GameType = domain.GameHoldem
+	}
+	if !table.GameType.IsValid() {
+		return table, domain.ErrInvalidGameType
+	}
+	if table.Limit == "" {
+		table.Limit = table.GameType.DefaultLimit()
+	}
+	if !table.Limit.IsValid() {
+		return table, domain.NewValidationError("limit", "limit must be no_limit, pot_limit or fixed_limit")
+	}
+	if table.AnteType == "" {
+		table.AnteType = domain.AnteNone
+	}
+	if table.Straddle == "" {
+		table.Straddle = domain.StraddleNone
+	}
+	if table.BlindType == "" {
+		table.BlindType = domain.BlindsStandard
+	}
+	switch {
+	case !table.AnteType.IsValid():
+		return table, domain.NewValidationError("ante_type", "ante_type must be none, per_player or big_blind")
+	case !table.Straddle.IsValid():
+		return table, domain.NewValidationError("straddle", "straddle must be none, utg or button")
+	case !table.BlindType.IsValid():
+		return table, domain.NewValidationError("blind_type", "blind_type must be blinds, ante_only or button_blind")
+	case table.Ante.IsNegative():
+		return table, domain.NewValidationError("ante", "ante cannot be negative")
+	case table.BlindType != domain.BlindsStandard && table.Straddle != domain.StraddleNone:
+		return table, domain.NewValidationError("straddle", "straddles need standard blinds")
+	case table.BlindType == domain.BlindsAnteOnly && table.AnteType != domain.AntePerPlayer:
+		return table, domain.NewValidationError("ante_type", "ante_only tables need a per_player ante")
+	}
+	if table.GameType == domain.GameStud && !isStudTable(table) {
+		return table, domain.ErrInvalidGameType
+	}
+	if table.ChipDenomination.IsNegative() {
+		return table, domain.NewValidationError("chip_denomination", "chip_denomination cannot be negative")
+	}
+	table.ChipDenomination = table.ChipUnit()
+	if !table.IsWholeChips(table.SmallBlind) || !table.IsWholeChips(table.BigBlind) || !table.IsWholeChips(table.Ante) {
+		return table, domain.ErrInvalidBetAmount
+	}
+	if !table.IsWholeChips(table.MinBuyIn) || !table.IsWholeChips(table.MaxBuyIn) {
+		return table, domain.ErrInvalidBuyIn
+	}
+	if table.RakePercent.IsNegative() || table.RakePercent.GreaterThan(decimal.NewFromInt(100)) {
+		return table, domain.ErrInvalidRake
+	}
+	for _, c := range table.RakeCaps {
+		if c.MinPlayers < 2 || c.Amount.IsNegative() {
+			return table, domain.ErrInvalidRake
+		}
+	}
+
+	return table, nil
+}
+
 func (s *Service) GetTable(ctx context.Context, tableID uuid.UUID) (domain.PokerTable, error) {
 	table, err := s.tableRepo.FindByID(ctx, tableID)
 	if err != nil {
@@ -94,6 +161,10 @@ func (s *Service) JoinTable(ctx context.Context, tableID, userID uuid.UUID, seat
 		return domain.PokerPlayer{}, fmt.Errorf("PokerService.JoinTable: %w", err)
 	}
 
+	if table.TournamentID != nil {
+		return domain.PokerPlayer{}, domain.ErrTournamentTable
+	}
+
 	if buyInAmount.LessThan(table.MinBuyIn) || buyInAmount.GreaterThan(table.MaxBuyIn) {
 		return domain.PokerPlayer{}, domain.ErrInvalidBuyIn
 	}
@@ -201,6 +272,71 @@ func (s *Service) LeaveTable(ctx context.Context, tableID, userID uuid.UUID) err
 	return nil
 }
 
+func (s *Service) SitOut(ctx context.Context, tableID, userID uuid.UUID) error {
+	if err := s.sendToHub(tableID, HubEvent{Type: EventSitOut, UserID: userID}); err != nil {
+		return fmt.Errorf("PokerService.SitOut: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) SitIn(ctx context.Context, tableID, userID uuid.UUID, waitForBigBlind bool) error {
+	err := s.sendToHub(tableID, HubEvent{
+		Type:            EventSitIn,
+		UserID:          userID,
+		WaitForBigBlind: waitForBigBlind,
+	})
+	if err != nil {
+		return fmt.Errorf("PokerService.SitIn: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) SetPreAction(ctx context.Context, tableID, userID uuid.UUID, action domain.PreActionType) error {
+	err := s.sendToHub(tableID, HubEvent{Type: EventPreAction, UserID: userID, PreAction: action})
+	if err != nil {
+		return fmt.Errorf("PokerService.SetPreAction: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RunItTwice(ctx context.Context, tableID, userID uuid.UUID, agree bool) error {
+	err := s.sendToHub(tableID, HubEvent{Type: EventRunItTwice, UserID: userID, Agree: agree})
+	if err != nil {
+		return fmt.Errorf("PokerService.RunItTwice: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) ShowCards(ctx context.Context, tableID, userID uuid.UUID) error {
+	if err := s.sendToHub(tableID, HubEvent{Type: EventShowCards, UserID: userID}); err != nil {
+		return fmt.Errorf("PokerService.ShowCards: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) MuckCards(ctx context.Context, tableID, userID uuid.UUID) error {
+	if err := s.sendToHub(tableID, HubEvent{Type: EventMuckCards, UserID: userID}); err != nil {
+		return fmt.Errorf("PokerService.MuckCards: %w", err)
+	}
+	return nil
+}
+
+// sendToHub delivers an event to the table's hub and waits for its result.
+// A table without a running hub has no seated players.
+func (s *Service) sendToHub(tableID uuid.UUID, event HubEvent) error {
+	hub := s.hubManager.GetHub(tableID)
+	if hub == nil {
+		return domain.ErrPlayerNotFound
+	}
+
+	resultCh := make(chan HubResult, 1)
+	event.ResultCh = resultCh
+	if err := hub.Send(event); err != nil {
+		return err
+	}
+	return (<-resultCh).Err
+}
+
 func (s *Service) GetTableState(ctx context.Context, tableID uuid.UUID) (domain.WSTableState, error) {
 	hub := s.hubManager.GetHub(tableID)
 	if hub != nil {
@@ -229,11 +365,17 @@ func (s *Service) GetTableState(ctx context.Context, tableID uuid.UUID) (domain.
 	}
 
 	return domain.WSTableState{
-		TableID:    table.ID,
-		Name:       table.Name,
-		SmallBlind: table.SmallBlind,
-		BigBlind:   table.BigBlind,
-		Stage:      domain.StageWaiting,
-		Players:    wsPlayers,
+		TableID:      table.ID,
+		Name:         table.Name,
+		GameType:     table.GameType,
+		BettingLimit: table.Limit,
+		SmallBlind:   table.SmallBlind,
+		BigBlind:     table.BigBlind,
+		Ante:         table.Ante,
+		AnteType:     table.AnteType,
+		Straddle:     table.Straddle,
+		BlindType:    table.BlindType,
+		Stage:        domain.StageWaiting,
+		Players:      wsPlayers,
 	}, nil
 }
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// showOrMuckTimeout is how long the losers of a showdown have to show
+// their hands before they are mucked.
+const showOrMuckTimeout = 5 * time.Second
+
+// allInRunout reports whether the betting is over for the rest of the
+// hand: two or more players are left and at most one of them has chips
+// behind.
+func (h *TableHub) allInRunout() bool {
+	allIn, canAct := 0, 0
+	for _, bp := range h.state.Hand.Betting.Players {
+		switch {
+		case bp.IsFolded:
+		case bp.IsAllIn:
+			allIn++
+		default:
+			canAct++
+		}
+	}
+	return allIn > 0 && allIn+canAct >= 2 && canAct <= 1
+}
+
+// exposeAllInHands turns every hand face up once nobody can bet any more,
+// before the rest of the cards are dealt, along with their equities.
+func (h *TableHub) exposeAllInHands() {
+	hand := h.state.Hand
+	if hand.Exposed || !h.allInRunout() {
+		return
+	}
+	hand.Exposed = true
+
+	cards := make(map[uuid.UUID][]domain.Card)
+	for _, id := range h.activePlayerIDs() {
+		if p := h.state.FindPlayerByID(id); p != nil {
+			cards[p.UserID] = hand.PlayerHands[id]
+		}
+	}
+	h.broadcastCardsShown(hand.Hand.ID, cards)
+	h.broadcastEquity()
+}
+
+// showdownOrder lists the players still in the hand in the order they show:
+// the last to bet or raise on the final street first, or the first player
+// left of the button if nobody did, then round the table.
+func (h *TableHub) showdownOrder() []uuid.UUID {
+	inHand := make(map[uuid.UUID]bool)
+	for _, id := range h.activePlayerIDs() {
+		inHand[id] = true
+	}
+
+	seatOrder := h.potSplit().SeatOrder
+	start := 0
+	for i, id := range seatOrder {
+		if id == h.state.Hand.LastAggressor {
+			start = i
+			break
+		}
+	}
+
+	order := make([]uuid.UUID, 0, len(inHand))
+	for i := range seatOrder {
+		if id := seatOrder[(start+i)%len(seatOrder)]; inHand[id] {
+			order = append(order, id)
+		}
+	}
+	return order
+}
+
+// showOrMuck holds the hands that lost at showdown without being shown,
+// keyed by user ID, until their players show or muck them. The end of the
+// hand waits for it: busted and startStacks are what it reports then.
+type showOrMuck struct {
+	handID      uuid.UUID
+	cards       map[uuid.UUID][]domain.Card
+	busted      []domain.PokerPlayer
+	startStacks map[uuid.UUID]decimal.Decimal
+}
+
+// offerShowOrMuck leaves the hands at showdown that were not shown to
+// their players to show or muck.
+func (h *TableHub) offerShowOrMuck(handPlayers []HandPlayerCards, shown map[uuid.UUID][]domain.Card) {
+	cards := make(map[uuid.UUID][]domain.Card)
+	for _, hp := range handPlayers {
+		if _, ok := shown[hp.PlayerID]; ok {
+			continue
+		}
+		if p := h.state.FindPlayerByID(hp.PlayerID); p != nil {
+			cards[p.UserID] = hp.HoleCards
+		}
+	}
+	if len(cards) > 0 {
+		h.showOrMuck = &showOrMuck{handID: h.state.Hand.Hand.ID, cards: cards}
+	}
+}
+
+// askShowOrMuck starts the clock on the show or muck decision.
+func (h *TableHub) askShowOrMuck() {
+	ask := domain.WSShowOrMuck{HandID: h.showOrMuck.handID, Timeout: showOrMuckTimeout.Seconds()}
+	for userID := range h.showOrMuck.cards {
+		ask.UserIDs = append(ask.UserIDs, userID)
+	}
+
+	h.stopTimer()
+	h.turnTimer = time.NewTimer(showOrMuckTimeout)
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, h.buildMessage(domain.WSMsgShowOrMuck, ask))
+}
+
+// closeShowOrMuck mucks the hands still undecided and ends the hand.
+func (h *TableHub) closeShowOrMuck() {
+	h.stopTimer()
+	decision := h.showOrMuck
+	h.showOrMuck = nil
+	h.handOver(decision.busted, decision.startStacks)
+}
+
+// decideShowOrMuck settles one player's hand, ending the hand once every
+// player has decided.
+func (h *TableHub) decideShowOrMuck(userID uuid.UUID, show bool) {
+	cards := h.showOrMuck.cards[userID]
+	delete(h.showOrMuck.cards, userID)
+	if show {
+		h.broadcastCardsShown(h.showOrMuck.handID, map[uuid.UUID][]domain.Card{userID: cards})
+	}
+	if len(h.showOrMuck.cards) == 0 {
+		h.closeShowOrMuck()
+	}
+}
+
+// handleShowCards shows a player's hole cards from the hand just played:
+// a hand that lost at showdown while its player may still show it, or the
+// hand of the player everyone folded to, until the next hand is dealt.
+func (h *TableHub) handleShowCards(userID uuid.UUID) error {
+	if h.showOrMuck != nil {
+		if _, ok := h.showOrMuck.cards[userID]; ok {
+			h.decideShowOrMuck(userID, true)
+			return nil
+		}
+	}
+
+	if h.lastHand == nil || h.lastHand.FoldWinner == uuid.Nil {
+		return domain.ErrNoCardsToShow
+	}
+
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil || player.ID != h.lastHand.FoldWinner {
+		return domain.ErrNoCardsToShow
+	}
+
+	h.lastHand.FoldWinner = uuid.Nil
+	h.broadcastCardsShown(h.lastHand.Hand.ID, map[uuid.UUID][]domain.Card{userID: h.lastHand.PlayerHands[player.ID]})
+	return nil
+}
+
+// handleMuckCards mucks a hand that lost at showdown without waiting for
+// the time to run out.
+func (h *TableHub) handleMuckCards(userID uuid.UUID) error {
+	if h.showOrMuck == nil {
+		return domain.ErrNoCardsToMuck
+	}
+	if _, ok := h.showOrMuck.cards[userID]; !ok {
+		return domain.ErrNoCardsToMuck
+	}
+
+	h.decideShowOrMuck(userID, false)
+	return nil
+}
+
+func (h *TableHub) broadcastCardsShown(handID uuid.UUID, cards map[uuid.UUID][]domain.Card) {
+	msg := h.buildMessage(domain.WSMsgCardsShown, domain.WSCardsShown{HandID: handID, Cards: cards})
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+}
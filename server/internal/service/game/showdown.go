@@ -1,6 +1,11 @@
 package game
 
 import (
+	"maps"
+	"math"
+	"slices"
+	"sort"
+
 	"github.com/google/uuid"
 	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/shopspring/decimal"
@@ -11,31 +16,61 @@ type HandPlayerCards struct {
 	HoleCards []domain.Card
 }
 
-func DetermineWinners(handPlayers []HandPlayerCards, communityCards []domain.Card, pots []domain.Pot) domain.HandResult {
+// PotSplit says how a pot is divided between tied winners: in whole chips
+// of Denomination, any odd chips going one each to the winners first to
+// the left of the button. SeatOrder lists the players in that order.
+type PotSplit struct {
+	Denomination decimal.Decimal
+	SeatOrder    []uuid.UUID
+}
+
+// DetermineWinners awards each pot to the best hand among its eligible
+// players. In hi-lo variants each pot is split between the best high and
+// the best qualifying low, the odd chip going high, and the high hand
+// scoops when no low qualifies.
+func DetermineWinners(handPlayers []HandPlayerCards, communityCards []domain.Card, pots []domain.Pot, variant Variant, split PotSplit) domain.HandResult {
 	handMap := make(map[uuid.UUID]HandRank, len(handPlayers))
+	lowMap := make(map[uuid.UUID]HandRank)
 	cardMap := make(map[uuid.UUID][]domain.Card, len(handPlayers))
 
 	for _, hp := range handPlayers {
-		rank := BestHand(hp.HoleCards, communityCards)
-		handMap[hp.PlayerID] = rank
+		handMap[hp.PlayerID] = variant.BestHand(hp.HoleCards, communityCards)
 		cardMap[hp.PlayerID] = hp.HoleCards
+		if variant.BestLow != nil {
+			if low, ok := variant.BestLow(hp.HoleCards, communityCards); ok {
+				lowMap[hp.PlayerID] = low
+			}
+		}
 	}
 
 	var allWinners []domain.WinnerInfo
-	showdownCards := make(map[uuid.UUID][]domain.Card)
 
 	for _, pot := range pots {
-		winners := resolvePot(pot, handMap)
-		share := pot.Amount.Div(decimal.NewFromInt(int64(len(winners))))
+		highWinners := resolvePot(pot, handMap)
+		if len(highWinners) == 0 {
+			// None of the pot's players has a hand here; rather than let
+			// the chips vanish, the best hand at showdown takes them.
+			highWinners = resolvePot(domain.Pot{EligibleIDs: slices.Collect(maps.Keys(handMap))}, handMap)
+		}
+		if variant.BestLow == nil {
+			allWinners = append(allWinners, split.award(highWinners, pot.Amount, handMap, "")...)
+			continue
+		}
 
-		for _, winnerID := range winners {
-			allWinners = append(allWinners, domain.WinnerInfo{
-				PlayerID: winnerID,
-				Amount:   share,
-				HandRank: handMap[winnerID].Name,
-			})
-			showdownCards[winnerID] = cardMap[winnerID]
+		lowWinners := resolvePot(pot, lowMap)
+		if len(lowWinners) == 0 {
+			allWinners = append(allWinners, split.award(highWinners, pot.Amount, handMap, domain.PotHalfScoop)...)
+			continue
 		}
+
+		lowHalf := split.chips(pot.Amount).Div(decimal.NewFromInt(2)).Floor().Mul(split.unit())
+		allWinners = append(allWinners, split.award(highWinners, pot.Amount.Sub(lowHalf), handMap, domain.PotHalfHigh)...)
+		allWinners = append(allWinners, split.award(lowWinners, lowHalf, lowMap, domain.PotHalfLow)...)
+	}
+
+	showdownCards := make(map[uuid.UUID][]domain.Card)
+	for _, w := range allWinners {
+		showdownCards[w.PlayerID] = cardMap[w.PlayerID]
 	}
 
 	allWinners = consolidateWinners(allWinners)
@@ -47,14 +82,182 @@ func DetermineWinners(handPlayers []HandPlayerCards, communityCards []domain.Car
 	}
 }
 
+// ShowdownHands decides which hands are shown at showdown. handPlayers
+// are taken in showdown order: each shows if their hand ties or beats, for
+// some pot they are in, the best hand shown for it so far. Any other hand
+// is left for its player to show or muck. With showAll every hand is
+// shown, as when the players were all-in.
+func ShowdownHands(handPlayers []HandPlayerCards, communityCards []domain.Card, pots []domain.Pot, variant Variant, showAll bool) []domain.ShownHand {
+	bestHigh := make([]int32, len(pots))
+	bestLow := make([]int32, len(pots))
+	for i := range pots {
+		bestHigh[i] = math.MaxInt32
+		bestLow[i] = math.MaxInt32
+	}
+
+	var shown []domain.ShownHand
+	for _, hp := range handPlayers {
+		high := variant.BestHand(hp.HoleCards, communityCards)
+		var low HandRank
+		hasLow := false
+		if variant.BestLow != nil {
+			low, hasLow = variant.BestLow(hp.HoleCards, communityCards)
+		}
+
+		show := showAll
+		for i, pot := range pots {
+			if !slices.Contains(pot.EligibleIDs, hp.PlayerID) {
+				continue
+			}
+			if high.Score <= bestHigh[i] || (hasLow && low.Score <= bestLow[i]) {
+				show = true
+			}
+		}
+		if !show {
+			continue
+		}
+
+		for i, pot := range pots {
+			if !slices.Contains(pot.EligibleIDs, hp.PlayerID) {
+				continue
+			}
+			bestHigh[i] = min(bestHigh[i], high.Score)
+			if hasLow {
+				bestLow[i] = min(bestLow[i], low.Score)
+			}
+		}
+
+		hand := domain.ShownHand{
+			PlayerID:  hp.PlayerID,
+			HoleCards: hp.HoleCards,
+			BestFive:  high.Cards,
+			HandRank:  high.Name,
+
+			Category:    high.Category,
+			Description: high.Description,
+		}
+		if hasLow {
+			hand.LowFive = low.Cards
+			hand.LowRank = low.Name
+		}
+		shown = append(shown, hand)
+	}
+
+	return shown
+}
+
+// DetermineWinnersTwice splits each pot between two runouts, the first
+// taking the odd chip, and awards each half on its own board.
+func DetermineWinnersTwice(handPlayers []HandPlayerCards, first, second []domain.Card, pots []domain.Pot, variant Variant, split PotSplit) domain.HandResult {
+	firstPots := make([]domain.Pot, len(pots))
+	secondPots := make([]domain.Pot, len(pots))
+	for i, pot := range pots {
+		half := split.chips(pot.Amount).Div(decimal.NewFromInt(2)).Floor().Mul(split.unit())
+		firstPots[i] = domain.Pot{Amount: pot.Amount.Sub(half), EligibleIDs: pot.EligibleIDs}
+		secondPots[i] = domain.Pot{Amount: half, EligibleIDs: pot.EligibleIDs}
+	}
+
+	result := DetermineWinners(handPlayers, first, firstPots, variant, split)
+	secondResult := DetermineWinners(handPlayers, second, secondPots, variant, split)
+
+	for i := range result.Winners {
+		result.Winners[i].Run = 1
+	}
+	for _, w := range secondResult.Winners {
+		w.Run = 2
+		result.Winners = append(result.Winners, w)
+	}
+	for id, cards := range secondResult.ShowdownCards {
+		result.ShowdownCards[id] = cards
+	}
+
+	result.Pots = pots
+	result.Boards = [][]domain.Card{first, second}
+	return result
+}
+
+// award splits amount between the tied winners in whole chips. Anything
+// short of a whole chip goes with the first odd chip.
+func (s PotSplit) award(winners []uuid.UUID, amount decimal.Decimal, ranks map[uuid.UUID]HandRank, half domain.PotHalf) []domain.WinnerInfo {
+	if len(winners) == 0 {
+		return nil
+	}
+
+	ordered := s.order(winners)
+	unit := s.unit()
+	chips := s.chips(amount)
+	n := decimal.NewFromInt(int64(len(ordered)))
+	base := chips.Div(n).Floor()
+	odd := chips.Sub(base.Mul(n)).IntPart()
+	dust := amount.Sub(chips.Mul(unit))
+
+	awards := make([]domain.WinnerInfo, len(ordered))
+	for i, id := range ordered {
+		share := base.Mul(unit)
+		if int64(i) < odd {
+			share = share.Add(unit)
+		}
+		if i == 0 {
+			share = share.Add(dust)
+		}
+		awards[i] = domain.WinnerInfo{
+			PlayerID: id,
+			Amount:   share,
+			HandRank: ranks[id].Name,
+			Half:     half,
+
+			Category:    ranks[id].Category,
+			BestFive:    ranks[id].Cards,
+			Description: ranks[id].Description,
+		}
+	}
+
+	return awards
+}
+
+func (s PotSplit) unit() decimal.Decimal {
+	if !s.Denomination.IsPositive() {
+		return domain.DefaultChipDenomination
+	}
+	return s.Denomination
+}
+
+// chips is how many whole chips amount makes.
+func (s PotSplit) chips(amount decimal.Decimal) decimal.Decimal {
+	return amount.Div(s.unit()).Floor()
+}
+
+// order sorts winners by SeatOrder. Players missing from it go last.
+func (s PotSplit) order(winners []uuid.UUID) []uuid.UUID {
+	pos := make(map[uuid.UUID]int, len(s.SeatOrder))
+	for i, id := range s.SeatOrder {
+		pos[id] = i
+	}
+	rank := func(id uuid.UUID) int {
+		if i, ok := pos[id]; ok {
+			return i
+		}
+		return len(s.SeatOrder)
+	}
+
+	ordered := make([]uuid.UUID, len(winners))
+	copy(ordered, winners)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+	return ordered
+}
+
 func resolvePot(pot domain.Pot, handMap map[uuid.UUID]HandRank) []uuid.UUID {
 	if len(pot.EligibleIDs) == 0 {
 		return nil
 	}
 
+	var eligibleIDs []uuid.UUID
 	var eligibleRanks []HandRank
 	for _, id := range pot.EligibleIDs {
 		if rank, ok := handMap[id]; ok {
+			eligibleIDs = append(eligibleIDs, id)
 			eligibleRanks = append(eligibleRanks, rank)
 		}
 	}
@@ -66,24 +269,28 @@ func resolvePot(pot domain.Pot, handMap map[uuid.UUID]HandRank) []uuid.UUID {
 	winnerIndices := CompareHands(eligibleRanks)
 	winners := make([]uuid.UUID, len(winnerIndices))
 	for i, idx := range winnerIndices {
-		winners[i] = pot.EligibleIDs[idx]
+		winners[i] = eligibleIDs[idx]
 	}
 
 	return winners
 }
 
+// consolidateWinners merges each player's awards across pots, keeping the
+// high and low halves of split pots apart.
 func consolidateWinners(winners []domain.WinnerInfo) []domain.WinnerInfo {
-	totals := make(map[uuid.UUID]domain.WinnerInfo)
+	type awardKey struct {
+		playerID uuid.UUID
+		half     domain.PotHalf
+	}
+	totals := make(map[awardKey]domain.WinnerInfo)
 
 	for _, w := range winners {
-		if existing, ok := totals[w.PlayerID]; ok {
-			totals[w.PlayerID] = domain.WinnerInfo{
-				PlayerID: w.PlayerID,
-				Amount:   existing.Amount.Add(w.Amount),
-				HandRank: w.HandRank,
-			}
+		key := awardKey{playerID: w.PlayerID, half: w.Half}
+		if existing, ok := totals[key]; ok {
+			existing.Amount = existing.Amount.Add(w.Amount)
+			totals[key] = existing
 		} else {
-			totals[w.PlayerID] = w
+			totals[key] = w
 		}
 	}
 
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+type award struct {
+	player int
+	half   domain.PotHalf
+	amount string
+}
+
+// checkAwards compares a result's winners, players being given by their
+// index in ids.
+func checkAwards(t *testing.T, result domain.HandResult, ids []uuid.UUID, want []award) {
+	t.Helper()
+	got := make(map[award]bool)
+	for _, w := range result.Winners {
+		idx := -1
+		for i, id := range ids {
+			if id == w.PlayerID {
+				idx = i
+			}
+		}
+		got[award{idx, w.Half, w.Amount.String()}] = true
+	}
+	if len(got) != len(want) {
+		t.Fatalf("winners = %v, want %v", got, want)
+	}
+	for _, a := range want {
+		if !got[a] {
+			t.Errorf("missing award %+v in %v", a, got)
+		}
+	}
+}
+
+func TestDetermineWinnersHiLo(t *testing.T) {
+	// Player 0 makes the only low, A-2-3-5-7, and player 1 trip queens.
+	// Player 2 ties player 0's low. Player 3 has neither.
+	board := []string{"2c", "5d", "7h", "Kc", "Qs"}
+	holes := [][]string{
+		{"Ah", "3h", "4d", "8s"},
+		{"Qh", "Qd", "9c", "9d"},
+		{"Ad", "3c", "Jh", "Js"},
+		{"Th", "Td", "Jc", "Jd"},
+	}
+
+	tests := []struct {
+		name    string
+		players []int
+		pot     int64
+		order   []int
+		want    []award
+	}{
+		{
+			name:    "high and low split",
+			players: []int{0, 1},
+			pot:     100,
+			want:    []award{{0, domain.PotHalfLow, "50"}, {1, domain.PotHalfHigh, "50"}},
+		},
+		{
+			name:    "odd chip goes high",
+			players: []int{0, 1},
+			pot:     101,
+			want:    []award{{0, domain.PotHalfLow, "50"}, {1, domain.PotHalfHigh, "51"}},
+		},
+		{
+			name:    "no low scoops",
+			players: []int{1, 3},
+			pot:     101,
+			want:    []award{{1, domain.PotHalfScoop, "101"}},
+		},
+		{
+			name:    "quartered low",
+			players: []int{0, 1, 2},
+			pot:     100,
+			want:    []award{{0, domain.PotHalfLow, "25"}, {2, domain.PotHalfLow, "25"}, {1, domain.PotHalfHigh, "50"}},
+		},
+		{
+			name:    "odd chip of a quartered low goes left of the button",
+			players: []int{0, 1, 2},
+			pot:     102,
+			order:   []int{2, 1, 0},
+			want:    []award{{0, domain.PotHalfLow, "25"}, {2, domain.PotHalfLow, "26"}, {1, domain.PotHalfHigh, "51"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]uuid.UUID, len(holes))
+			for i := range ids {
+				ids[i] = uuid.New()
+			}
+
+			var hands []HandPlayerCards
+			var eligible []uuid.UUID
+			for _, p := range tt.players {
+				hands = append(hands, HandPlayerCards{PlayerID: ids[p], HoleCards: mustCards(t, holes[p]...)})
+				eligible = append(eligible, ids[p])
+			}
+			split := PotSplit{Denomination: decimal.NewFromInt(1)}
+			for _, p := range tt.order {
+				split.SeatOrder = append(split.SeatOrder, ids[p])
+			}
+
+			pots := []domain.Pot{{Amount: decimal.NewFromInt(tt.pot), EligibleIDs: eligible}}
+			result := DetermineWinners(hands, mustCards(t, board...), pots, VariantFor(domain.GamePLO8), split)
+			checkAwards(t, result, ids, tt.want)
+		})
+	}
+}
+
+// TestDetermineWinnersChipUnit splits tied pots in whole chips of the
+// table's denomination.
+func TestDetermineWinnersChipUnit(t *testing.T) {
+	board := []string{"Ac", "Jc", "9s", "4d", "2h"}
+	holes := [][]string{{"Kh", "Qh"}, {"Kd", "Qd"}, {"Ks", "Qs"}}
+
+	tests := []struct {
+		name  string
+		unit  string
+		pot   string
+		order []int
+		want  []award
+	}{
+		{
+			name: "even split",
+			unit: "1", pot: "90",
+			order: []int{0, 1, 2},
+			want:  []award{{0, "", "30"}, {1, "", "30"}, {2, "", "30"}},
+		},
+		{
+			name: "odd chips go left of the button",
+			unit: "1", pot: "92",
+			order: []int{1, 2, 0},
+			want:  []award{{0, "", "30"}, {1, "", "31"}, {2, "", "31"}},
+		},
+		{
+			name: "odd chips are whole chips",
+			unit: "5", pot: "25",
+			order: []int{2, 0, 1},
+			want:  []award{{0, "", "10"}, {1, "", "5"}, {2, "", "10"}},
+		},
+		{
+			name: "less than a chip goes with the first odd chip",
+			unit: "5", pot: "27",
+			order: []int{2, 0, 1},
+			want:  []award{{0, "", "10"}, {1, "", "5"}, {2, "", "12"}},
+		},
+		{
+			name: "cents",
+			unit: "0.01", pot: "1",
+			order: []int{0, 1, 2},
+			want:  []award{{0, "", "0.34"}, {1, "", "0.33"}, {2, "", "0.33"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]uuid.UUID, len(holes))
+			hands := make([]HandPlayerCards, len(holes))
+			for i := range ids {
+				ids[i] = uuid.New()
+				hands[i] = HandPlayerCards{PlayerID: ids[i], HoleCards: mustCards(t, holes[i]...)}
+			}
+			split := PotSplit{Denomination: decimal.RequireFromString(tt.unit)}
+			for _, p := range tt.order {
+				split.SeatOrder = append(split.SeatOrder, ids[p])
+			}
+
+			pots := []domain.Pot{{Amount: decimal.RequireFromString(tt.pot), EligibleIDs: ids}}
+			result := DetermineWinners(hands, mustCards(t, board...), pots, VariantFor(domain.GameHoldem), split)
+			checkAwards(t, result, ids, tt.want)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"slices"
 	"sort"
 
 	"github.com/google/uuid"
@@ -11,42 +12,51 @@ import (
 type PotContribution struct {
 	PlayerID uuid.UUID
 	TotalBet decimal.Decimal
-	IsAllIn  bool
-	IsFolded bool
+	// DeadMoney is what the player put in without it counting as a bet,
+	// such as antes. It goes to the main pot and earns no eligibility.
+	DeadMoney decimal.Decimal
+	IsAllIn   bool
+	IsFolded  bool
 }
 
+// CalculateSidePots splits what was put in into a main pot and side pots,
+// each with the players who can win it. Dead money makes up the bottom of
+// the main pot, which every player still in can win except one all-in for
+// their ante alone: they can win only as much of it as they matched. A pot
+// that nobody still in can win goes to the pot below it.
 func CalculateSidePots(contributions []PotContribution) []domain.Pot {
-	allInLevels := collectAllInLevels(contributions)
-
-	if len(allInLevels) == 0 {
-		return []domain.Pot{buildMainPot(contributions)}
-	}
+	deadPots := layerPots(contributions,
+		func(c PotContribution) decimal.Decimal { return c.DeadMoney },
+		func(c PotContribution) bool { return c.IsAllIn && !c.TotalBet.IsPositive() },
+	)
+	livePots := layerPots(contributions,
+		func(c PotContribution) decimal.Decimal { return c.TotalBet },
+		func(c PotContribution) bool { return c.IsAllIn },
+	)
+	return mergePots(append(deadPots, livePots...))
+}
 
+// layerPots splits one kind of chips at each level a capped player put
+// in. A player can win a pot unless they folded or were capped below it.
+func layerPots(contributions []PotContribution, amount func(PotContribution) decimal.Decimal, capped func(PotContribution) bool) []domain.Pot {
 	var pots []domain.Pot
 	prevLevel := decimal.Zero
 
-	for _, level := range allInLevels {
-		pot := buildPotAtLevel(contributions, prevLevel, level)
-		if pot.Amount.IsPositive() {
-			pots = append(pots, pot)
-		}
+	for _, level := range collectCapLevels(contributions, amount, capped) {
+		pots = append(pots, buildPotAtLevel(contributions, amount, capped, prevLevel, level))
 		prevLevel = level
 	}
 
-	remainder := buildRemainderPot(contributions, prevLevel)
-	if remainder.Amount.IsPositive() {
-		pots = append(pots, remainder)
-	}
-
-	return pots
+	return append(pots, buildRemainderPot(contributions, amount, capped, prevLevel))
 }
 
-func collectAllInLevels(contributions []PotContribution) []decimal.Decimal {
+// collectCapLevels lists the distinct positive amounts capped players put
+// in, lowest first.
+func collectCapLevels(contributions []PotContribution, amount func(PotContribution) decimal.Decimal, capped func(PotContribution) bool) []decimal.Decimal {
 	levelMap := make(map[string]decimal.Decimal)
 	for _, c := range contributions {
-		if c.IsAllIn {
-			key := c.TotalBet.String()
-			levelMap[key] = c.TotalBet
+		if level := amount(c); capped(c) && level.IsPositive() {
+			levelMap[level.String()] = level
 		}
 	}
 
@@ -62,59 +72,75 @@ func collectAllInLevels(contributions []PotContribution) []decimal.Decimal {
 	return levels
 }
 
-func buildMainPot(contributions []PotContribution) domain.Pot {
-	amount := decimal.Zero
+func buildPotAtLevel(contributions []PotContribution, amount func(PotContribution) decimal.Decimal, capped func(PotContribution) bool, prevLevel, level decimal.Decimal) domain.Pot {
+	total := decimal.Zero
 	var eligible []uuid.UUID
 
 	for _, c := range contributions {
-		amount = amount.Add(c.TotalBet)
-		if !c.IsFolded {
+		put := amount(c)
+		if share := decimal.Min(put, level).Sub(prevLevel); share.IsPositive() {
+			total = total.Add(share)
+		}
+		if !c.IsFolded && (!capped(c) || put.GreaterThanOrEqual(level)) {
 			eligible = append(eligible, c.PlayerID)
 		}
 	}
 
 	return domain.Pot{
-		Amount:      amount,
+		Amount:      total,
 		EligibleIDs: eligible,
 	}
 }
 
-func buildPotAtLevel(contributions []PotContribution, prevLevel, level decimal.Decimal) domain.Pot {
-	amount := decimal.Zero
+func buildRemainderPot(contributions []PotContribution, amount func(PotContribution) decimal.Decimal, capped func(PotContribution) bool, lastLevel decimal.Decimal) domain.Pot {
+	total := decimal.Zero
 	var eligible []uuid.UUID
 
 	for _, c := range contributions {
-		contribution := decimal.Min(c.TotalBet, level).Sub(prevLevel)
-		if contribution.IsPositive() {
-			amount = amount.Add(contribution)
+		put := amount(c)
+		if remainder := put.Sub(lastLevel); remainder.IsPositive() {
+			total = total.Add(remainder)
 		}
-		if !c.IsFolded && c.TotalBet.GreaterThanOrEqual(level) {
+		if !c.IsFolded && (!capped(c) || put.GreaterThan(lastLevel)) {
 			eligible = append(eligible, c.PlayerID)
 		}
 	}
 
 	return domain.Pot{
-		Amount:      amount,
+		Amount:      total,
 		EligibleIDs: eligible,
 	}
 }
 
-func buildRemainderPot(contributions []PotContribution, lastLevel decimal.Decimal) domain.Pot {
-	amount := decimal.Zero
-	var eligible []uuid.UUID
+// mergePots drops empty pots, hands a pot nobody can win to the pot below
+// it, or above it when there is none below, and joins neighbouring pots
+// the same players can win.
+func mergePots(pots []domain.Pot) []domain.Pot {
+	var merged []domain.Pot
+	orphaned := decimal.Zero
 
-	for _, c := range contributions {
-		remainder := c.TotalBet.Sub(lastLevel)
-		if remainder.IsPositive() {
-			amount = amount.Add(remainder)
+	for _, pot := range pots {
+		if !pot.Amount.IsPositive() {
+			continue
 		}
-		if !c.IsFolded && c.TotalBet.GreaterThan(lastLevel) {
-			eligible = append(eligible, c.PlayerID)
+		if len(pot.EligibleIDs) == 0 {
+			if len(merged) > 0 {
+				merged[len(merged)-1].Amount = merged[len(merged)-1].Amount.Add(pot.Amount)
+			} else {
+				orphaned = orphaned.Add(pot.Amount)
+			}
+			continue
 		}
-	}
 
-	return domain.Pot{
-		Amount:      amount,
-		EligibleIDs: eligible,
+		pot.Amount = pot.Amount.Add(orphaned)
+		orphaned = decimal.Zero
+
+		if n := len(merged); n > 0 && slices.Equal(merged[n-1].EligibleIDs, pot.EligibleIDs) {
+			merged[n-1].Amount = merged[n-1].Amount.Add(pot.Amount)
+			continue
+		}
+		merged = append(merged, pot)
 	}
+
+	return merged
 }
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestCalculateSidePots(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	dec := decimal.NewFromInt
+
+	type wantPot struct {
+		amount   int64
+		eligible []uuid.UUID
+	}
+
+	tests := []struct {
+		name          string
+		contributions []PotContribution
+		want          []wantPot
+	}{
+		{
+			name: "no all-in makes one pot",
+			contributions: []PotContribution{
+				{PlayerID: a, TotalBet: dec(100)},
+				{PlayerID: b, TotalBet: dec(100)},
+				{PlayerID: c, TotalBet: dec(40), IsFolded: true},
+			},
+			want: []wantPot{{240, []uuid.UUID{a, b}}},
+		},
+		{
+			name: "short all-in splits off a side pot",
+			contributions: []PotContribution{
+				{PlayerID: a, TotalBet: dec(50), IsAllIn: true},
+				{PlayerID: b, TotalBet: dec(100)},
+				{PlayerID: c, TotalBet: dec(100)},
+			},
+			want: []wantPot{{150, []uuid.UUID{a, b, c}}, {100, []uuid.UUID{b, c}}},
+		},
+		{
+			name: "antes join the main pot",
+			contributions: []PotContribution{
+				{PlayerID: a, TotalBet: dec(50), DeadMoney: dec(5), IsAllIn: true},
+				{PlayerID: b, TotalBet: dec(100), DeadMoney: dec(5)},
+				{PlayerID: c, TotalBet: dec(100), DeadMoney: dec(5)},
+			},
+			want: []wantPot{{165, []uuid.UUID{a, b, c}}, {100, []uuid.UUID{b, c}}},
+		},
+		{
+			name: "all-in for the ante alone wins the antes",
+			contributions: []PotContribution{
+				{PlayerID: a, DeadMoney: dec(5), IsAllIn: true},
+				{PlayerID: b, TotalBet: dec(100), DeadMoney: dec(10)},
+				{PlayerID: c, TotalBet: dec(100), DeadMoney: dec(10)},
+			},
+			want: []wantPot{{15, []uuid.UUID{a, b, c}}, {210, []uuid.UUID{b, c}}},
+		},
+		{
+			name: "big blind ante belongs to everyone",
+			contributions: []PotContribution{
+				{PlayerID: a, TotalBet: dec(20)},
+				{PlayerID: b, TotalBet: dec(20), DeadMoney: dec(20)},
+				{PlayerID: c, TotalBet: dec(10), IsFolded: true},
+			},
+			want: []wantPot{{70, []uuid.UUID{a, b}}},
+		},
+		{
+			name: "pot nobody can win goes below",
+			contributions: []PotContribution{
+				{PlayerID: a, TotalBet: dec(50), IsAllIn: true},
+				{PlayerID: b, TotalBet: dec(50), IsAllIn: true},
+				{PlayerID: c, TotalBet: dec(80), IsFolded: true},
+			},
+			want: []wantPot{{180, []uuid.UUID{a, b}}},
+		},
+		{
+			name: "ante only pot with every bettor folded",
+			contributions: []PotContribution{
+				{PlayerID: a, DeadMoney: dec(5), IsAllIn: true},
+				{PlayerID: b, TotalBet: dec(30), DeadMoney: dec(5), IsFolded: true},
+				{PlayerID: c, TotalBet: dec(10), DeadMoney: dec(5), IsFolded: true},
+				{PlayerID: d, DeadMoney: dec(5), IsFolded: true},
+			},
+			want: []wantPot{{60, []uuid.UUID{a}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pots := CalculateSidePots(tt.contributions)
+			if len(pots) != len(tt.want) {
+				t.Fatalf("got %d pots %v, want %d", len(pots), pots, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if !pots[i].Amount.Equal(dec(want.amount)) {
+					t.Errorf("pot %d amount = %s, want %d", i, pots[i].Amount, want.amount)
+				}
+				if !slices.Equal(pots[i].EligibleIDs, want.eligible) {
+					t.Errorf("pot %d eligible = %v, want %v", i, pots[i].EligibleIDs, want.eligible)
+				}
+			}
+		})
+	}
+}
+
+func TestDetermineWinnersNeverDropsAPot(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	hands := []HandPlayerCards{
+		{PlayerID: a, HoleCards: mustCards(t, "As", "Ad")},
+		{PlayerID: b, HoleCards: mustCards(t, "Ks", "Kd")},
+	}
+	board := mustCards(t, "2c", "7d", "9h", "Jc", "3s")
+	pots := []domain.Pot{
+		{Amount: decimal.NewFromInt(100), EligibleIDs: []uuid.UUID{a, b}},
+		{Amount: decimal.NewFromInt(40), EligibleIDs: []uuid.UUID{uuid.New()}},
+	}
+
+	result := DetermineWinners(hands, board, pots, VariantFor(domain.GameHoldem), PotSplit{})
+
+	total := decimal.Zero
+	for _, w := range result.Winners {
+		total = total.Add(w.Amount)
+	}
+	if !total.Equal(decimal.NewFromInt(140)) {
+		t.Fatalf("awarded %s, want 140", total)
+	}
+}
+
+func mustCards(t *testing.T, codes ...string) []domain.Card {
+	t.Helper()
+	cards := make([]domain.Card, len(codes))
+	for i, code := range codes {
+		c, err := domain.ParseCard(code)
+		if err != nil {
+			t.Fatalf("ParseCard(%q): %v", code, err)
+		}
+		cards[i] = c
+	}
+	return cards
+}
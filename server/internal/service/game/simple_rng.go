@@ -25,8 +25,8 @@ func (s *SimpleRNGService) HashSeed(serverSeed string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func (s *SimpleRNGService) ShuffleDeck(serverSeed, clientSeed string, nonce int) []domain.Card {
-	deck := domain.FullDeck()
+func (s *SimpleRNGService) ShuffleDeck(cards []domain.Card, serverSeed, clientSeed string, nonce int) []domain.Card {
+	deck := append([]domain.Card(nil), cards...)
 
 	combined := fmt.Sprintf("%s:%s:%d", serverSeed, clientSeed, nonce)
 	seed := sha256.Sum256([]byte(combined))
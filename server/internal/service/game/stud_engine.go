@@ -0,0 +1,383 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// studMaxPlayers keeps the deck from running out before seventh street.
+const studMaxPlayers = 8
+
+// isStudTable reports whether a table's settings suit seven card stud:
+// fixed-limit betting, at most studMaxPlayers seats, and an ante and
+// bring-in in place of blinds and straddles.
+func isStudTable(table domain.PokerTable) bool {
+	return table.Limit == domain.LimitFixed &&
+		table.MaxPlayers <= studMaxPlayers &&
+		table.Straddle == domain.StraddleNone &&
+		table.BlindType == domain.BlindsStandard &&
+		table.AnteType != domain.AnteBigBlind
+}
+
+// studEngine deals seven card stud. Everyone antes, the lowest upcard
+// brings it in for the small blind, and the betting is fixed-limit: the
+// big blind is the small bet on third and fourth street and doubles from
+// fifth street on.
+type studEngine struct {
+	*TableHub
+}
+
+// studVariant scores the best five of a player's seven cards.
+var studVariant = Variant{Deck: domain.FullDeck, BestHand: BestHand}
+
+// suitOrder breaks ties between equal upcards for the bring-in, from clubs
+// up to spades.
+var suitOrder = map[domain.Suit]int{
+	domain.SuitClubs:    0,
+	domain.SuitDiamonds: 1,
+	domain.SuitHearts:   2,
+	domain.SuitSpades:   3,
+}
+
+func (h *studEngine) startHand(ctx context.Context) error {
+	serverSeed, err := h.rngSvc.GenerateServerSeed()
+	if err != nil {
+		return fmt.Errorf("generate server seed: %w", err)
+	}
+
+	seats := h.handSeats()
+	if len(seats) < 2 {
+		return domain.ErrMinPlayersRequired
+	}
+
+	h.state.HandCount++
+	h.state.DealerSeat = nextOccupiedSeat(seats, h.state.DealerSeat)
+	h.refillTimeBanks()
+
+	deck := h.rngSvc.ShuffleDeck(studVariant.Deck(), serverSeed, "default", h.state.HandCount)
+
+	hand := domain.PokerHand{
+		ID:         uuid.New(),
+		TableID:    h.state.Table.ID,
+		HandNumber: h.state.HandCount,
+		Pot:        decimal.Zero,
+		Stage:      domain.StageThirdStreet,
+	}
+
+	bettingPlayers := make([]BettingPlayer, 0, len(seats))
+	cumulativeBets := make(map[uuid.UUID]decimal.Decimal)
+	for _, seat := range seats {
+		p := h.state.Players[seat]
+		if p.IsSittingOut() {
+			updated := *p
+			updated.Status = domain.PlayerStatusActive
+			updated.WaitingForBigBlind = false
+			h.state.Players[seat] = &updated
+			p = &updated
+		}
+
+		cumulativeBets[p.ID] = decimal.Zero
+		bettingPlayers = append(bettingPlayers, BettingPlayer{
+			PlayerID:     p.ID,
+			Stack:        p.Stack,
+			BetThisRound: decimal.Zero,
+		})
+	}
+
+	fsm, err := NewGameFSM().Transition(domain.StageThirdStreet)
+	if err != nil {
+		return fmt.Errorf("FSM transition to third street: %w", err)
+	}
+
+	dead := make(map[uuid.UUID]decimal.Decimal)
+	h.state.Hand = &HandState{
+		Hand:           hand,
+		FSM:            fsm,
+		Deck:           deck,
+		PlayerHands:    make(map[uuid.UUID][]domain.Card),
+		UpCards:        make(map[uuid.UUID][]domain.Card),
+		CumulativeBets: cumulativeBets,
+		DeadMoney:      dead,
+		ServerSeed:     serverSeed,
+		SeedHash:       h.rngSvc.HashSeed(serverSeed),
+		ClientSeed:     "default",
+		Nonce:          h.state.HandCount,
+		PreActions:     make(map[uuid.UUID]domain.PreAction),
+	}
+
+	betting := NewBettingState(bettingPlayers, h.betSize(domain.StageThirdStreet), decimal.Zero, domain.LimitFixed)
+	if h.state.Table.Ante.IsPositive() {
+		for i := range betting.Players {
+			betting = h.postDead(betting, i, h.state.Table.Ante, dead)
+		}
+	}
+
+	h.state.Hand.Betting = betting
+	h.dealStreet(domain.StageThirdStreet)
+
+	// The bring-in counts as the opening bet, so if everyone just calls
+	// the bring-in player has no option.
+	if idx := h.bringInIndex(); idx >= 0 {
+		betting = h.postLive(betting, idx, h.state.Table.SmallBlind)
+		betting.Players[idx].HasActed = true
+		betting.CurrentIdx = NextPlayer(betting.Players, idx)
+	}
+	h.state.Hand.Betting = betting
+	h.state.Hand.Hand.Pot = betting.PotSize
+
+	if _, err := h.handRepo.Create(ctx, h.state.Hand.Hand); err != nil {
+		h.logger.Error("failed to persist hand", "error", err)
+	}
+
+	h.broadcastNewHand()
+	h.broadcastStreet(domain.StageThirdStreet)
+	h.broadcastPotUpdate()
+
+	if IsBettingComplete(betting) {
+		h.advanceStage(ctx)
+		return nil
+	}
+	h.startTurn(ctx)
+
+	return nil
+}
+
+func (h *studEngine) advanceStage(ctx context.Context) {
+	h.exposeAllInHands()
+	handState := h.state.Hand
+	nextStage := handState.FSM.NextStage()
+
+	if nextStage == domain.StageShowdown {
+		h.bankBets()
+		newFSM, _ := handState.FSM.Transition(domain.StageShowdown)
+		h.state.Hand.FSM = newFSM
+		h.settleShowdown(ctx, studVariant)
+		return
+	}
+
+	newFSM, err := handState.FSM.Transition(nextStage)
+	if err != nil {
+		h.logger.Error("FSM transition failed", "error", err, "to", nextStage)
+		return
+	}
+
+	h.state.Hand.FSM = newFSM
+	h.state.Hand.Hand.Stage = nextStage
+	h.clearPreActions()
+	h.dealStreet(nextStage)
+	canAct := h.openBettingRound(h.betSize(nextStage))
+
+	h.broadcastStreet(nextStage)
+
+	if !canAct {
+		h.advanceStage(ctx)
+		return
+	}
+
+	h.state.Hand.Betting.CurrentIdx = h.bestShowingIndex()
+	h.startTurn(ctx)
+}
+
+// handSeats are the seats dealt into the next hand. With no blinds to
+// wait for, players waiting for the big blind are dealt straight in.
+func (h *studEngine) handSeats() []int {
+	var seats []int
+	for seat, p := range h.state.Players {
+		if !p.IsSittingOut() || p.WaitingForBigBlind {
+			seats = append(seats, seat)
+		}
+	}
+	sort.Ints(seats)
+	return seats
+}
+
+// betSize is the small bet on third and fourth street and the big bet
+// after that.
+func (h *studEngine) betSize(stage domain.GameStage) decimal.Decimal {
+	bb := h.state.Table.BigBlind
+	if stage == domain.StageThirdStreet || stage == domain.StageFourthStreet {
+		return bb
+	}
+	return bb.Mul(decimal.NewFromInt(2))
+}
+
+// dealStreet deals a street to every player still in the hand: two down
+// and one up on third street, one up on fourth to sixth, and one down on
+// seventh. If the deck cannot go round on seventh street, one shared card
+// is dealt face up instead.
+func (h *studEngine) dealStreet(stage domain.GameStage) {
+	hand := h.state.Hand
+
+	var live []uuid.UUID
+	for _, bp := range hand.Betting.Players {
+		if !bp.IsFolded {
+			live = append(live, bp.PlayerID)
+		}
+	}
+
+	switch stage {
+	case domain.StageThirdStreet:
+		for _, id := range live {
+			h.dealTo(id, 2, false)
+			h.dealTo(id, 1, true)
+		}
+	case domain.StageFourthStreet, domain.StageFifthStreet, domain.StageSixthStreet:
+		for _, id := range live {
+			h.dealTo(id, 1, true)
+		}
+	case domain.StageSeventhStreet:
+		if len(hand.Deck) < len(live) {
+			hand.CommunityCards = append(hand.CommunityCards, hand.Deck[0])
+			hand.Deck = hand.Deck[1:]
+			hand.Hand.CommunityCards = domain.CardsToString(hand.CommunityCards)
+			return
+		}
+		for _, id := range live {
+			h.dealTo(id, 1, false)
+		}
+	}
+}
+
+func (h *studEngine) dealTo(playerID uuid.UUID, count int, faceUp bool) {
+	hand := h.state.Hand
+	if len(hand.Deck) < count {
+		return
+	}
+
+	cards := hand.Deck[:count]
+	hand.Deck = hand.Deck[count:]
+	hand.PlayerHands[playerID] = append(hand.PlayerHands[playerID], cards...)
+	if faceUp {
+		hand.UpCards[playerID] = append(hand.UpCards[playerID], cards...)
+	}
+}
+
+// bringInIndex is the player with the lowest upcard, with suits breaking
+// ties. Players all in on the ante are skipped; -1 means nobody can bring
+// it in.
+func (h *studEngine) bringInIndex() int {
+	betting := h.state.Hand.Betting
+	lowest := -1
+	var lowCard domain.Card
+
+	for i, bp := range betting.Players {
+		up := h.state.Hand.UpCards[bp.PlayerID]
+		if bp.IsFolded || bp.IsAllIn || len(up) == 0 {
+			continue
+		}
+		card := up[0]
+		if lowest == -1 || card.Rank.Value() < lowCard.Rank.Value() ||
+			(card.Rank == lowCard.Rank && suitOrder[card.Suit] < suitOrder[lowCard.Suit]) {
+			lowest = i
+			lowCard = card
+		}
+	}
+
+	return lowest
+}
+
+// bestShowingIndex is the player to act first after third street: the
+// best hand showing, with ties going to the first player left of the
+// dealer.
+func (h *studEngine) bestShowingIndex() int {
+	players := h.state.Hand.Betting.Players
+	n := len(players)
+
+	start := 0
+	for i, bp := range players {
+		if p := h.state.FindPlayerByID(bp.PlayerID); p != nil && p.SeatNumber > h.state.DealerSeat {
+			start = i
+			break
+		}
+	}
+
+	best := -1
+	var bestScore []int
+	for k := 0; k < n; k++ {
+		i := (start + k) % n
+		bp := players[i]
+		if bp.IsFolded || bp.IsAllIn {
+			continue
+		}
+		score := showingScore(h.state.Hand.UpCards[bp.PlayerID])
+		if best == -1 || slices.Compare(score, bestScore) > 0 {
+			best = i
+			bestScore = score
+		}
+	}
+
+	return max(best, 0)
+}
+
+// showingScore ranks up to four upcards: quads, trips, two pair, a pair or
+// high cards, followed by the ranks that decide between equal groups.
+// Straights and flushes do not count until all five cards are out. Higher
+// scores are better.
+func showingScore(cards []domain.Card) []int {
+	counts := make(map[int]int)
+	for _, c := range cards {
+		counts[c.Rank.Value()]++
+	}
+
+	values := make([]int, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] > counts[values[j]]
+		}
+		return values[i] > values[j]
+	})
+
+	category := 0
+	if len(values) > 0 {
+		switch top := counts[values[0]]; {
+		case top == 4:
+			category = 4
+		case top == 3:
+			category = 3
+		case top == 2 && len(values) > 1 && counts[values[1]] == 2:
+			category = 2
+		case top == 2:
+			category = 1
+		}
+	}
+
+	return append([]int{category}, values...)
+}
+
+// broadcastStreet shows everyone the upcards of the players still in the
+// hand, and sends each of them all their cards on the streets that deal
+// down cards.
+func (h *studEngine) broadcastStreet(stage domain.GameStage) {
+	hand := h.state.Hand
+
+	upCards := make(map[uuid.UUID][]domain.Card)
+	for _, bp := range hand.Betting.Players {
+		if bp.IsFolded {
+			continue
+		}
+		p := h.state.FindPlayerByID(bp.PlayerID)
+		if p == nil {
+			continue
+		}
+		upCards[p.UserID] = hand.UpCards[bp.PlayerID]
+		if stage == domain.StageThirdStreet || stage == domain.StageSeventhStreet {
+			h.sendCardsDealt(p.UserID, hand.Hand.ID, hand.PlayerHands[bp.PlayerID])
+		}
+	}
+
+	msg := h.buildMessage(domain.WSMsgUpCards, domain.WSUpCards{
+		Stage:          stage,
+		UpCards:        upCards,
+		CommunityCards: hand.CommunityCards,
+	})
+	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
+}
@@ -0,0 +1,242 @@
+package game
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// studTable seats a player per entry of upCards, from seat 1, with those
+// upcards showing. allIn and folded mark players by index.
+func studTable(t *testing.T, dealerSeat int, upCards [][]string, allIn, folded []int) *studEngine {
+	t.Helper()
+	stacks := make([]int64, len(upCards))
+	for i := range stacks {
+		stacks[i] = 1000
+	}
+	betting := seatPlayers(stacks...)
+	for _, i := range allIn {
+		betting[i].IsAllIn = true
+		betting[i].Stack = decimal.Zero
+	}
+	for _, i := range folded {
+		betting[i].IsFolded = true
+	}
+
+	state := TableState{
+		Players:    make(map[int]*domain.PokerPlayer),
+		DealerSeat: dealerSeat,
+		Hand: &HandState{
+			Betting:     BettingState{Players: betting},
+			PlayerHands: make(map[uuid.UUID][]domain.Card),
+			UpCards:     make(map[uuid.UUID][]domain.Card),
+		},
+	}
+	for i, bp := range betting {
+		state.Players[i+1] = &domain.PokerPlayer{ID: bp.PlayerID, SeatNumber: i + 1}
+		up := mustCards(t, upCards[i]...)
+		state.Hand.UpCards[bp.PlayerID] = up
+		state.Hand.PlayerHands[bp.PlayerID] = slices.Clone(up)
+	}
+	return &studEngine{TableHub: &TableHub{state: state}}
+}
+
+func TestStudBringInIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		upCards [][]string
+		allIn   []int
+		folded  []int
+		want    int
+	}{
+		{
+			name:    "lowest upcard",
+			upCards: [][]string{{"Kd"}, {"3h"}, {"9s"}},
+			want:    1,
+		},
+		{
+			name:    "clubs lowest on equal ranks",
+			upCards: [][]string{{"3s"}, {"3c"}, {"3d"}},
+			want:    1,
+		},
+		{
+			name:    "spades highest on equal ranks",
+			upCards: [][]string{{"4s"}, {"4h"}},
+			want:    1,
+		},
+		{
+			name:    "all in on the ante is skipped",
+			upCards: [][]string{{"2c"}, {"5h"}, {"9s"}},
+			allIn:   []int{0},
+			want:    1,
+		},
+		{
+			name:    "folded is skipped",
+			upCards: [][]string{{"Ac"}, {"2d"}, {"8s"}},
+			folded:  []int{1},
+			want:    2,
+		},
+		{
+			name:    "nobody left to bring it in",
+			upCards: [][]string{{"2c"}, {"5h"}},
+			allIn:   []int{0, 1},
+			want:    -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := studTable(t, 0, tt.upCards, tt.allIn, tt.folded)
+			if got := h.bringInIndex(); got != tt.want {
+				t.Errorf("bringInIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStudShowingScoreOrder(t *testing.T) {
+	// From worst to best.
+	showings := [][]string{
+		{"2c", "3d", "5h", "7s"},
+		{"2c", "3d", "5h", "Ks"},
+		{"Ac", "Kd", "Qh", "Js"},
+		{"2c", "2d", "3h", "4s"},
+		{"2c", "2d", "3h", "5s"},
+		{"Ac", "Ad", "2h", "3s"},
+		{"3c", "3d", "2h", "2s"},
+		{"Kc", "Kd", "2h", "2s"},
+		{"Kc", "Kd", "3h", "3s"},
+		{"2c", "2d", "2h", "As"},
+		{"Ac", "Ad", "Ah", "2s"},
+		{"2c", "2d", "2h", "2s"},
+		{"Ac", "Ad", "Ah", "As"},
+	}
+
+	for i := 1; i < len(showings); i++ {
+		lower := showingScore(mustCards(t, showings[i-1]...))
+		higher := showingScore(mustCards(t, showings[i]...))
+		if slices.Compare(higher, lower) <= 0 {
+			t.Errorf("showingScore(%v) = %v, want above showingScore(%v) = %v",
+				showings[i], higher, showings[i-1], lower)
+		}
+	}
+
+	if a, b := showingScore(mustCards(t, "Kc", "7d")), showingScore(mustCards(t, "Ks", "7h")); slices.Compare(a, b) != 0 {
+		t.Errorf("suits changed the score: %v and %v", a, b)
+	}
+}
+
+func TestStudBestShowingIndex(t *testing.T) {
+	tests := []struct {
+		name       string
+		dealerSeat int
+		upCards    [][]string
+		allIn      []int
+		folded     []int
+		want       int
+	}{
+		{
+			name:    "pair beats high cards",
+			upCards: [][]string{{"Ac", "Kd"}, {"7h", "7s"}, {"Qd", "Jc"}},
+			want:    1,
+		},
+		{
+			name:    "kicker decides equal pairs",
+			upCards: [][]string{{"9c", "9d", "2h"}, {"9h", "9s", "4c"}},
+			want:    1,
+		},
+		{
+			name:       "tie goes to the first left of the dealer",
+			dealerSeat: 1,
+			upCards:    [][]string{{"Ac", "Kd"}, {"As", "Kh"}, {"2c", "3d"}},
+			want:       1,
+		},
+		{
+			name:       "tie goes round from the dealer",
+			dealerSeat: 2,
+			upCards:    [][]string{{"2c", "3d"}, {"As", "Kh"}, {"Ac", "Kd"}},
+			want:       2,
+		},
+		{
+			name:    "all in and folded are skipped",
+			upCards: [][]string{{"Ac", "Ad"}, {"Kc", "Kd"}, {"5h", "9s"}},
+			allIn:   []int{0},
+			folded:  []int{1},
+			want:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := studTable(t, tt.dealerSeat, tt.upCards, tt.allIn, tt.folded)
+			if got := h.bestShowingIndex(); got != tt.want {
+				t.Errorf("bestShowingIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStudSeventhStreet(t *testing.T) {
+	upCards := [][]string{{"Ac"}, {"Kd"}, {"Qh"}}
+
+	tests := []struct {
+		name      string
+		deck      []string
+		folded    []int
+		shared    []string
+		downCards int
+		deckLeft  int
+	}{
+		{
+			name:      "one down card each",
+			deck:      []string{"2c", "3c", "4c", "5c"},
+			downCards: 1,
+			deckLeft:  1,
+		},
+		{
+			name:     "short deck deals one shared card",
+			deck:     []string{"2c", "3c"},
+			shared:   []string{"2c"},
+			deckLeft: 1,
+		},
+		{
+			name:      "folded players need no card",
+			deck:      []string{"2c", "3c"},
+			folded:    []int{2},
+			downCards: 1,
+			deckLeft:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := studTable(t, 0, upCards, nil, tt.folded)
+			h.state.Hand.Deck = mustCards(t, tt.deck...)
+
+			h.dealStreet(domain.StageSeventhStreet)
+
+			hand := h.state.Hand
+			if want := mustCards(t, tt.shared...); !slices.Equal(hand.CommunityCards, want) {
+				t.Errorf("community cards = %v, want %v", hand.CommunityCards, want)
+			}
+			if len(hand.Deck) != tt.deckLeft {
+				t.Errorf("%d cards left in the deck, want %d", len(hand.Deck), tt.deckLeft)
+			}
+			for i, bp := range hand.Betting.Players {
+				want := len(upCards[i])
+				if !bp.IsFolded {
+					want += tt.downCards
+				}
+				if got := len(hand.PlayerHands[bp.PlayerID]); got != want {
+					t.Errorf("player %d holds %d cards, want %d", i, got, want)
+				}
+				if got := len(hand.UpCards[bp.PlayerID]); got != len(upCards[i]) {
+					t.Errorf("player %d shows %d cards, want %d", i, got, len(upCards[i]))
+				}
+			}
+		})
+	}
+}
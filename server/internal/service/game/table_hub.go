@@ -22,39 +22,58 @@ type TableHub struct {
 	eventCh     chan HubEvent
 	stateCh     chan chan TableState
 	turnTimer   *time.Timer
-	turnTimeout time.Duration
+	clock       TurnClock
+	engine      gameEngine
 	broadcaster ports.Broadcaster
 	walletSvc   ports.WalletService
 	rngSvc      ports.RNGService
 	handRepo    ports.PokerHandRepository
 	playerRepo  ports.PokerPlayerRepository
+	rakeRepo    ports.RakeRepository
 	logger      *slog.Logger
 	done        chan struct{}
+
+	// Set on tournament tables only, which deal when the director says.
+	director *TournamentDirector
+
+	// The hand last played, kept until the next deal so that a player who
+	// won it uncontested can still show their cards.
+	lastHand *HandState
+	// showOrMuck is the choice left to the losers of the last showdown.
+	showOrMuck *showOrMuck
+
+	// Set while the player to act is drawing on their time bank.
+	bankPlayerID  uuid.UUID
+	bankStartedAt time.Time
 }
 
 func NewTableHub(
 	table domain.PokerTable,
-	turnTimeout time.Duration,
+	clock TurnClock,
 	broadcaster ports.Broadcaster,
 	walletSvc ports.WalletService,
 	rngSvc ports.RNGService,
 	handRepo ports.PokerHandRepository,
 	playerRepo ports.PokerPlayerRepository,
+	rakeRepo ports.RakeRepository,
 	logger *slog.Logger,
 ) *TableHub {
-	return &TableHub{
+	h := &TableHub{
 		state:       NewTableState(table),
 		eventCh:     make(chan HubEvent, 64),
 		stateCh:     make(chan chan TableState, 8),
-		turnTimeout: turnTimeout,
+		clock:       clock,
 		broadcaster: broadcaster,
 		walletSvc:   walletSvc,
 		rngSvc:      rngSvc,
 		handRepo:    handRepo,
 		playerRepo:  playerRepo,
+		rakeRepo:    rakeRepo,
 		logger:      logger.With("table_id", table.ID),
 		done:        make(chan struct{}),
 	}
+	h.engine = newGameEngine(h)
+	return h
 }
 
 func (h *TableHub) Send(event HubEvent) error {
@@ -127,6 +146,9 @@ func (h *TableHub) copyState() TableState {
 		Hand:       h.state.Hand,
 		DealerSeat: h.state.DealerSeat,
 		HandCount:  h.state.HandCount,
+
+		SmallBlindSeat: h.state.SmallBlindSeat,
+		BigBlindSeat:   h.state.BigBlindSeat,
 	}
 	for seat, p := range h.state.Players {
 		playerCopy := *p
@@ -149,6 +171,20 @@ func (h *TableHub) handleEvent(ctx context.Context, event HubEvent) {
 		result.Stack = stack
 	case EventStartHand:
 		result.Err = h.tryStartHand(ctx)
+	case EventPlayerRename:
+		h.handlePlayerRename(event.UserID, event.Username)
+	case EventSitOut:
+		result.Err = h.handleSitOut(event.UserID)
+	case EventSitIn:
+		result.Err = h.handleSitIn(ctx, event.UserID, event.WaitForBigBlind)
+	case EventPreAction:
+		result.Err = h.handlePreAction(ctx, event.UserID, event.PreAction)
+	case EventRunItTwice:
+		result.Err = h.handleRunItTwice(ctx, event.UserID, event.Agree)
+	case EventShowCards:
+		result.Err = h.handleShowCards(event.UserID)
+	case EventMuckCards:
+		result.Err = h.handleMuckCards(event.UserID)
 	}
 
 	if event.ResultCh != nil {
@@ -177,13 +213,14 @@ func (h *TableHub) handlePlayerJoin(ctx context.Context, event HubEvent) error {
 		SeatNumber: event.SeatNum,
 		Status:     domain.PlayerStatusActive,
 		JoinedAt:   time.Now(),
+		TimeBank:   h.clock.TimeBank,
 	}
 
 	h.state.Players[event.SeatNum] = player
 
 	h.broadcastTableState()
 
-	if h.state.Hand == nil && len(h.state.Players) >= 2 {
+	if h.director == nil && h.state.Hand == nil && h.state.DealablePlayerCount() >= 2 {
 		return h.tryStartHand(ctx)
 	}
 
@@ -201,6 +238,7 @@ func (h *TableHub) handlePlayerLeave(ctx context.Context, userID uuid.UUID) (*de
 			if bp.PlayerID == player.ID && !bp.IsFolded {
 				newState := applyFold(h.state.Hand.Betting, i)
 				h.state.Hand.Betting = newState
+				delete(h.state.Hand.PreActions, player.ID)
 				break
 			}
 		}
@@ -211,13 +249,113 @@ func (h *TableHub) handlePlayerLeave(ctx context.Context, userID uuid.UUID) (*de
 
 	h.broadcastTableState()
 
-	if h.state.Hand != nil && ActivePlayerCount(h.state.Hand.Betting) <= 1 {
+	switch {
+	case h.state.Hand != nil && ActivePlayerCount(h.state.Hand.Betting) <= 1:
 		h.completeHand(ctx)
+	case h.runItTwicePending():
+		h.countRunItTwiceAnswers(ctx)
+	case h.showOrMuck != nil:
+		if _, ok := h.showOrMuck.cards[userID]; ok {
+			h.decideShowOrMuck(userID, false)
+		}
 	}
 
 	return &stack, nil
 }
 
+func (h *TableHub) handlePlayerRename(userID uuid.UUID, username string) {
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return
+	}
+
+	updated := *player
+	updated.Username = username
+	h.state.Players[player.SeatNumber] = &updated
+
+	h.broadcastTableState()
+}
+
+func (h *TableHub) handleSitOut(userID uuid.UUID) error {
+	if h.director != nil {
+		return domain.ErrTournamentTable
+	}
+
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return domain.ErrPlayerNotFound
+	}
+	if (player.IsSittingOut() && !player.WaitingForBigBlind) || player.SitOutNextHand {
+		return domain.ErrAlreadySittingOut
+	}
+
+	h.sitOut(player)
+	h.broadcastTableState()
+	return nil
+}
+
+func (h *TableHub) handleSitIn(ctx context.Context, userID uuid.UUID, waitForBigBlind bool) error {
+	if h.director != nil {
+		return domain.ErrTournamentTable
+	}
+
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return domain.ErrPlayerNotFound
+	}
+
+	updated := *player
+	updated.Timeouts = 0
+
+	switch {
+	case player.SitOutNextHand:
+		updated.SitOutNextHand = false
+	case player.IsSittingOut():
+		if player.OwesBlinds() && waitForBigBlind {
+			updated.WaitingForBigBlind = true
+		} else {
+			updated.Status = domain.PlayerStatusActive
+			updated.WaitingForBigBlind = false
+		}
+	default:
+		return domain.ErrNotSittingOut
+	}
+
+	h.state.Players[player.SeatNumber] = &updated
+	h.broadcastTableState()
+
+	if h.director == nil && h.state.Hand == nil && h.state.DealablePlayerCount() >= 2 {
+		return h.tryStartHand(ctx)
+	}
+
+	return nil
+}
+
+// sitOut takes a player out at once if they are not in the current hand,
+// and once it is over otherwise.
+func (h *TableHub) sitOut(player *domain.PokerPlayer) {
+	updated := *player
+	if h.inHand(player.ID) {
+		updated.SitOutNextHand = true
+	} else {
+		updated.Status = domain.PlayerStatusSittingOut
+		updated.WaitingForBigBlind = false
+	}
+	h.state.Players[player.SeatNumber] = &updated
+}
+
+func (h *TableHub) inHand(playerID uuid.UUID) bool {
+	if h.state.Hand == nil {
+		return false
+	}
+	for _, bp := range h.state.Hand.Betting.Players {
+		if bp.PlayerID == playerID {
+			return !bp.IsFolded
+		}
+	}
+	return false
+}
+
 func (h *TableHub) handlePlayerAction(ctx context.Context, userID uuid.UUID, action domain.ActionType, amount decimal.Decimal) error {
 	if h.state.Hand == nil {
 		return domain.ErrGameNotStarted
@@ -228,19 +366,38 @@ func (h *TableHub) handlePlayerAction(ctx context.Context, userID uuid.UUID, act
 		return domain.ErrPlayerNotFound
 	}
 
+	if h.runItTwicePending() {
+		return domain.ErrNotPlayerTurn
+	}
+
+	if (action == domain.ActionBet || action == domain.ActionRaise) && !h.state.Table.IsWholeChips(amount) {
+		return domain.ErrInvalidBetAmount
+	}
+
 	newBetting, err := ValidateAction(h.state.Hand.Betting, player.ID, action, amount)
 	if err != nil {
 		return err
 	}
 
+	if action == domain.ActionBet || action == domain.ActionRaise {
+		amount = newBetting.CurrentBet
+	}
+	if newBetting.CurrentBet.GreaterThan(h.state.Hand.Betting.CurrentBet) {
+		h.state.Hand.LastAggressor = player.ID
+	}
+
 	h.state.Hand.Betting = newBetting
 	h.state.Hand.ActionOrder++
+	h.dropStalePreActions()
 
 	for _, bp := range newBetting.Players {
 		for seat, p := range h.state.Players {
 			if p.ID == bp.PlayerID {
 				updated := *p
 				updated.Stack = bp.Stack
+				if p.UserID == userID {
+					updated.Timeouts = 0
+				}
 				if bp.IsFolded {
 					updated.Status = domain.PlayerStatusFolded
 				} else if bp.IsAllIn {
@@ -262,19 +419,26 @@ func (h *TableHub) handlePlayerAction(ctx context.Context, userID uuid.UUID, act
 	}
 
 	if IsBettingComplete(newBetting) {
-		h.advanceStage(ctx)
+		h.engine.advanceStage(ctx)
 		return nil
 	}
 
-	h.resetTimer()
-	h.broadcastTurnChanged()
+	h.startTurn(ctx)
 	return nil
 }
 
 func (h *TableHub) handleTimeout(ctx context.Context) {
+	if h.showOrMuck != nil {
+		h.closeShowOrMuck()
+		return
+	}
 	if h.state.Hand == nil {
 		return
 	}
+	if h.runItTwicePending() {
+		h.decideRunItTwice(ctx, false)
+		return
+	}
 
 	currentIdx := h.state.Hand.Betting.CurrentIdx
 	if currentIdx >= len(h.state.Hand.Betting.Players) {
@@ -282,156 +446,130 @@ func (h *TableHub) handleTimeout(ctx context.Context) {
 	}
 
 	currentPlayer := h.state.Hand.Betting.Players[currentIdx]
-	var userID uuid.UUID
-	for _, p := range h.state.Players {
-		if p.ID == currentPlayer.PlayerID {
-			userID = p.UserID
-			break
-		}
+	player := h.state.FindPlayerByID(currentPlayer.PlayerID)
+	if player == nil {
+		return
 	}
 
-	if err := h.handlePlayerAction(ctx, userID, domain.ActionFold, decimal.Zero); err != nil {
-		h.logger.Error("auto-fold on timeout failed", "error", err)
+	if h.bankPlayerID != player.ID && player.TimeBank > 0 {
+		h.startTimeBank(player)
+		return
 	}
-}
 
-func (h *TableHub) tryStartHand(ctx context.Context) error {
-	if h.state.Hand != nil {
-		return domain.ErrGameAlreadyStarted
-	}
+	userID := player.UserID
+	timeouts := player.Timeouts + 1
 
-	if len(h.state.Players) < 2 {
-		return domain.ErrMinPlayersRequired
+	action := domain.ActionFold
+	if ComputeLegalActions(h.state.Hand.Betting).Allows(domain.ActionCheck) {
+		action = domain.ActionCheck
 	}
 
-	return h.startHand(ctx)
-}
-
-func (h *TableHub) startHand(ctx context.Context) error {
-	serverSeed, err := h.rngSvc.GenerateServerSeed()
-	if err != nil {
-		return fmt.Errorf("generate server seed: %w", err)
+	if err := h.handlePlayerAction(ctx, userID, action, decimal.Zero); err != nil {
+		h.logger.Error("auto-action on timeout failed", "action", action, "error", err)
 	}
 
-	h.state.HandCount++
-	h.state.DealerSeat = h.nextOccupiedSeat(h.state.DealerSeat)
-
-	deck := h.rngSvc.ShuffleDeck(serverSeed, "default", h.state.HandCount)
+	h.recordTimeout(userID, timeouts)
+}
 
-	hand := domain.PokerHand{
-		ID:         uuid.New(),
-		TableID:    h.state.Table.ID,
-		HandNumber: h.state.HandCount,
-		Pot:        decimal.Zero,
-		Stage:      domain.StagePreflop,
-	}
-
-	seats := h.sortedSeats()
-	bettingPlayers := make([]BettingPlayer, 0, len(seats))
-	playerHands := make(map[uuid.UUID][]domain.Card)
-	cumulativeBets := make(map[uuid.UUID]decimal.Decimal)
-	deckIdx := 0
-
-	for _, seat := range seats {
-		p := h.state.Players[seat]
-		holeCards := []domain.Card{deck[deckIdx], deck[deckIdx+1]}
-		deckIdx += 2
-		playerHands[p.ID] = holeCards
-		cumulativeBets[p.ID] = decimal.Zero
-
-		bettingPlayers = append(bettingPlayers, BettingPlayer{
-			PlayerID:     p.ID,
-			Stack:        p.Stack,
-			BetThisRound: decimal.Zero,
-			HasActed:     false,
-			IsAllIn:      false,
-			IsFolded:     false,
-		})
+// recordTimeout sits a player out once they have let the clock run out
+// SitOutAfterTimeouts times in a row. Tournament players are never sat
+// out; they keep being dealt in and blinded away instead.
+func (h *TableHub) recordTimeout(userID uuid.UUID, timeouts int) {
+	player := h.state.FindPlayerByUserID(userID)
+	if player == nil {
+		return
 	}
 
-	betting := NewBettingState(bettingPlayers, h.state.Table.BigBlind, decimal.Zero)
+	updated := *player
+	updated.Timeouts = timeouts
+	h.state.Players[player.SeatNumber] = &updated
 
-	sbIdx, bbIdx := h.blindPositions(seats)
-	betting = h.postBlinds(betting, sbIdx, bbIdx)
-
-	for _, bp := range betting.Players {
-		cumulativeBets[bp.PlayerID] = bp.BetThisRound
+	limit := h.clock.SitOutAfterTimeouts
+	if h.director != nil || limit <= 0 || timeouts < limit || updated.IsSittingOut() || updated.SitOutNextHand {
+		return
 	}
 
-	firstToAct := (bbIdx + 1) % len(bettingPlayers)
-	betting.CurrentIdx = firstToAct
+	h.logger.Info("sitting out player after repeated timeouts", "user_id", userID, "timeouts", timeouts)
+	h.sitOut(&updated)
+	h.broadcastTableState()
+}
 
-	fsm, err := NewGameFSM().Transition(domain.StagePreflop)
-	if err != nil {
-		return fmt.Errorf("FSM transition to preflop: %w", err)
+func (h *TableHub) tryStartHand(ctx context.Context) error {
+	if h.state.Hand != nil {
+		return domain.ErrGameAlreadyStarted
 	}
 
-	h.state.Hand = &HandState{
-		Hand:           hand,
-		FSM:            fsm,
-		Deck:           deck[deckIdx:],
-		CommunityCards: nil,
-		PlayerHands:    playerHands,
-		Betting:        betting,
-		CumulativeBets: cumulativeBets,
-		ServerSeed:     serverSeed,
-		SeedHash:       h.rngSvc.HashSeed(serverSeed),
-		ClientSeed:     "default",
-		Nonce:          h.state.HandCount,
-		ActionOrder:    0,
+	if h.state.DealablePlayerCount() < 2 {
+		return domain.ErrMinPlayersRequired
 	}
 
-	if _, err := h.handRepo.Create(ctx, hand); err != nil {
-		h.logger.Error("failed to persist hand", "error", err)
+	// The next hand is dealt once the losers of the last showdown have
+	// shown or mucked.
+	if h.showOrMuck != nil {
+		return nil
 	}
 
-	for id, cards := range playerHands {
-		var userID uuid.UUID
-		for _, p := range h.state.Players {
-			if p.ID == id {
-				userID = p.UserID
-				break
-			}
-		}
-		h.sendCardsDealt(userID, hand.ID, cards)
+	if h.director != nil {
+		h.applyBlindLevel()
 	}
 
-	h.broadcastNewHand()
-	h.broadcastPotUpdate()
-	h.resetTimer()
-	h.broadcastTurnChanged()
-
-	return nil
+	h.lastHand = nil
+	return h.engine.startHand(ctx)
 }
 
-func (h *TableHub) advanceStage(ctx context.Context) {
-	handState := h.state.Hand
-	nextStage := handState.FSM.NextStage()
+// bankBets adds the bets of the betting round just finished to each
+// player's total for the hand.
+func (h *TableHub) bankBets() {
+	for _, p := range h.state.Hand.Betting.Players {
+		h.state.Hand.CumulativeBets[p.PlayerID] = h.state.Hand.CumulativeBets[p.PlayerID].Add(p.BetThisRound)
+	}
+}
 
-	if nextStage == domain.StageShowdown {
-		h.doShowdown(ctx)
-		return
+// returnUncalledBet gives the part of the biggest bet that nobody matched
+// back to the player who made it, so it is neither won nor raked. Bets
+// must already be banked.
+func (h *TableHub) returnUncalledBet() {
+	hand := h.state.Hand
+	var top, second decimal.Decimal
+	topIdx := -1
+	for i, p := range hand.Betting.Players {
+		bet := hand.CumulativeBets[p.PlayerID]
+		switch {
+		case bet.GreaterThan(top):
+			second = top
+			top = bet
+			topIdx = i
+		case bet.GreaterThan(second):
+			second = bet
+		}
 	}
 
-	newFSM, err := handState.FSM.Transition(nextStage)
-	if err != nil {
-		h.logger.Error("FSM transition failed", "error", err, "to", nextStage)
+	uncalled := top.Sub(second)
+	if topIdx < 0 || !uncalled.IsPositive() {
 		return
 	}
 
-	switch nextStage {
-	case domain.StageFlop:
-		h.dealCommunity(3)
-	case domain.StageTurn, domain.StageRiver:
-		h.dealCommunity(1)
-	}
+	players := copyPlayers(hand.Betting.Players)
+	bp := players[topIdx]
+	bp.Stack = bp.Stack.Add(uncalled)
+	players[topIdx] = bp
+	hand.Betting.Players = players
+	hand.Betting.PotSize = hand.Betting.PotSize.Sub(uncalled)
+	hand.Hand.Pot = hand.Betting.PotSize
+	hand.CumulativeBets[bp.PlayerID] = second
+	h.setStack(bp.PlayerID, bp.Stack)
+}
 
-	for _, p := range handState.Betting.Players {
-		h.state.Hand.CumulativeBets[p.PlayerID] = h.state.Hand.CumulativeBets[p.PlayerID].Add(p.BetThisRound)
-	}
+// openBettingRound banks the last round's bets and starts a new round with
+// the given bet size, with the action on the first player able to act. It
+// reports false if nobody can act, in which case the hand should move on.
+func (h *TableHub) openBettingRound(betSize decimal.Decimal) bool {
+	h.bankBets()
+	h.state.Hand.LastAggressor = uuid.Nil
 
-	newPlayers := make([]BettingPlayer, len(handState.Betting.Players))
-	for i, p := range handState.Betting.Players {
+	prev := h.state.Hand.Betting
+	newPlayers := make([]BettingPlayer, len(prev.Players))
+	for i, p := range prev.Players {
 		newPlayers[i] = BettingPlayer{
 			PlayerID:     p.PlayerID,
 			Stack:        p.Stack,
@@ -442,7 +580,7 @@ func (h *TableHub) advanceStage(ctx context.Context) {
 		}
 	}
 
-	firstActive := 0
+	firstActive := -1
 	for i, p := range newPlayers {
 		if !p.IsFolded && !p.IsAllIn {
 			firstActive = i
@@ -450,71 +588,33 @@ func (h *TableHub) advanceStage(ctx context.Context) {
 		}
 	}
 
-	h.state.Hand.FSM = newFSM
-	h.state.Hand.Hand.Stage = nextStage
 	h.state.Hand.Betting = BettingState{
 		Players:    newPlayers,
 		CurrentBet: decimal.Zero,
-		MinRaise:   h.state.Table.BigBlind,
-		PotSize:    handState.Betting.PotSize,
-		CurrentIdx: firstActive,
-		BigBlind:   h.state.Table.BigBlind,
-	}
-
-	h.broadcastCommunityCards()
-
-	allActed := true
-	for _, p := range newPlayers {
-		if !p.IsFolded && !p.IsAllIn {
-			allActed = false
-			break
-		}
+		MinRaise:   betSize,
+		PotSize:    prev.PotSize,
+		CurrentIdx: max(firstActive, 0),
+		BigBlind:   betSize,
+		Limit:      prev.Limit,
 	}
 
-	if allActed {
-		h.advanceStage(ctx)
-		return
-	}
-
-	h.resetTimer()
-	h.broadcastTurnChanged()
+	return firstActive >= 0
 }
 
-func (h *TableHub) doShowdown(ctx context.Context) {
+// settleShowdown splits the pots between the players still in the hand,
+// pays them and ends the hand. Bets must already be banked.
+func (h *TableHub) settleShowdown(ctx context.Context, variant Variant) {
 	handState := h.state.Hand
-
-	for _, p := range handState.Betting.Players {
-		h.state.Hand.CumulativeBets[p.PlayerID] = h.state.Hand.CumulativeBets[p.PlayerID].Add(p.BetThisRound)
-	}
-
-	for handState.FSM.Stage() != domain.StageRiver && handState.FSM.Stage() != domain.StageShowdown {
-		nextStage := handState.FSM.NextStage()
-		if nextStage == domain.StageShowdown {
-			break
-		}
-		switch nextStage {
-		case domain.StageFlop:
-			h.dealCommunity(3)
-		case domain.StageTurn, domain.StageRiver:
-			h.dealCommunity(1)
-		}
-		newFSM, err := handState.FSM.Transition(nextStage)
-		if err != nil {
-			break
-		}
-		handState.FSM = newFSM
-	}
-
-	newFSM, _ := handState.FSM.Transition(domain.StageShowdown)
-	h.state.Hand.FSM = newFSM
+	h.returnUncalledBet()
 
 	contributions := make([]PotContribution, 0, len(handState.Betting.Players))
 	for _, bp := range handState.Betting.Players {
 		contributions = append(contributions, PotContribution{
-			PlayerID: bp.PlayerID,
-			TotalBet: h.state.Hand.CumulativeBets[bp.PlayerID],
-			IsAllIn:  bp.IsAllIn,
-			IsFolded: bp.IsFolded,
+			PlayerID:  bp.PlayerID,
+			TotalBet:  handState.CumulativeBets[bp.PlayerID],
+			DeadMoney: handState.DeadMoney[bp.PlayerID],
+			IsAllIn:   bp.IsAllIn,
+			IsFolded:  bp.IsFolded,
 		})
 	}
 
@@ -522,29 +622,40 @@ func (h *TableHub) doShowdown(ctx context.Context) {
 	if len(pots) == 0 {
 		pots = []domain.Pot{{Amount: handState.Betting.PotSize, EligibleIDs: h.activePlayerIDs()}}
 	}
+	pots = h.takeRake(pots)
 
 	var handPlayers []HandPlayerCards
-	for playerID, cards := range handState.PlayerHands {
-		isFolded := false
-		for _, bp := range handState.Betting.Players {
-			if bp.PlayerID == playerID {
-				isFolded = bp.IsFolded
-				break
-			}
+	for _, playerID := range h.showdownOrder() {
+		handPlayers = append(handPlayers, HandPlayerCards{
+			PlayerID:  playerID,
+			HoleCards: handState.PlayerHands[playerID],
+		})
+	}
+
+	var result domain.HandResult
+	if handState.SecondBoard != nil {
+		result = DetermineWinnersTwice(handPlayers, handState.CommunityCards, handState.SecondBoard, pots, variant, h.potSplit())
+	} else {
+		result = DetermineWinners(handPlayers, handState.CommunityCards, pots, variant, h.potSplit())
+	}
+	result.Shown = ShowdownHands(handPlayers, handState.CommunityCards, pots, variant, handState.Exposed)
+	if handState.SecondBoard != nil {
+		for i := range result.Shown {
+			result.Shown[i].Run = 1
 		}
-		if !isFolded {
-			handPlayers = append(handPlayers, HandPlayerCards{
-				PlayerID:  playerID,
-				HoleCards: cards,
-			})
+		for _, shown := range ShowdownHands(handPlayers, handState.SecondBoard, pots, variant, true) {
+			shown.Run = 2
+			result.Shown = append(result.Shown, shown)
 		}
 	}
-
-	result := DetermineWinners(handPlayers, handState.CommunityCards, pots)
+	result.ShowdownCards = make(map[uuid.UUID][]domain.Card, len(result.Shown))
+	for _, shown := range result.Shown {
+		result.ShowdownCards[shown.PlayerID] = shown.HoleCards
+	}
+	h.offerShowOrMuck(handPlayers, result.ShowdownCards)
 	result.HandID = handState.Hand.ID
-
-	h.completePayout(ctx, result)
-	h.broadcastHandResult(result)
+	result.Rake = handState.Hand.Rake
+	h.payOut(ctx, result)
 	h.cleanupHand(ctx)
 }
 
@@ -553,38 +664,91 @@ func (h *TableHub) completeHand(ctx context.Context) {
 		return
 	}
 
+	h.bankBets()
+	h.returnUncalledBet()
+
 	winnerIDs := h.activePlayerIDs()
 	if len(winnerIDs) == 1 {
 		winnerID := winnerIDs[0]
+		h.state.Hand.FoldWinner = winnerID
+		pots := h.takeRake([]domain.Pot{{
+			Amount:      h.state.Hand.Betting.PotSize,
+			EligibleIDs: winnerIDs,
+		}})
 		result := domain.HandResult{
 			HandID: h.state.Hand.Hand.ID,
 			Winners: []domain.WinnerInfo{{
 				PlayerID: winnerID,
-				Amount:   h.state.Hand.Betting.PotSize,
+				Amount:   pots[0].Amount,
 				HandRank: "last player standing",
 			}},
-			Pots: []domain.Pot{{
-				Amount:      h.state.Hand.Betting.PotSize,
-				EligibleIDs: winnerIDs,
-			}},
+			Pots: pots,
+			Rake: h.state.Hand.Hand.Rake,
 		}
-
-		h.completePayout(ctx, result)
-		h.broadcastHandResult(result)
+		h.payOut(ctx, result)
 	}
 
 	h.cleanupHand(ctx)
 }
 
+// potSplit orders the players clockwise from the button's left, the order
+// in which the odd chips of a split pot are handed out.
+func (h *TableHub) potSplit() PotSplit {
+	seats := h.state.OccupiedSeats()
+	sort.Ints(seats)
+	start := dealerIndex(seats, nextOccupiedSeat(seats, h.state.DealerSeat))
+
+	order := make([]uuid.UUID, 0, len(seats))
+	for i := range seats {
+		order = append(order, h.state.Players[seats[(start+i)%len(seats)]].ID)
+	}
+	return PotSplit{Denomination: h.state.Table.ChipUnit(), SeatOrder: order}
+}
+
+// payOut pays the winners and the rake. A result that does not add up to
+// the pot is paid nothing, leaving the hand for an operator to settle
+// rather than crediting the wrong amounts.
+func (h *TableHub) payOut(ctx context.Context, result domain.HandResult) {
+	if err := h.checkPayout(result); err != nil {
+		h.logger.Error("CRITICAL: hand payout withheld", "hand_id", result.HandID, "error", err)
+		return
+	}
+
+	h.state.Hand.Hand.Winners = result.Winners
+	h.completePayout(ctx, result)
+	h.creditRake(ctx)
+	h.broadcastHandResult(result)
+}
+
+// checkPayout confirms that what the hand pays out, rake included, is
+// exactly what went into the pot.
+func (h *TableHub) checkPayout(result domain.HandResult) error {
+	collected := h.state.Hand.Betting.PotSize
+	paid := result.Rake
+	for _, w := range result.Winners {
+		paid = paid.Add(w.Amount)
+	}
+
+	if !paid.Equal(collected) {
+		return fmt.Errorf("%w: collected %s, paid %s", domain.ErrPayoutMismatch, collected, paid)
+	}
+	return nil
+}
+
+// completePayout adds each winner's share to their stack. At cash tables
+// it is deposited to their wallet as well; tournament chips stay on the
+// table.
 func (h *TableHub) completePayout(ctx context.Context, result domain.HandResult) {
 	for _, winner := range result.Winners {
 		for seat, p := range h.state.Players {
 			if p.ID == winner.PlayerID {
-				if _, err := h.walletSvc.Deposit(ctx, p.UserID, winner.Amount.String()); err != nil {
-					h.logger.Error("CRITICAL: payout deposit failed",
-						"user_id", p.UserID, "amount", winner.Amount,
-						"hand_id", result.HandID, "error", err)
-					continue
+				if h.director == nil {
+					if _, err := h.walletSvc.Deposit(ctx, p.UserID, winner.Amount.String()); err != nil {
+						h.logger.Error("CRITICAL: payout deposit failed",
+							"user_id", p.UserID, "amount", winner.Amount,
+							"hand_id", result.HandID, "error", err)
+						continue
+					}
 				}
 
 				updated := *p
@@ -600,6 +764,11 @@ func (h *TableHub) completePayout(ctx context.Context, result domain.HandResult)
 func (h *TableHub) cleanupHand(ctx context.Context) {
 	h.stopTimer()
 
+	var startStacks map[uuid.UUID]decimal.Decimal
+	if h.director != nil {
+		startStacks = h.handStartStacks()
+	}
+
 	if h.state.Hand != nil {
 		now := time.Now()
 		h.state.Hand.Hand.Stage = domain.StageComplete
@@ -610,114 +779,111 @@ func (h *TableHub) cleanupHand(ctx context.Context) {
 		}
 	}
 
+	h.lastHand = h.state.Hand
 	h.state.Hand = nil
 
+	var busted []domain.PokerPlayer
 	for seat, p := range h.state.Players {
 		if p.Stack.IsZero() {
+			busted = append(busted, *p)
 			delete(h.state.Players, seat)
 			continue
 		}
 		updated := *p
-		updated.Status = domain.PlayerStatusActive
+		switch {
+		case p.SitOutNextHand:
+			updated.Status = domain.PlayerStatusSittingOut
+			updated.SitOutNextHand = false
+		case p.IsSittingOut():
+		default:
+			updated.Status = domain.PlayerStatusActive
+		}
 		h.state.Players[seat] = &updated
 	}
 
 	h.broadcastTableState()
 
-	if len(h.state.Players) >= 2 {
-		time.AfterFunc(3*time.Second, func() {
-			h.Send(HubEvent{Type: EventStartHand})
-		})
+	if h.showOrMuck != nil {
+		h.showOrMuck.busted = busted
+		h.showOrMuck.startStacks = startStacks
+		h.askShowOrMuck()
+		return
 	}
+	h.handOver(busted, startStacks)
 }
 
-func (h *TableHub) dealCommunity(count int) {
-	if h.state.Hand == nil || len(h.state.Hand.Deck) < count {
+// handOver reports a tournament hand to the director, or at a cash table
+// deals the next hand after a pause.
+func (h *TableHub) handOver(busted []domain.PokerPlayer, startStacks map[uuid.UUID]decimal.Decimal) {
+	if h.director != nil {
+		h.director.handFinished(h.handReport(busted, startStacks))
 		return
 	}
 
-	cards := h.state.Hand.Deck[:count]
-	h.state.Hand.Deck = h.state.Hand.Deck[count:]
-	h.state.Hand.CommunityCards = append(h.state.Hand.CommunityCards, cards...)
-	h.state.Hand.Hand.CommunityCards = domain.CardsToString(h.state.Hand.CommunityCards)
+	if h.state.DealablePlayerCount() >= 2 {
+		time.AfterFunc(3*time.Second, func() {
+			h.Send(HubEvent{Type: EventStartHand})
+		})
+	}
 }
 
-func (h *TableHub) postBlinds(betting BettingState, sbIdx, bbIdx int) BettingState {
-	sb := h.state.Table.SmallBlind
-	bb := h.state.Table.BigBlind
+// postLive puts up to amount in front of a player as a live bet.
+func (h *TableHub) postLive(betting BettingState, idx int, amount decimal.Decimal) BettingState {
+	bp := betting.Players[idx]
+	paid := decimal.Min(amount, bp.Stack)
+	bp.Stack = bp.Stack.Sub(paid)
+	bp.BetThisRound = bp.BetThisRound.Add(paid)
+	bp.IsAllIn = bp.Stack.IsZero()
+	betting.Players[idx] = bp
 
-	sbPlayer := betting.Players[sbIdx]
-	sbAmount := decimal.Min(sb, sbPlayer.Stack)
-	betting.Players[sbIdx] = BettingPlayer{
-		PlayerID:     sbPlayer.PlayerID,
-		Stack:        sbPlayer.Stack.Sub(sbAmount),
-		BetThisRound: sbAmount,
-		HasActed:     false,
-		IsAllIn:      sbAmount.Equal(sbPlayer.Stack),
-		IsFolded:     false,
+	betting.PotSize = betting.PotSize.Add(paid)
+	if bp.BetThisRound.GreaterThan(betting.CurrentBet) {
+		betting.CurrentBet = bp.BetThisRound
 	}
 
-	bbPlayer := betting.Players[bbIdx]
-	bbAmount := decimal.Min(bb, bbPlayer.Stack)
-	betting.Players[bbIdx] = BettingPlayer{
-		PlayerID:     bbPlayer.PlayerID,
-		Stack:        bbPlayer.Stack.Sub(bbAmount),
-		BetThisRound: bbAmount,
-		HasActed:     false,
-		IsAllIn:      bbAmount.Equal(bbPlayer.Stack),
-		IsFolded:     false,
-	}
+	h.setStack(bp.PlayerID, bp.Stack)
+	return betting
+}
 
-	betting.CurrentBet = bbAmount
-	betting.PotSize = sbAmount.Add(bbAmount)
+// postDead puts up to amount from a player straight into the pot, where it
+// does not count towards their bet.
+func (h *TableHub) postDead(betting BettingState, idx int, amount decimal.Decimal, dead map[uuid.UUID]decimal.Decimal) BettingState {
+	bp := betting.Players[idx]
+	paid := decimal.Min(amount, bp.Stack)
+	bp.Stack = bp.Stack.Sub(paid)
+	bp.IsAllIn = bp.Stack.IsZero()
+	betting.Players[idx] = bp
 
-	for seat, p := range h.state.Players {
-		if p.ID == sbPlayer.PlayerID {
-			updated := *p
-			updated.Stack = sbPlayer.Stack.Sub(sbAmount)
-			h.state.Players[seat] = &updated
-		}
-		if p.ID == bbPlayer.PlayerID {
-			updated := *p
-			updated.Stack = bbPlayer.Stack.Sub(bbAmount)
-			h.state.Players[seat] = &updated
-		}
-	}
+	betting.PotSize = betting.PotSize.Add(paid)
+	dead[bp.PlayerID] = dead[bp.PlayerID].Add(paid)
 
+	h.setStack(bp.PlayerID, bp.Stack)
 	return betting
 }
 
-func (h *TableHub) blindPositions(seats []int) (sbIdx, bbIdx int) {
-	n := len(seats)
-	if n == 2 {
-		dealerSeatIdx := 0
-		for i, s := range seats {
-			if s == h.state.DealerSeat {
-				dealerSeatIdx = i
-				break
-			}
-		}
-		return dealerSeatIdx, (dealerSeatIdx + 1) % n
+func (h *TableHub) setStack(playerID uuid.UUID, stack decimal.Decimal) {
+	p := h.state.FindPlayerByID(playerID)
+	if p == nil {
+		return
 	}
+	updated := *p
+	updated.Stack = stack
+	h.state.Players[p.SeatNumber] = &updated
+}
 
-	dealerSeatIdx := 0
+// dealerIndex is the dealer's index in seats, or 0 if they are not dealt in.
+func dealerIndex(seats []int, dealerSeat int) int {
 	for i, s := range seats {
-		if s == h.state.DealerSeat {
-			dealerSeatIdx = i
-			break
+		if s == dealerSeat {
+			return i
 		}
 	}
-	return (dealerSeatIdx + 1) % n, (dealerSeatIdx + 2) % n
-}
-
-func (h *TableHub) sortedSeats() []int {
-	seats := h.state.OccupiedSeats()
-	sort.Ints(seats)
-	return seats
+	return 0
 }
 
-func (h *TableHub) nextOccupiedSeat(current int) int {
-	seats := h.sortedSeats()
+// nextOccupiedSeat returns the first of the sorted seats after current,
+// wrapping around the table.
+func nextOccupiedSeat(seats []int, current int) int {
 	if len(seats) == 0 {
 		return 0
 	}
@@ -746,7 +912,7 @@ func (h *TableHub) activePlayerIDs() []uuid.UUID {
 
 func (h *TableHub) resetTimer() {
 	h.stopTimer()
-	h.turnTimer = time.NewTimer(h.turnTimeout)
+	h.turnTimer = time.NewTimer(h.clock.TurnTimeout)
 }
 
 func (h *TableHub) stopTimer() {
@@ -754,6 +920,43 @@ func (h *TableHub) stopTimer() {
 		h.turnTimer.Stop()
 		h.turnTimer = nil
 	}
+	h.chargeTimeBank()
+}
+
+// startTimeBank gives the player to act the rest of their bank once the
+// base timer has run out.
+func (h *TableHub) startTimeBank(player *domain.PokerPlayer) {
+	h.stopTimer()
+	h.bankPlayerID = player.ID
+	h.bankStartedAt = time.Now()
+	h.turnTimer = time.NewTimer(player.TimeBank)
+	h.broadcastTimeBankStarted(player.UserID, player.TimeBank)
+}
+
+// chargeTimeBank deducts the time used from the bank being drawn on, if any.
+func (h *TableHub) chargeTimeBank() {
+	if h.bankPlayerID == uuid.Nil {
+		return
+	}
+
+	if p := h.state.FindPlayerByID(h.bankPlayerID); p != nil {
+		updated := *p
+		updated.TimeBank = max(p.TimeBank-time.Since(h.bankStartedAt), 0)
+		h.state.Players[p.SeatNumber] = &updated
+	}
+	h.bankPlayerID = uuid.Nil
+}
+
+func (h *TableHub) refillTimeBanks() {
+	for seat, p := range h.state.Players {
+		bank := h.clock.refillTimeBank(p.TimeBank, h.state.HandCount)
+		if bank == p.TimeBank {
+			continue
+		}
+		updated := *p
+		updated.TimeBank = bank
+		h.state.Players[seat] = &updated
+	}
 }
 
 func (h *TableHub) broadcastTableState() {
@@ -771,18 +974,6 @@ func (h *TableHub) broadcastPlayerActed(userID uuid.UUID, action domain.ActionTy
 	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
 }
 
-func (h *TableHub) broadcastCommunityCards() {
-	if h.state.Hand == nil {
-		return
-	}
-	payload := map[string]any{
-		"cards": h.state.Hand.CommunityCards,
-		"stage": h.state.Hand.FSM.Stage(),
-	}
-	msg := h.buildMessage(domain.WSMsgCommunity, payload)
-	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
-}
-
 func (h *TableHub) broadcastHandResult(result domain.HandResult) {
 	msg := h.buildMessage(domain.WSMsgHandResult, result)
 	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
@@ -813,18 +1004,35 @@ func (h *TableHub) broadcastTurnChanged() {
 	currentPlayer := h.state.Hand.Betting.Players[idx]
 
 	var userID uuid.UUID
+	var timeBank time.Duration
 	for _, p := range h.state.Players {
 		if p.ID == currentPlayer.PlayerID {
 			userID = p.UserID
+			timeBank = p.TimeBank
 			break
 		}
 	}
 
-	payload := map[string]any{
-		"user_id": userID,
-		"timeout": h.turnTimeout.Seconds(),
+	payload := domain.WSTurnChanged{
+		UserID:   userID,
+		Timeout:  h.clock.TurnTimeout.Seconds(),
+		TimeBank: timeBank.Seconds(),
 	}
 	msg := h.buildMessage(domain.WSMsgTurnChanged, payload)
+	h.broadcaster.BroadcastToTableExcept(h.state.Table.ID, userID, msg)
+
+	legal := ComputeLegalActions(h.state.Hand.Betting)
+	payload.LegalActions = &legal
+	msg = h.buildMessage(domain.WSMsgTurnChanged, payload)
+	h.broadcaster.SendToPlayer(h.state.Table.ID, userID, msg)
+}
+
+func (h *TableHub) broadcastTimeBankStarted(userID uuid.UUID, bank time.Duration) {
+	payload := map[string]any{
+		"user_id":   userID,
+		"time_bank": bank.Seconds(),
+	}
+	msg := h.buildMessage(domain.WSMsgTimeBank, payload)
 	h.broadcaster.BroadcastToTable(h.state.Table.ID, msg)
 }
 
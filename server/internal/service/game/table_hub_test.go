@@ -0,0 +1,114 @@
+package game
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestReturnUncalledBet(t *testing.T) {
+	tests := []struct {
+		name     string
+		bets     []int64
+		folded   []bool
+		returned []int64
+	}{
+		{
+			name:     "called bet stays",
+			bets:     []int64{100, 100},
+			returned: []int64{0, 0},
+		},
+		{
+			name:     "short all-in call",
+			bets:     []int64{100, 60},
+			returned: []int64{40, 0},
+		},
+		{
+			name:     "largest of three",
+			bets:     []int64{100, 100, 300},
+			returned: []int64{0, 0, 200},
+		},
+		{
+			name:     "bet everyone folded to",
+			bets:     []int64{50, 200, 30},
+			folded:   []bool{true, false, true},
+			returned: []int64{0, 150, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dec := decimal.NewFromInt
+			players := make([]BettingPlayer, len(tt.bets))
+			bets := make(map[uuid.UUID]decimal.Decimal)
+			pot := decimal.Zero
+			for i, bet := range tt.bets {
+				players[i] = BettingPlayer{PlayerID: uuid.New(), Stack: dec(1000)}
+				if tt.folded != nil {
+					players[i].IsFolded = tt.folded[i]
+				}
+				bets[players[i].PlayerID] = dec(bet)
+				pot = pot.Add(dec(bet))
+			}
+
+			h := &TableHub{state: TableState{
+				Players: make(map[int]*domain.PokerPlayer),
+				Hand: &HandState{
+					Betting:        BettingState{Players: players, PotSize: pot},
+					CumulativeBets: bets,
+				},
+			}}
+			h.returnUncalledBet()
+
+			returned := decimal.Zero
+			for i, p := range h.state.Hand.Betting.Players {
+				want := dec(tt.returned[i])
+				if got := p.Stack.Sub(dec(1000)); !got.Equal(want) {
+					t.Errorf("player %d got back %s, want %s", i, got, want)
+				}
+				if got := h.state.Hand.CumulativeBets[p.PlayerID]; !got.Equal(dec(tt.bets[i]).Sub(want)) {
+					t.Errorf("player %d bet = %s, want %s", i, got, dec(tt.bets[i]).Sub(want))
+				}
+				returned = returned.Add(want)
+			}
+			if want := pot.Sub(returned); !h.state.Hand.Betting.PotSize.Equal(want) {
+				t.Errorf("pot = %s, want %s", h.state.Hand.Betting.PotSize, want)
+			}
+		})
+	}
+}
+
+func TestCheckPayout(t *testing.T) {
+	h := &TableHub{state: TableState{Hand: &HandState{
+		Betting: BettingState{PotSize: decimal.NewFromInt(200)},
+	}}}
+
+	tests := []struct {
+		name    string
+		rake    int64
+		amounts []int64
+		wantErr bool
+	}{
+		{name: "one winner", amounts: []int64{200}},
+		{name: "split with rake", rake: 10, amounts: []int64{95, 95}},
+		{name: "chips missing", rake: 10, amounts: []int64{95, 90}, wantErr: true},
+		{name: "chips added", amounts: []int64{150, 100}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := domain.HandResult{Rake: decimal.NewFromInt(tt.rake)}
+			for _, amount := range tt.amounts {
+				result.Winners = append(result.Winners, domain.WinnerInfo{PlayerID: uuid.New(), Amount: decimal.NewFromInt(amount)})
+			}
+
+			err := h.checkPayout(result)
+			if got := errors.Is(err, domain.ErrPayoutMismatch); got != tt.wantErr {
+				t.Errorf("checkPayout() = %v, want mismatch %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -12,6 +12,10 @@ type TableState struct {
 	Hand       *HandState
 	DealerSeat int
 	HandCount  int
+	// Blind seats of the last hand, used to work out which sitting-out
+	// players the blinds passed over. Zero before the first hand.
+	SmallBlindSeat int
+	BigBlindSeat   int
 }
 
 type HandState struct {
@@ -20,14 +24,38 @@ type HandState struct {
 	Deck           []domain.Card
 	CommunityCards []domain.Card
 	PlayerHands    map[uuid.UUID][]domain.Card
+	// UpCards are the face-up cards of stud games, also held in
+	// PlayerHands.
+	UpCards        map[uuid.UUID][]domain.Card
 	Betting        BettingState
 	CumulativeBets map[uuid.UUID]decimal.Decimal
+	DeadMoney      map[uuid.UUID]decimal.Decimal
 	Pots           []domain.Pot
 	ServerSeed     string
 	SeedHash       string
 	ClientSeed     string
 	Nonce          int
 	ActionOrder    int
+	// PreActions are queued by players waiting for their turn. Each is
+	// only ever shown to its own player.
+	PreActions map[uuid.UUID]domain.PreAction
+	// BurnCards are dealt face down before each street, in order.
+	BurnCards []domain.Card
+
+	// RunItTwice is the offer made when everyone is all-in before the
+	// river, and SecondBoard the second runout if it was accepted.
+	RunItTwice  *RunItTwice
+	SecondBoard []domain.Card
+
+	// LastAggressor made the last bet or raise of the current street and
+	// shows first. Exposed is set once the hands have been turned face up
+	// for an all-in runout.
+	LastAggressor uuid.UUID
+	Exposed       bool
+
+	// FoldWinner won the hand when everyone else folded, and may show
+	// their cards until the next hand is dealt.
+	FoldWinner uuid.UUID
 }
 
 func NewTableState(table domain.PokerTable) TableState {
@@ -49,6 +77,17 @@ func (ts TableState) ActivePlayerCount() int {
 	return count
 }
 
+// DealablePlayerCount counts the players who can be dealt into the next hand.
+func (ts TableState) DealablePlayerCount() int {
+	count := 0
+	for _, p := range ts.Players {
+		if !p.IsSittingOut() || p.WaitingForBigBlind {
+			count++
+		}
+	}
+	return count
+}
+
 func (ts TableState) PlayerList() []domain.PokerPlayer {
 	players := make([]domain.PokerPlayer, 0, len(ts.Players))
 	for _, p := range ts.Players {
@@ -74,6 +113,15 @@ func (ts TableState) FindPlayerByUserID(userID uuid.UUID) *domain.PokerPlayer {
 	return nil
 }
 
+func (ts TableState) FindPlayerByID(playerID uuid.UUID) *domain.PokerPlayer {
+	for _, p := range ts.Players {
+		if p.ID == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
 func (ts TableState) OccupiedSeats() []int {
 	seats := make([]int, 0, len(ts.Players))
 	for seat := range ts.Players {
@@ -96,7 +144,9 @@ func (ts TableState) ToWSTableState() domain.WSTableState {
 	players := make([]domain.WSPlayerInfo, 0, len(ts.Players))
 	for _, p := range ts.Players {
 		betAmount := decimal.Zero
+		var upCards []domain.Card
 		if ts.Hand != nil {
+			upCards = ts.Hand.UpCards[p.ID]
 			for _, bp := range ts.Hand.Betting.Players {
 				if bp.PlayerID == p.ID {
 					betAmount = bp.BetThisRound
@@ -113,18 +163,33 @@ func (ts TableState) ToWSTableState() domain.WSTableState {
 			Status:     p.Status,
 			BetAmount:  betAmount,
 			IsDealer:   p.SeatNumber == ts.DealerSeat,
+			TimeBank:   p.TimeBank.Seconds(),
+
+			MissedSmallBlind:   p.MissedSmallBlind,
+			MissedBigBlind:     p.MissedBigBlind,
+			WaitingForBigBlind: p.WaitingForBigBlind,
+
+			UpCards: upCards,
 		})
 	}
 
 	return domain.WSTableState{
 		TableID:        ts.Table.ID,
 		Name:           ts.Table.Name,
+		GameType:       ts.Table.GameType,
+		BettingLimit:   ts.Table.Limit,
 		SmallBlind:     ts.Table.SmallBlind,
 		BigBlind:       ts.Table.BigBlind,
+		Ante:           ts.Table.Ante,
+		AnteType:       ts.Table.AnteType,
+		Straddle:       ts.Table.Straddle,
+		BlindType:      ts.Table.BlindType,
 		Pot:            pot,
 		CommunityCards: communityCards,
 		Stage:          stage,
 		DealerSeat:     ts.DealerSeat,
 		Players:        players,
+
+		TournamentID: ts.Table.TournamentID,
 	}
 }
@@ -0,0 +1,761 @@
+package game
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+	"github.com/shopspring/decimal"
+)
+
+// tournamentCloseDelay keeps a finished tournament's tables open long
+// enough for players to see the last hand.
+const tournamentCloseDelay = 30 * time.Second
+
+// TournamentDirector runs a tournament over one or more table hubs. It
+// keeps the blind clock, seats late entrants, and between hands moves
+// players from table to table so that no table has two more players than
+// another, breaking tables as the field shrinks until everyone left is at
+// the final table. On the money bubble the tables play hand-for-hand: each
+// waits for the others to finish their hand before the next is dealt.
+//
+// Hubs report to the director from their own goroutines. The director
+// never sends to a hub or calls its host while holding its lock: it queues
+// them and acts once it lets go, sending to hubs from a goroutine of its
+// own, so a hub with a full queue cannot block it or the hub reporting.
+type TournamentDirector struct {
+	mu          sync.Mutex
+	ctx         context.Context
+	t           domain.Tournament
+	host        ports.TournamentHost
+	hubs        *HubManager
+	broadcaster ports.Broadcaster
+	logger      *slog.Logger
+
+	tables     map[uuid.UUID]*directedTable
+	players    map[uuid.UUID]*directedPlayer
+	eliminated []domain.TournamentStanding
+	entries    int
+
+	level     int
+	levelEnds time.Time
+	timer     *time.Timer
+	onBreak   bool
+
+	handForHand bool
+	// pendingBusts are the players out in the current hand-for-hand round,
+	// ranked together once every table has played it.
+	pendingBusts []bustedPlayer
+	finished     bool
+
+	// hubEvents and hostCalls are queued under the lock and carried out by
+	// unlock. delivered is closed once the last batch of events is sent,
+	// so that batches reach each hub in order.
+	hubEvents []queuedEvent
+	hostCalls []func()
+	delivered chan struct{}
+}
+
+type queuedEvent struct {
+	hub   *TableHub
+	event HubEvent
+}
+
+type directedTable struct {
+	id         uuid.UUID
+	hub        *TableHub
+	seats      map[int]uuid.UUID
+	dealerSeat int
+	// idle tables are between hands, waiting for the director to deal.
+	idle bool
+}
+
+type directedPlayer struct {
+	id       uuid.UUID
+	userID   uuid.UUID
+	username string
+	stack    decimal.Decimal
+	tableID  uuid.UUID
+	seat     int
+}
+
+// handReport is what a tournament table tells its director after each
+// hand. Stacks are keyed by user ID.
+type handReport struct {
+	tableID    uuid.UUID
+	stacks     map[uuid.UUID]decimal.Decimal
+	busted     []bustedPlayer
+	dealerSeat int
+}
+
+type bustedPlayer struct {
+	userID     uuid.UUID
+	username   string
+	tableID    uuid.UUID
+	startStack decimal.Decimal
+}
+
+func NewTournamentDirector(t domain.Tournament, host ports.TournamentHost, hubs *HubManager) *TournamentDirector {
+	ctx := hubs.baseCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &TournamentDirector{
+		ctx:         ctx,
+		t:           t,
+		host:        host,
+		hubs:        hubs,
+		broadcaster: hubs.broadcaster,
+		logger:      hubs.logger.With("tournament_id", t.ID),
+		tables:      make(map[uuid.UUID]*directedTable),
+		players:     make(map[uuid.UUID]*directedPlayer),
+	}
+}
+
+// Start seats players round the tables in the order given, starts the
+// blind clock and deals the first hands.
+func (d *TournamentDirector) Start(tables []domain.PokerTable, players []domain.PokerPlayer) {
+	d.mu.Lock()
+	defer d.unlock()
+
+	seated := make(map[uuid.UUID][]domain.PokerPlayer, len(tables))
+	for i, p := range players {
+		tableID := tables[i%len(tables)].ID
+		p.SeatNumber = len(seated[tableID]) + 1
+		seated[tableID] = append(seated[tableID], p)
+	}
+
+	for _, table := range tables {
+		t := &directedTable{
+			id:    table.ID,
+			hub:   d.hubs.openTournamentTable(d.ctx, table, d, seated[table.ID]),
+			seats: make(map[int]uuid.UUID),
+			idle:  true,
+		}
+		d.tables[table.ID] = t
+		for _, p := range seated[table.ID] {
+			t.seats[p.SeatNumber] = p.UserID
+			d.players[p.UserID] = &directedPlayer{
+				id:       p.ID,
+				userID:   p.UserID,
+				username: p.Username,
+				stack:    p.Stack,
+				tableID:  table.ID,
+				seat:     p.SeatNumber,
+			}
+		}
+	}
+	d.entries = len(players)
+
+	d.scheduleLevel()
+	d.updateBubble()
+	d.dealIdleTables()
+	d.broadcastStandings()
+}
+
+// AddPlayer seats a late entrant or re-entry at the table with the fewest
+// players, opening a new table if every table is full. The table is opened
+// without the lock, so the entry is checked again once it is.
+func (d *TournamentDirector) AddPlayer(p domain.PokerPlayer) error {
+	var opened *domain.PokerTable
+	for {
+		d.mu.Lock()
+		if err := d.canEnter(p.UserID); err != nil {
+			d.discardTable(opened)
+			d.unlock()
+			return err
+		}
+
+		t := d.shortestTable(nil)
+		if t == nil && opened != nil {
+			t = d.addTable(*opened)
+			opened = nil
+		}
+		if t != nil {
+			d.discardTable(opened)
+			err := d.seatEntrant(p, t)
+			d.unlock()
+			return err
+		}
+		d.mu.Unlock()
+
+		table, err := d.host.OpenTable(d.ctx, d.t.ID)
+		if err != nil {
+			return err
+		}
+		opened = &table
+	}
+}
+
+func (d *TournamentDirector) canEnter(userID uuid.UUID) error {
+	if !d.lateRegistrationOpen() {
+		return domain.ErrRegistrationClosed
+	}
+	if _, ok := d.players[userID]; ok {
+		return domain.ErrAlreadyRegistered
+	}
+	return nil
+}
+
+func (d *TournamentDirector) addTable(table domain.PokerTable) *directedTable {
+	t := &directedTable{
+		id:    table.ID,
+		hub:   d.hubs.openTournamentTable(d.ctx, table, d, nil),
+		seats: make(map[int]uuid.UUID),
+		idle:  true,
+	}
+	d.tables[table.ID] = t
+	return t
+}
+
+// discardTable closes a table opened for an entrant who was seated
+// elsewhere, or not at all, by the time it was ready.
+func (d *TournamentDirector) discardTable(table *domain.PokerTable) {
+	if table == nil {
+		return
+	}
+	d.callHost(func() {
+		if err := d.host.CloseTable(d.ctx, table.ID); err != nil {
+			d.logger.Error("failed to close tournament table", "table_id", table.ID, "error", err)
+		}
+	})
+}
+
+func (d *TournamentDirector) seatEntrant(p domain.PokerPlayer, t *directedTable) error {
+	seat, ok := d.freeSeat(t)
+	if !ok {
+		return domain.ErrTableFull
+	}
+	t.seats[seat] = p.UserID
+	d.players[p.UserID] = &directedPlayer{
+		id:       p.ID,
+		userID:   p.UserID,
+		username: p.Username,
+		stack:    p.Stack,
+		tableID:  t.id,
+		seat:     seat,
+	}
+	d.entries++
+
+	d.send(t.hub, HubEvent{
+		Type:     EventPlayerJoin,
+		UserID:   p.UserID,
+		PlayerID: p.ID,
+		SeatNum:  seat,
+		BuyIn:    p.Stack,
+		Username: p.Username,
+	})
+
+	d.dealIdleTables()
+	d.broadcastStandings()
+	return nil
+}
+
+// LateRegistrationOpen reports whether players may still enter or
+// re-enter.
+func (d *TournamentDirector) LateRegistrationOpen() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lateRegistrationOpen()
+}
+
+func (d *TournamentDirector) lateRegistrationOpen() bool {
+	return !d.finished && d.level < d.t.LateRegLevels
+}
+
+func (d *TournamentDirector) Standings() domain.TournamentStandings {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.standings()
+}
+
+// blinds are the blinds and ante for hands dealt now.
+func (d *TournamentDirector) blinds() domain.BlindLevel {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.t.Levels[d.level]
+}
+
+// handFinished is called by a table between hands. Players who busted are
+// eliminated, the tables are rebalanced, and the next hands are dealt.
+func (d *TournamentDirector) handFinished(report handReport) {
+	d.mu.Lock()
+	defer d.unlock()
+
+	t, ok := d.tables[report.tableID]
+	if !ok || d.finished {
+		return
+	}
+	t.idle = true
+	t.dealerSeat = report.dealerSeat
+
+	for userID, stack := range report.stacks {
+		if p := d.players[userID]; p != nil && p.tableID == t.id {
+			p.stack = stack
+		}
+	}
+	for _, b := range report.busted {
+		if p := d.players[b.userID]; p != nil {
+			delete(t.seats, p.seat)
+			delete(d.players, b.userID)
+		}
+	}
+
+	if d.handForHand {
+		d.pendingBusts = append(d.pendingBusts, report.busted...)
+	} else {
+		d.eliminate(report.busted)
+	}
+
+	d.rebalance(t)
+
+	if d.handForHand {
+		if !d.allIdle() {
+			return
+		}
+		d.eliminate(d.pendingBusts)
+		d.pendingBusts = nil
+	}
+
+	if len(d.players) <= 1 {
+		d.finish()
+		return
+	}
+
+	d.updateBubble()
+	d.dealIdleTables()
+	d.broadcastStandings()
+}
+
+// eliminate gives players who busted together their finishing positions,
+// those who started the hand with fewer chips finishing lower.
+func (d *TournamentDirector) eliminate(busted []bustedPlayer) {
+	sort.SliceStable(busted, func(i, j int) bool {
+		return busted[i].startStack.LessThan(busted[j].startStack)
+	})
+
+	for i, b := range busted {
+		position := len(d.players) + len(busted) - i
+		d.callHost(func() {
+			if err := d.host.PlayerEliminated(d.ctx, d.t.ID, b.userID, position); err != nil {
+				d.logger.Error("failed to record tournament elimination",
+					"user_id", b.userID, "position", position, "error", err)
+			}
+		})
+		d.eliminated = append(d.eliminated, domain.TournamentStanding{
+			UserID:   b.userID,
+			Username: b.username,
+			Position: position,
+		})
+
+		msg := d.message(domain.WSMsgEliminated, domain.WSEliminated{UserID: b.userID, Position: position})
+		d.broadcaster.BroadcastToTable(b.tableID, msg)
+		d.broadcaster.BroadcastToTable(d.t.ID, msg)
+	}
+}
+
+// rebalance runs between hands at table t. Tables left empty are closed.
+// If the field now fits on fewer tables, t is broken up and its players
+// spread over the others; otherwise players move from t to the shortest
+// table until t has at most one more player than it.
+func (d *TournamentDirector) rebalance(t *directedTable) {
+	for _, other := range d.tables {
+		if other.idle && len(other.seats) == 0 && len(d.tables) > 1 {
+			d.closeTable(other)
+		}
+	}
+	if _, ok := d.tables[t.id]; !ok || len(d.tables) == 1 {
+		return
+	}
+
+	size := d.t.TableSize
+	needed := (len(d.players) + size - 1) / size
+	if len(d.tables) > needed {
+		for _, seat := range sortedSeats(t.seats) {
+			if !d.move(t.seats[seat], t, d.shortestTable(t)) {
+				return
+			}
+		}
+		d.closeTable(t)
+		return
+	}
+
+	for {
+		target := d.shortestTable(t)
+		if target == nil || len(t.seats) <= len(target.seats)+1 {
+			return
+		}
+		if !d.move(d.nextBigBlind(t), t, target) {
+			return
+		}
+	}
+}
+
+// move takes a player from an idle table to a free seat at another. They
+// are dealt in there from its next hand. It reports false, leaving them
+// where they are, if there is no seat to move them to.
+func (d *TournamentDirector) move(userID uuid.UUID, from, to *directedTable) bool {
+	p := d.players[userID]
+	if to == nil {
+		d.logger.Error("no table to move tournament player to", "user_id", userID, "from", from.id)
+		return false
+	}
+	seat, ok := d.freeSeat(to)
+	if !ok {
+		d.logger.Error("no free seat to move tournament player to", "user_id", userID, "from", from.id, "to", to.id)
+		return false
+	}
+
+	delete(from.seats, p.seat)
+	to.seats[seat] = userID
+	p.tableID = to.id
+	p.seat = seat
+
+	d.send(from.hub, HubEvent{Type: EventPlayerLeave, UserID: userID})
+	d.send(to.hub, HubEvent{
+		Type:     EventPlayerJoin,
+		UserID:   userID,
+		PlayerID: p.id,
+		SeatNum:  seat,
+		BuyIn:    p.stack,
+		Username: p.username,
+	})
+
+	msg := d.message(domain.WSMsgTableMoved, domain.WSTableMoved{
+		TournamentID: d.t.ID,
+		TableID:      to.id,
+		SeatNumber:   seat,
+	})
+	d.broadcaster.SendToPlayer(from.id, userID, msg)
+	d.broadcaster.SendToPlayer(d.t.ID, userID, msg)
+	return true
+}
+
+func (d *TournamentDirector) closeTable(t *directedTable) {
+	delete(d.tables, t.id)
+	d.send(t.hub, HubEvent{Type: EventShutdown})
+	d.callHost(func() {
+		if err := d.host.CloseTable(d.ctx, t.id); err != nil {
+			d.logger.Error("failed to close tournament table", "table_id", t.id, "error", err)
+		}
+	})
+}
+
+// shortestTable is the table other than exclude with the fewest players
+// and a free seat, or nil if there is none.
+func (d *TournamentDirector) shortestTable(exclude *directedTable) *directedTable {
+	var shortest *directedTable
+	for _, t := range d.tables {
+		if t == exclude || len(t.seats) >= d.t.TableSize {
+			continue
+		}
+		if shortest == nil || len(t.seats) < len(shortest.seats) {
+			shortest = t
+		}
+	}
+	return shortest
+}
+
+// freeSeat picks one of t's empty seats at random, reporting false if
+// there is none.
+func (d *TournamentDirector) freeSeat(t *directedTable) (int, bool) {
+	var free []int
+	for seat := 1; seat <= d.t.TableSize; seat++ {
+		if _, taken := t.seats[seat]; !taken {
+			free = append(free, seat)
+		}
+	}
+	if len(free) == 0 {
+		return 0, false
+	}
+	return free[rand.IntN(len(free))], true
+}
+
+// nextBigBlind is the player due to post the big blind in t's next hand,
+// who is the fairest to move as they have paid their way round.
+func (d *TournamentDirector) nextBigBlind(t *directedTable) uuid.UUID {
+	seats := sortedSeats(t.seats)
+	_, bbIdx := blindPositions(seats, nextOccupiedSeat(seats, t.dealerSeat))
+	return t.seats[seats[bbIdx]]
+}
+
+func (d *TournamentDirector) allIdle() bool {
+	for _, t := range d.tables {
+		if !t.idle {
+			return false
+		}
+	}
+	return true
+}
+
+// updateBubble has the tables play hand-for-hand while the next player
+// out would finish just outside the money. Late registration must be over,
+// or the bubble could still move.
+func (d *TournamentDirector) updateBubble() {
+	d.handForHand = len(d.tables) > 1 &&
+		len(d.players) == len(d.t.Payouts)+1 &&
+		!d.lateRegistrationOpen()
+}
+
+// dealIdleTables starts the next hand at every idle table with two or more
+// players, unless play has stopped for a break or a hand-for-hand round
+// is still being played elsewhere.
+func (d *TournamentDirector) dealIdleTables() {
+	if d.finished || d.onBreak || (d.handForHand && !d.allIdle()) {
+		return
+	}
+	for _, t := range d.tables {
+		if t.idle && len(t.seats) >= 2 {
+			t.idle = false
+			d.send(t.hub, HubEvent{Type: EventStartHand})
+		}
+	}
+}
+
+// finish pays out once a single player has all the chips, and closes the
+// tables after a short delay.
+func (d *TournamentDirector) finish() {
+	d.finished = true
+	d.stopTimer()
+
+	for userID, p := range d.players {
+		d.callHost(func() {
+			if err := d.host.TournamentFinished(d.ctx, d.t.ID, userID); err != nil {
+				d.logger.Error("CRITICAL: failed to finish tournament", "winner_id", userID, "error", err)
+			}
+		})
+		d.eliminated = append(d.eliminated, domain.TournamentStanding{
+			UserID:   userID,
+			Username: p.username,
+			Position: 1,
+		})
+
+		msg := d.message(domain.WSMsgEliminated, domain.WSEliminated{UserID: userID, Position: 1})
+		d.broadcaster.BroadcastToTable(p.tableID, msg)
+	}
+
+	d.broadcastStandings()
+
+	for _, t := range d.tables {
+		d.callHost(func() {
+			if err := d.host.CloseTable(d.ctx, t.id); err != nil {
+				d.logger.Error("failed to close tournament table", "table_id", t.id, "error", err)
+			}
+		})
+		hub := t.hub
+		time.AfterFunc(tournamentCloseDelay, func() {
+			_ = hub.Send(HubEvent{Type: EventShutdown})
+		})
+	}
+}
+
+// scheduleLevel starts the clock on the current level. The last level
+// lasts until the tournament ends.
+func (d *TournamentDirector) scheduleLevel() {
+	d.stopTimer()
+	if d.level >= len(d.t.Levels)-1 {
+		d.levelEnds = time.Time{}
+		return
+	}
+
+	d.levelEnds = time.Now().Add(d.t.LevelDuration())
+	d.timer = time.AfterFunc(d.t.LevelDuration(), d.endLevel)
+}
+
+// endLevel moves on to the next level, or starts a break if one is due.
+// Hands already being played finish under the old blinds.
+func (d *TournamentDirector) endLevel() {
+	d.mu.Lock()
+	defer d.unlock()
+
+	if d.finished {
+		return
+	}
+
+	if every := d.t.BreakEvery; every > 0 && (d.level+1)%every == 0 {
+		d.onBreak = true
+		d.levelEnds = time.Now().Add(d.t.BreakDuration())
+		d.timer = time.AfterFunc(d.t.BreakDuration(), d.endBreak)
+		d.broadcastStandings()
+		return
+	}
+
+	d.nextLevel()
+}
+
+// endBreak resumes play at the next level.
+func (d *TournamentDirector) endBreak() {
+	d.mu.Lock()
+	defer d.unlock()
+
+	if d.finished {
+		return
+	}
+
+	d.onBreak = false
+	d.nextLevel()
+	d.dealIdleTables()
+}
+
+func (d *TournamentDirector) nextLevel() {
+	d.level++
+	d.scheduleLevel()
+	d.updateBubble()
+	d.dealIdleTables()
+	d.broadcastBlindLevel()
+	d.broadcastStandings()
+}
+
+// send queues an event for hub, sent once the lock is released.
+func (d *TournamentDirector) send(hub *TableHub, event HubEvent) {
+	d.hubEvents = append(d.hubEvents, queuedEvent{hub: hub, event: event})
+}
+
+// callHost queues a call to the host, made once the lock is released.
+func (d *TournamentDirector) callHost(fn func()) {
+	d.hostCalls = append(d.hostCalls, fn)
+}
+
+// unlock releases the lock and carries out what was queued under it. The
+// host is called here, in order; the hubs are sent their events from
+// another goroutine once the previous batch has gone, as the caller may
+// itself be a hub.
+func (d *TournamentDirector) unlock() {
+	events, calls := d.hubEvents, d.hostCalls
+	d.hubEvents, d.hostCalls = nil, nil
+
+	if len(events) > 0 {
+		prev := d.delivered
+		done := make(chan struct{})
+		d.delivered = done
+		go func() {
+			defer close(done)
+			if prev != nil {
+				<-prev
+			}
+			for _, e := range events {
+				_ = e.hub.Send(e.event)
+			}
+		}()
+	}
+	d.mu.Unlock()
+
+	for _, call := range calls {
+		call()
+	}
+}
+
+func (d *TournamentDirector) stopTimer() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+func (d *TournamentDirector) standings() domain.TournamentStandings {
+	prizes := d.t.Prizes(d.entries)
+	s := domain.TournamentStandings{
+		TournamentID:     d.t.ID,
+		Status:           domain.TournamentRunning,
+		Level:            d.level + 1,
+		Blinds:           d.t.Levels[d.level],
+		OnBreak:          d.onBreak,
+		HandForHand:      d.handForHand,
+		LateRegistration: d.lateRegistrationOpen(),
+		Entries:          d.entries,
+		PlayersLeft:      len(d.players),
+		PrizePool:        d.t.PrizePool(d.entries),
+		Prizes:           prizes,
+	}
+	if d.finished {
+		s.Status = domain.TournamentFinished
+	}
+	if !d.levelEnds.IsZero() {
+		s.NextLevelIn = max(time.Until(d.levelEnds), 0).Seconds()
+	}
+
+	for id := range d.tables {
+		s.Tables = append(s.Tables, id)
+	}
+
+	if !d.finished {
+		live := make([]domain.TournamentStanding, 0, len(d.players))
+		for _, p := range d.players {
+			tableID := p.tableID
+			live = append(live, domain.TournamentStanding{
+				UserID:   p.userID,
+				Username: p.username,
+				Stack:    p.stack,
+				TableID:  &tableID,
+			})
+		}
+		sort.Slice(live, func(i, j int) bool {
+			return live[i].Stack.GreaterThan(live[j].Stack)
+		})
+		s.Players = live
+	}
+
+	out := make([]domain.TournamentStanding, len(d.eliminated))
+	copy(out, d.eliminated)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Position < out[j].Position
+	})
+	for i := range out {
+		if pos := out[i].Position; pos <= len(prizes) {
+			out[i].Prize = prizes[pos-1]
+		}
+	}
+	s.Players = append(s.Players, out...)
+
+	return s
+}
+
+// broadcastStandings sends the standings to every table and to clients
+// following the tournament as a whole.
+func (d *TournamentDirector) broadcastStandings() {
+	msg := d.message(domain.WSMsgStandings, d.standings())
+	d.broadcaster.BroadcastToTable(d.t.ID, msg)
+	for id := range d.tables {
+		d.broadcaster.BroadcastToTable(id, msg)
+	}
+}
+
+func (d *TournamentDirector) broadcastBlindLevel() {
+	payload := domain.WSBlindLevel{
+		Level:      d.level + 1,
+		BlindLevel: d.t.Levels[d.level],
+	}
+	if !d.levelEnds.IsZero() {
+		payload.NextLevelIn = time.Until(d.levelEnds).Seconds()
+	}
+	msg := d.message(domain.WSMsgBlindLevel, payload)
+	d.broadcaster.BroadcastToTable(d.t.ID, msg)
+	for id := range d.tables {
+		d.broadcaster.BroadcastToTable(id, msg)
+	}
+}
+
+func (d *TournamentDirector) message(msgType domain.WSMessageType, payload any) domain.WSMessage {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		d.logger.Error("failed to marshal message payload", "type", msgType, "error", err)
+		return domain.WSMessage{Type: msgType}
+	}
+	return domain.WSMessage{Type: msgType, Payload: data}
+}
+
+func sortedSeats(seats map[int]uuid.UUID) []int {
+	sorted := make([]int, 0, len(seats))
+	for seat := range seats {
+		sorted = append(sorted, seat)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/shopspring/decimal"
+)
+
+// applyBlindLevel sets the table's blinds and ante from the director's
+// current level.
+func (h *TableHub) applyBlindLevel() {
+	level := h.director.blinds()
+	h.state.Table.SmallBlind = level.SmallBlind
+	h.state.Table.BigBlind = level.BigBlind
+	h.state.Table.Ante = level.Ante
+}
+
+// handStartStacks is what each player in the current hand had when it
+// was dealt, for ranking players who bust out together.
+func (h *TableHub) handStartStacks() map[uuid.UUID]decimal.Decimal {
+	stacks := make(map[uuid.UUID]decimal.Decimal)
+	if h.state.Hand == nil {
+		return stacks
+	}
+	for _, bp := range h.state.Hand.Betting.Players {
+		stacks[bp.PlayerID] = h.state.Hand.CumulativeBets[bp.PlayerID].
+			Add(h.state.Hand.DeadMoney[bp.PlayerID])
+	}
+	return stacks
+}
+
+// handReport tells the director how the hand just played left the table.
+func (h *TableHub) handReport(busted []domain.PokerPlayer, startStacks map[uuid.UUID]decimal.Decimal) handReport {
+	report := handReport{
+		tableID:    h.state.Table.ID,
+		stacks:     make(map[uuid.UUID]decimal.Decimal, len(h.state.Players)),
+		dealerSeat: h.state.DealerSeat,
+	}
+	for _, p := range h.state.Players {
+		report.stacks[p.UserID] = p.Stack
+	}
+	for _, p := range busted {
+		report.busted = append(report.busted, bustedPlayer{
+			userID:     p.UserID,
+			username:   p.Username,
+			tableID:    h.state.Table.ID,
+			startStack: startStacks[p.ID],
+		})
+	}
+	return report
+}
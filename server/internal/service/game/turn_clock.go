@@ -0,0 +1,26 @@
+package game
+
+import "time"
+
+// TurnClock configures how long players have to act.
+type TurnClock struct {
+	TurnTimeout time.Duration
+
+	// TimeBank is a player's bank when they sit down and the most it can
+	// refill to. It is drawn on automatically once TurnTimeout runs out.
+	TimeBank            time.Duration
+	TimeBankRefill      time.Duration
+	TimeBankRefillHands int
+
+	// SitOutAfterTimeouts is the number of consecutive timeouts after which
+	// a player is sat out. Zero disables it.
+	SitOutAfterTimeouts int
+}
+
+// refillTimeBank tops up a bank after every TimeBankRefillHands hands.
+func (c TurnClock) refillTimeBank(bank time.Duration, handCount int) time.Duration {
+	if c.TimeBankRefillHands <= 0 || handCount%c.TimeBankRefillHands != 0 {
+		return bank
+	}
+	return min(bank+c.TimeBankRefill, c.TimeBank)
+}
@@ -0,0 +1,26 @@
+package game
+
+import "github.com/jokeoa/goigaming/internal/core/domain"
+
+// Variant holds the rules that differ between the games a table can run.
+type Variant struct {
+	Deck      func() []domain.Card
+	HoleCards int
+	BestHand  HandEvaluator
+	// BestLow is set for hi-lo games, where the pot is split with the
+	// best qualifying low.
+	BestLow LowEvaluator
+}
+
+func VariantFor(gameType domain.GameType) Variant {
+	switch gameType {
+	case domain.GamePLO:
+		return Variant{Deck: domain.FullDeck, HoleCards: 4, BestHand: BestOmahaHand}
+	case domain.GamePLO8:
+		return Variant{Deck: domain.FullDeck, HoleCards: 4, BestHand: BestOmahaHand, BestLow: BestOmahaLow}
+	case domain.GameShort:
+		return Variant{Deck: domain.ShortDeck, HoleCards: 2, BestHand: BestShortDeckHand}
+	default:
+		return Variant{Deck: domain.FullDeck, HoleCards: 2, BestHand: BestHand}
+	}
+}
@@ -0,0 +1,422 @@
+package kyc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+	"github.com/jokeoa/goigaming/internal/repository/postgres"
+	"github.com/shopspring/decimal"
+)
+
+const maxDocumentSize = 10 << 20
+
+var documentExtensions = map[string]string{
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+	"application/pdf": ".pdf",
+}
+
+type Service struct {
+	pool           *pgxpool.Pool
+	userRepo       ports.UserRepository
+	kycRepo        ports.KYCRepository
+	userFn         func(db postgres.DBTX) ports.UserRepository
+	kycFn          func(db postgres.DBTX) ports.KYCRepository
+	notificationFn func(db postgres.DBTX) ports.NotificationRepository
+	auditFn        func(db postgres.DBTX) ports.AuditLogRepository
+	storage        ports.FileStorage
+	limits         domain.KYCLimits
+}
+
+func NewService(
+	pool *pgxpool.Pool,
+	userRepo ports.UserRepository,
+	kycRepo ports.KYCRepository,
+	userFn func(db postgres.DBTX) ports.UserRepository,
+	kycFn func(db postgres.DBTX) ports.KYCRepository,
+	notificationFn func(db postgres.DBTX) ports.NotificationRepository,
+	auditFn func(db postgres.DBTX) ports.AuditLogRepository,
+	storage ports.FileStorage,
+	limits domain.KYCLimits,
+) *Service {
+	return &Service{
+		pool:           pool,
+		userRepo:       userRepo,
+		kycRepo:        kycRepo,
+		userFn:         userFn,
+		kycFn:          kycFn,
+		notificationFn: notificationFn,
+		auditFn:        auditFn,
+		storage:        storage,
+		limits:         limits,
+	}
+}
+
+func (s *Service) GetOverview(ctx context.Context, userID uuid.UUID) (domain.KYCOverview, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.KYCOverview{}, fmt.Errorf("KYCService.GetOverview: %w", err)
+	}
+
+	overview := domain.KYCOverview{
+		Level:         user.KYCLevel,
+		WithdrawLimit: s.limits.WithdrawLimit(user.KYCLevel),
+	}
+
+	req, err := s.kycRepo.FindLatestRequestByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		if req, err = s.withDocuments(ctx, req); err != nil {
+			return domain.KYCOverview{}, fmt.Errorf("KYCService.GetOverview: %w", err)
+		}
+		overview.Request = &req
+	case !errors.Is(err, domain.ErrKYCRequestNotFound):
+		return domain.KYCOverview{}, fmt.Errorf("KYCService.GetOverview: %w", err)
+	}
+
+	return overview, nil
+}
+
+// Submit opens a request for level with the given documents. When the
+// reviewer asked for more information, the documents are added to the
+// open request and it goes back into the queue.
+func (s *Service) Submit(ctx context.Context, userID uuid.UUID, level domain.KYCLevel, uploads []domain.KYCUpload) (domain.KYCRequest, error) {
+	if !level.IsValid() || level == domain.KYCLevelNone {
+		return domain.KYCRequest{}, domain.ErrInvalidKYCLevel
+	}
+	if len(uploads) == 0 {
+		return domain.KYCRequest{}, domain.ErrKYCDocumentsMissing
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Submit: %w", err)
+	}
+	if level <= user.KYCLevel {
+		return domain.KYCRequest{}, domain.ErrInvalidKYCLevel
+	}
+
+	req := domain.KYCRequest{UserID: userID, RequestedLevel: level}
+
+	latest, err := s.kycRepo.FindLatestRequestByUserID(ctx, userID)
+	switch {
+	case err == nil && latest.Status == domain.KYCStatusPending:
+		return domain.KYCRequest{}, domain.ErrKYCRequestPending
+	case err == nil && latest.Status == domain.KYCStatusInfoRequested:
+		if latest.RequestedLevel != level {
+			return domain.KYCRequest{}, domain.ErrInvalidKYCLevel
+		}
+		if req, err = s.withDocuments(ctx, latest); err != nil {
+			return domain.KYCRequest{}, fmt.Errorf("KYCService.Submit: %w", err)
+		}
+	case err != nil && !errors.Is(err, domain.ErrKYCRequestNotFound):
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Submit: %w", err)
+	}
+
+	// Completeness only depends on the document types, so it is checked
+	// before anything is written to storage.
+	check := req
+	for _, u := range uploads {
+		check.Documents = append(check.Documents, domain.KYCDocument{Type: u.Type})
+	}
+	if !check.HasDocumentsFor(level) {
+		return domain.KYCRequest{}, domain.ErrKYCDocumentsMissing
+	}
+
+	docs, err := s.storeDocuments(ctx, userID, uploads)
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Submit: %w", err)
+	}
+
+	err = postgres.RunInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		kycRepo := s.kycFn(tx)
+
+		req.Status = domain.KYCStatusPending
+
+		var saved domain.KYCRequest
+		var err error
+		if req.ID == uuid.Nil {
+			saved, err = kycRepo.CreateRequest(ctx, req)
+		} else {
+			saved, err = kycRepo.UpdateRequest(ctx, req)
+		}
+		if err != nil {
+			return err
+		}
+		saved.Documents = req.Documents
+
+		types := make([]domain.KYCDocumentType, 0, len(docs))
+		for _, d := range docs {
+			d.RequestID = saved.ID
+			created, err := kycRepo.CreateDocument(ctx, d)
+			if err != nil {
+				return err
+			}
+			saved.Documents = append(saved.Documents, created)
+			types = append(types, d.Type)
+		}
+
+		if err := s.audit(ctx, tx, &userID, "kyc.submitted", "kyc_request", saved.ID, map[string]any{
+			"level":     level,
+			"documents": types,
+		}); err != nil {
+			return err
+		}
+
+		req = saved
+		return nil
+	})
+	if err != nil {
+		for _, d := range docs {
+			_ = s.storage.Delete(ctx, d.URL)
+		}
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Submit: %w", err)
+	}
+
+	return req, nil
+}
+
+func (s *Service) ListRequests(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]domain.KYCRequest, error) {
+	requests, err := s.kycRepo.FindRequestsByStatus(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("KYCService.ListRequests: %w", err)
+	}
+	return requests, nil
+}
+
+func (s *Service) GetRequest(ctx context.Context, requestID uuid.UUID) (domain.KYCRequest, error) {
+	req, err := s.kycRepo.FindRequestByID(ctx, requestID)
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.GetRequest: %w", err)
+	}
+
+	req, err = s.withDocuments(ctx, req)
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.GetRequest: %w", err)
+	}
+
+	return req, nil
+}
+
+// Review records an admin decision on an open request. Approval raises the
+// user's level; every decision notifies the user and is audited.
+func (s *Service) Review(ctx context.Context, reviewerID, requestID uuid.UUID, decision domain.KYCDecision, note string) (domain.KYCRequest, error) {
+	var status domain.KYCStatus
+	var message string
+
+	switch decision {
+	case domain.KYCDecisionApprove:
+		status = domain.KYCStatusApproved
+	case domain.KYCDecisionReject:
+		status = domain.KYCStatusRejected
+		message = "Your identity verification was rejected."
+		if note != "" {
+			message = "Your identity verification was rejected: " + note
+		}
+	case domain.KYCDecisionRequestInfo:
+		if note == "" {
+			return domain.KYCRequest{}, domain.ErrKYCReviewNoteRequired
+		}
+		status = domain.KYCStatusInfoRequested
+		message = "More information is needed for your identity verification: " + note
+	default:
+		return domain.KYCRequest{}, domain.ErrInvalidKYCDecision
+	}
+
+	var result domain.KYCRequest
+
+	err := postgres.RunInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		kycRepo := s.kycFn(tx)
+		userRepo := s.userFn(tx)
+
+		req, err := kycRepo.FindRequestByID(ctx, requestID)
+		if err != nil {
+			return err
+		}
+		if !req.IsOpen() {
+			return domain.ErrKYCRequestClosed
+		}
+		if req.UserID == reviewerID {
+			return domain.ErrForbidden
+		}
+
+		now := time.Now()
+		req.Status = status
+		req.ReviewerID = &reviewerID
+		req.ReviewNote = note
+		req.ReviewedAt = &now
+
+		result, err = kycRepo.UpdateRequest(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if err := s.audit(ctx, tx, &reviewerID, "kyc."+string(status), "kyc_request", req.ID, map[string]any{
+			"note": note,
+		}); err != nil {
+			return err
+		}
+
+		notificationType := domain.NotificationKYCRejected
+		switch status {
+		case domain.KYCStatusInfoRequested:
+			notificationType = domain.NotificationKYCInfoRequested
+		case domain.KYCStatusApproved:
+			notificationType = domain.NotificationKYCApproved
+
+			user, err := userRepo.FindByID(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+
+			level := user.KYCLevel
+			if req.RequestedLevel > level {
+				if err := userRepo.UpdateKYCLevel(ctx, user.ID, req.RequestedLevel); err != nil {
+					return err
+				}
+				if err := s.audit(ctx, tx, &reviewerID, "kyc.level_changed", "user", user.ID, map[string]any{
+					"from":       user.KYCLevel,
+					"to":         req.RequestedLevel,
+					"request_id": req.ID,
+				}); err != nil {
+					return err
+				}
+				level = req.RequestedLevel
+			}
+			message = fmt.Sprintf("Your identity verification was approved. Your verification level is now %d.", level)
+		}
+
+		_, err = s.notificationFn(tx).Create(ctx, domain.Notification{
+			UserID:  req.UserID,
+			Type:    notificationType,
+			Message: message,
+		})
+		return err
+	})
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Review: %w", err)
+	}
+
+	result, err = s.withDocuments(ctx, result)
+	if err != nil {
+		return domain.KYCRequest{}, fmt.Errorf("KYCService.Review: %w", err)
+	}
+
+	return result, nil
+}
+
+// OpenDocument returns the stored file of a document. The caller must
+// close the reader.
+func (s *Service) OpenDocument(ctx context.Context, documentID uuid.UUID) (domain.KYCDocument, io.ReadCloser, error) {
+	doc, err := s.kycRepo.FindDocumentByID(ctx, documentID)
+	if err != nil {
+		return domain.KYCDocument{}, nil, fmt.Errorf("KYCService.OpenDocument: %w", err)
+	}
+
+	r, err := s.storage.Open(ctx, doc.URL)
+	if err != nil {
+		return domain.KYCDocument{}, nil, fmt.Errorf("KYCService.OpenDocument: %w", err)
+	}
+
+	return doc, r, nil
+}
+
+// CheckWithdrawal implements ports.WithdrawalLimiter.
+func (s *Service) CheckWithdrawal(ctx context.Context, userID uuid.UUID, amount, withdrawn decimal.Decimal) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("KYCService.CheckWithdrawal: %w", err)
+	}
+
+	if required := s.limits.RequiredLevel(withdrawn.Add(amount)); user.KYCLevel < required {
+		return fmt.Errorf("KYCService.CheckWithdrawal: level %d needed: %w", required, domain.ErrKYCLevelRequired)
+	}
+
+	return nil
+}
+
+func (s *Service) storeDocuments(ctx context.Context, userID uuid.UUID, uploads []domain.KYCUpload) ([]domain.KYCDocument, error) {
+	docs := make([]domain.KYCDocument, 0, len(uploads))
+
+	cleanup := func() {
+		for _, d := range docs {
+			_ = s.storage.Delete(ctx, d.URL)
+		}
+	}
+
+	for _, u := range uploads {
+		if u.Size > maxDocumentSize {
+			cleanup()
+			return nil, domain.ErrKYCDocumentTooLarge
+		}
+
+		data, err := io.ReadAll(io.LimitReader(u.Reader, maxDocumentSize+1))
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("read upload: %w", err)
+		}
+		if len(data) > maxDocumentSize {
+			cleanup()
+			return nil, domain.ErrKYCDocumentTooLarge
+		}
+
+		contentType := http.DetectContentType(data)
+		ext, ok := documentExtensions[contentType]
+		if !ok {
+			cleanup()
+			return nil, domain.ErrInvalidKYCDocument
+		}
+
+		key := fmt.Sprintf("%s/%s%s", userID, uuid.New(), ext)
+		url, err := s.storage.Save(ctx, key, bytes.NewReader(data))
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+
+		docs = append(docs, domain.KYCDocument{
+			Type:        u.Type,
+			URL:         url,
+			ContentType: contentType,
+			Size:        int64(len(data)),
+		})
+	}
+
+	return docs, nil
+}
+
+func (s *Service) withDocuments(ctx context.Context, req domain.KYCRequest) (domain.KYCRequest, error) {
+	docs, err := s.kycRepo.FindDocumentsByRequestID(ctx, req.ID)
+	if err != nil {
+		return domain.KYCRequest{}, err
+	}
+	req.Documents = docs
+	return req, nil
+}
+
+func (s *Service) audit(ctx context.Context, db postgres.DBTX, actorID *uuid.UUID, action, entityType string, entityID uuid.UUID, details map[string]any) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("marshal audit details: %w", err)
+	}
+
+	_, err = s.auditFn(db).Create(ctx, domain.AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Details:    data,
+	})
+	return err
+}
@@ -0,0 +1,33 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+)
+
+type Service struct {
+	repo ports.NotificationRepository
+}
+
+func NewService(repo ports.NotificationRepository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Notification, error) {
+	notifications, err := s.repo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("NotificationService.List: %w", err)
+	}
+	return notifications, nil
+}
+
+func (s *Service) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	if err := s.repo.MarkRead(ctx, notificationID, userID); err != nil {
+		return fmt.Errorf("NotificationService.MarkRead: %w", err)
+	}
+	return nil
+}
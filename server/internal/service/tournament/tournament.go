@@ -0,0 +1,683 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jokeoa/goigaming/internal/core/domain"
+	"github.com/jokeoa/goigaming/internal/core/ports"
+	"github.com/jokeoa/goigaming/internal/service/game"
+	"github.com/shopspring/decimal"
+)
+
+// scheduleInterval is how often Run looks for scheduled tournaments due
+// to start.
+const scheduleInterval = 10 * time.Second
+
+// Service runs Sit-and-Go and scheduled tournaments. It takes buy-ins from
+// wallets on registration, starts a Sit-and-Go once it is full and a
+// scheduled tournament at its start time, and hosts the director that
+// plays each one out, paying the prizes once it reports a winner.
+type Service struct {
+	// mu serialises registrations so a tournament never overfills and
+	// starts exactly once.
+	mu         sync.Mutex
+	repo       ports.TournamentRepository
+	tableRepo  ports.PokerTableRepository
+	pokerSvc   ports.PokerService
+	walletSvc  ports.WalletService
+	userSvc    ports.UserService
+	hubManager *game.HubManager
+
+	// directorsMu guards directors apart from mu, as directors call back
+	// into the service while registrations hold mu.
+	directorsMu sync.Mutex
+	directors   map[uuid.UUID]*game.TournamentDirector
+}
+
+func NewService(
+	repo ports.TournamentRepository,
+	tableRepo ports.PokerTableRepository,
+	pokerSvc ports.PokerService,
+	walletSvc ports.WalletService,
+	userSvc ports.UserService,
+	hubManager *game.HubManager,
+) *Service {
+	return &Service{
+		repo:       repo,
+		tableRepo:  tableRepo,
+		pokerSvc:   pokerSvc,
+		walletSvc:  walletSvc,
+		userSvc:    userSvc,
+		hubManager: hubManager,
+		directors:  make(map[uuid.UUID]*game.TournamentDirector),
+	}
+}
+
+func (s *Service) CreateTournament(ctx context.Context, t domain.Tournament) (domain.Tournament, error) {
+	if !t.BuyIn.IsPositive() || t.Fee.IsNegative() || !t.StartingChips.IsPositive() {
+		return domain.Tournament{}, domain.ErrInvalidBuyIn
+	}
+	if err := checkLevels(t.Levels, t.LevelSeconds); err != nil {
+		return domain.Tournament{}, err
+	}
+
+	t, err := withSchedule(t)
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+
+	table, err := game.PrepareTable(tableFor(t))
+	if err != nil {
+		return domain.Tournament{}, err
+	}
+	t.GameType = table.GameType
+	t.Limit = table.Limit
+
+	if len(t.Payouts) == 0 {
+		t.Payouts = defaultPayouts(t.MaxPlayers)
+	}
+	if err := checkPayouts(t.Payouts, t.MaxPlayers); err != nil {
+		return domain.Tournament{}, err
+	}
+
+	t.Status = domain.TournamentRegistering
+
+	created, err := s.repo.Create(ctx, t)
+	if err != nil {
+		return domain.Tournament{}, fmt.Errorf("TournamentService.CreateTournament: %w", err)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetTournament(ctx context.Context, id uuid.UUID) (domain.TournamentDetail, error) {
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return domain.TournamentDetail{}, fmt.Errorf("TournamentService.GetTournament: %w", err)
+	}
+
+	entries, err := s.repo.FindEntries(ctx, id)
+	if err != nil {
+		return domain.TournamentDetail{}, fmt.Errorf("TournamentService.GetTournament entries: %w", err)
+	}
+
+	return domain.TournamentDetail{Tournament: t, Entries: entries}, nil
+}
+
+func (s *Service) ListTournaments(ctx context.Context) ([]domain.Tournament, error) {
+	tournaments, err := s.repo.FindByStatus(ctx, domain.TournamentRegistering, domain.TournamentRunning)
+	if err != nil {
+		return nil, fmt.Errorf("TournamentService.ListTournaments: %w", err)
+	}
+	return tournaments, nil
+}
+
+// GetStandings is the live state of play while a tournament is running,
+// and otherwise what the entries record.
+func (s *Service) GetStandings(ctx context.Context, id uuid.UUID) (domain.TournamentStandings, error) {
+	if director := s.director(id); director != nil {
+		return director.Standings(), nil
+	}
+
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return domain.TournamentStandings{}, fmt.Errorf("TournamentService.GetStandings: %w", err)
+	}
+
+	entries, err := s.repo.FindEntries(ctx, id)
+	if err != nil {
+		return domain.TournamentStandings{}, fmt.Errorf("TournamentService.GetStandings entries: %w", err)
+	}
+
+	return standingsFrom(t, entries), nil
+}
+
+// Register takes the buy-in and fee from the user's wallet and enters them
+// into the tournament. A Sit-and-Go starts once its last seat is taken, or
+// is cancelled and refunded if it fails to.
+// While a scheduled tournament's late registration is open, players can
+// still enter, and those who have busted out can re-enter up to
+// MaxReEntries times; they are seated straight away.
+func (s *Service) Register(ctx context.Context, tournamentID, userID uuid.UUID) (domain.TournamentEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.repo.FindByID(ctx, tournamentID)
+	if err != nil {
+		return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register: %w", err)
+	}
+
+	var director *game.TournamentDirector
+	switch {
+	case t.Status == domain.TournamentRegistering:
+		if t.RegistrationOpensAt != nil && time.Now().Before(*t.RegistrationOpensAt) {
+			return domain.TournamentEntry{}, domain.ErrRegistrationClosed
+		}
+	case t.Status == domain.TournamentRunning && t.Kind == domain.TournamentScheduled:
+		director = s.director(t.ID)
+		if director == nil || !director.LateRegistrationOpen() {
+			return domain.TournamentEntry{}, domain.ErrRegistrationClosed
+		}
+	default:
+		return domain.TournamentEntry{}, domain.ErrTournamentStarted
+	}
+
+	entries, err := s.repo.FindEntries(ctx, tournamentID)
+	if err != nil {
+		return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register entries: %w", err)
+	}
+
+	active, prior := 0, 0
+	for _, e := range entries {
+		if e.Position == 0 {
+			active++
+		}
+		if e.UserID != userID {
+			continue
+		}
+		if e.Position == 0 {
+			return domain.TournamentEntry{}, domain.ErrAlreadyRegistered
+		}
+		prior++
+	}
+	if prior > t.MaxReEntries {
+		return domain.TournamentEntry{}, domain.ErrReEntryLimit
+	}
+	if active >= t.MaxPlayers {
+		return domain.TournamentEntry{}, domain.ErrTableFull
+	}
+
+	user, err := s.userSvc.GetByID(ctx, userID)
+	if err != nil {
+		return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register get user: %w", err)
+	}
+
+	cost := t.Cost().String()
+	if _, err := s.walletSvc.Withdraw(ctx, userID, cost); err != nil {
+		return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register withdraw: %w", err)
+	}
+
+	entry, err := s.repo.CreateEntry(ctx, domain.TournamentEntry{
+		TournamentID: tournamentID,
+		UserID:       userID,
+		Username:     user.Username,
+		EntryNumber:  prior + 1,
+	})
+	if err != nil {
+		if _, depErr := s.walletSvc.Deposit(ctx, userID, cost); depErr != nil {
+			return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register refund failed: create=%w, refund=%v", err, depErr)
+		}
+		return domain.TournamentEntry{}, err
+	}
+
+	if director != nil {
+		err := director.AddPlayer(domain.PokerPlayer{
+			ID:       uuid.New(),
+			UserID:   userID,
+			Username: user.Username,
+			Stack:    t.StartingChips,
+			JoinedAt: time.Now(),
+		})
+		if err != nil {
+			if delErr := s.repo.DeleteEntry(ctx, entry.ID); delErr != nil {
+				return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register remove entry failed: seat=%w, remove=%v", err, delErr)
+			}
+			if _, depErr := s.walletSvc.Deposit(ctx, userID, cost); depErr != nil {
+				return domain.TournamentEntry{}, fmt.Errorf("TournamentService.Register refund failed: seat=%w, refund=%v", err, depErr)
+			}
+			return domain.TournamentEntry{}, err
+		}
+		return entry, nil
+	}
+
+	entries = append(entries, entry)
+	if t.Kind == domain.TournamentSitAndGo && len(entries) == t.MaxPlayers {
+		if err := s.start(ctx, t, entries); err != nil {
+			return entry, fmt.Errorf("TournamentService.Register start: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// Unregister refunds the buy-in and fee of a user who registered for a
+// tournament that has not started yet.
+func (s *Service) Unregister(ctx context.Context, tournamentID, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, err := s.repo.FindByID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("TournamentService.Unregister: %w", err)
+	}
+	if t.Status != domain.TournamentRegistering {
+		return domain.ErrTournamentStarted
+	}
+
+	entries, err := s.repo.FindEntries(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("TournamentService.Unregister entries: %w", err)
+	}
+	entry, ok := activeEntry(entries, userID)
+	if !ok {
+		return domain.ErrNotRegistered
+	}
+
+	if err := s.repo.DeleteEntry(ctx, entry.ID); err != nil {
+		return fmt.Errorf("TournamentService.Unregister: %w", err)
+	}
+
+	if _, err := s.walletSvc.Deposit(ctx, userID, t.Cost().String()); err != nil {
+		return fmt.Errorf("TournamentService.Unregister refund: %w", err)
+	}
+
+	return nil
+}
+
+// Run starts scheduled tournaments when their start time comes, until ctx
+// is done. One that has fewer than two entrants by then is cancelled and
+// its buy-ins refunded.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.startDue(ctx); err != nil {
+				slog.Error("failed to start scheduled tournaments", "error", err)
+			}
+		}
+	}
+}
+
+func (s *Service) startDue(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tournaments, err := s.repo.FindByStatus(ctx, domain.TournamentRegistering)
+	if err != nil {
+		return fmt.Errorf("TournamentService.startDue: %w", err)
+	}
+
+	now := time.Now()
+	var errs []error
+	for _, t := range tournaments {
+		if t.Kind != domain.TournamentScheduled || t.StartsAt == nil || now.Before(*t.StartsAt) {
+			continue
+		}
+
+		entries, err := s.repo.FindEntries(ctx, t.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if len(entries) < 2 {
+			err = s.cancel(ctx, t, entries)
+		} else {
+			err = s.start(ctx, t, entries)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tournament %s: %w", t.ID, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("TournamentService.startDue: %w", err)
+	}
+	return nil
+}
+
+// start opens as many tables as the entrants need and hands them to a new
+// director, which seats everyone at random with the starting stack. If the
+// tournament cannot be started it is called off, as nothing would retry a
+// full Sit-and-Go.
+func (s *Service) start(ctx context.Context, t domain.Tournament, entries []domain.TournamentEntry) error {
+	count := (len(entries) + t.TableSize - 1) / t.TableSize
+	tables := make([]domain.PokerTable, 0, count)
+	for range count {
+		table, err := s.openTable(ctx, t)
+		if err != nil {
+			return s.abandonStart(ctx, t, entries, tables, err)
+		}
+		tables = append(tables, table)
+	}
+
+	started := t
+	now := time.Now()
+	started.Status = domain.TournamentRunning
+	started.TableID = &tables[0].ID
+	started.StartedAt = &now
+	if _, err := s.repo.Update(ctx, started); err != nil {
+		return s.abandonStart(ctx, t, entries, tables, err)
+	}
+	t = started
+
+	order := rand.Perm(len(entries))
+	players := make([]domain.PokerPlayer, len(entries))
+	for i, j := range order {
+		players[i] = domain.PokerPlayer{
+			ID:       uuid.New(),
+			UserID:   entries[j].UserID,
+			Username: entries[j].Username,
+			Stack:    t.StartingChips,
+			JoinedAt: now,
+		}
+	}
+
+	director := game.NewTournamentDirector(t, s, s.hubManager)
+	s.directorsMu.Lock()
+	s.directors[t.ID] = director
+	s.directorsMu.Unlock()
+
+	director.Start(tables, players)
+	return nil
+}
+
+// abandonStart closes the tables a failed start opened and cancels the
+// tournament, refunding its entries.
+func (s *Service) abandonStart(ctx context.Context, t domain.Tournament, entries []domain.TournamentEntry, tables []domain.PokerTable, cause error) error {
+	errs := []error{cause}
+	for _, table := range tables {
+		if err := s.CloseTable(ctx, table.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.cancel(ctx, t, entries); err != nil {
+		errs = append(errs, fmt.Errorf("cancel: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// cancel calls off a tournament and refunds everyone who registered.
+func (s *Service) cancel(ctx context.Context, t domain.Tournament, entries []domain.TournamentEntry) error {
+	var errs []error
+	for _, e := range entries {
+		if _, err := s.walletSvc.Deposit(ctx, e.UserID, t.Cost().String()); err != nil {
+			errs = append(errs, fmt.Errorf("refund %s: %w", e.UserID, err))
+		}
+	}
+
+	now := time.Now()
+	t.Status = domain.TournamentCancelled
+	t.EndedAt = &now
+	if _, err := s.repo.Update(ctx, t); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Service) director(tournamentID uuid.UUID) *game.TournamentDirector {
+	s.directorsMu.Lock()
+	defer s.directorsMu.Unlock()
+	return s.directors[tournamentID]
+}
+
+// OpenTable implements ports.TournamentHost.
+func (s *Service) OpenTable(ctx context.Context, tournamentID uuid.UUID) (domain.PokerTable, error) {
+	t, err := s.repo.FindByID(ctx, tournamentID)
+	if err != nil {
+		return domain.PokerTable{}, fmt.Errorf("TournamentService.OpenTable: %w", err)
+	}
+
+	table, err := s.openTable(ctx, t)
+	if err != nil {
+		return domain.PokerTable{}, fmt.Errorf("TournamentService.OpenTable: %w", err)
+	}
+	return table, nil
+}
+
+func (s *Service) openTable(ctx context.Context, t domain.Tournament) (domain.PokerTable, error) {
+	table, err := s.pokerSvc.CreateTable(ctx, tableFor(t))
+	if err != nil {
+		return domain.PokerTable{}, err
+	}
+	if err := s.tableRepo.UpdateStatus(ctx, table.ID, domain.TableStatusActive); err != nil {
+		return domain.PokerTable{}, err
+	}
+	table.Status = domain.TableStatusActive
+	return table, nil
+}
+
+// CloseTable implements ports.TournamentHost.
+func (s *Service) CloseTable(ctx context.Context, tableID uuid.UUID) error {
+	if err := s.tableRepo.UpdateStatus(ctx, tableID, domain.TableStatusClosed); err != nil {
+		return fmt.Errorf("TournamentService.CloseTable: %w", err)
+	}
+	return nil
+}
+
+// PlayerEliminated implements ports.TournamentHost.
+func (s *Service) PlayerEliminated(ctx context.Context, tournamentID, userID uuid.UUID, position int) error {
+	entries, err := s.repo.FindEntries(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("TournamentService.PlayerEliminated: %w", err)
+	}
+	entry, ok := activeEntry(entries, userID)
+	if !ok {
+		return fmt.Errorf("TournamentService.PlayerEliminated: %w", domain.ErrNotRegistered)
+	}
+
+	now := time.Now()
+	entry.Position = position
+	entry.Prize = decimal.Zero
+	entry.EliminatedAt = &now
+	if err := s.repo.UpdateEntry(ctx, entry); err != nil {
+		return fmt.Errorf("TournamentService.PlayerEliminated: %w", err)
+	}
+	return nil
+}
+
+// TournamentFinished implements ports.TournamentHost. It credits each
+// entry that finished in the money; the prize pool counts re-entries.
+func (s *Service) TournamentFinished(ctx context.Context, tournamentID, winnerID uuid.UUID) error {
+	s.directorsMu.Lock()
+	delete(s.directors, tournamentID)
+	s.directorsMu.Unlock()
+
+	t, err := s.repo.FindByID(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("TournamentService.TournamentFinished: %w", err)
+	}
+	if t.Status != domain.TournamentRunning {
+		return nil
+	}
+
+	entries, err := s.repo.FindEntries(ctx, tournamentID)
+	if err != nil {
+		return fmt.Errorf("TournamentService.TournamentFinished entries: %w", err)
+	}
+
+	prizes := t.Prizes(len(entries))
+	var errs []error
+	for _, e := range entries {
+		if e.UserID == winnerID && e.Position == 0 {
+			e.Position = 1
+		}
+		if e.Position < 1 || e.Position > len(prizes) {
+			continue
+		}
+
+		e.Prize = prizes[e.Position-1]
+		if _, err := s.walletSvc.Deposit(ctx, e.UserID, e.Prize.String()); err != nil {
+			errs = append(errs, fmt.Errorf("pay %s: %w", e.UserID, err))
+			continue
+		}
+		if err := s.repo.UpdateEntry(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("record prize for %s: %w", e.UserID, err))
+		}
+	}
+
+	now := time.Now()
+	t.Status = domain.TournamentFinished
+	t.EndedAt = &now
+	if _, err := s.repo.Update(ctx, t); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("TournamentService.TournamentFinished: %w", err)
+	}
+	return nil
+}
+
+// activeEntry is the user's entry that is still in play, if any.
+func activeEntry(entries []domain.TournamentEntry, userID uuid.UUID) (domain.TournamentEntry, bool) {
+	for _, e := range entries {
+		if e.UserID == userID && e.Position == 0 {
+			return e, true
+		}
+	}
+	return domain.TournamentEntry{}, false
+}
+
+// standingsFrom builds standings from a tournament's entries alone, for
+// one that is not being played right now.
+func standingsFrom(t domain.Tournament, entries []domain.TournamentEntry) domain.TournamentStandings {
+	st := domain.TournamentStandings{
+		TournamentID: t.ID,
+		Status:       t.Status,
+		Level:        1,
+		Blinds:       t.Levels[0],
+		Entries:      len(entries),
+		PrizePool:    t.PrizePool(len(entries)),
+		Prizes:       t.Prizes(len(entries)),
+	}
+
+	var out []domain.TournamentStanding
+	for _, e := range entries {
+		standing := domain.TournamentStanding{
+			UserID:   e.UserID,
+			Username: e.Username,
+			Position: e.Position,
+			Prize:    e.Prize,
+		}
+		if e.Position == 0 {
+			standing.Stack = t.StartingChips
+			st.Players = append(st.Players, standing)
+			st.PlayersLeft++
+			continue
+		}
+		out = append(out, standing)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Position < out[j].Position
+	})
+	st.Players = append(st.Players, out...)
+	return st
+}
+
+// tableFor is a table a tournament is played on, with the blinds of its
+// first level.
+func tableFor(t domain.Tournament) domain.PokerTable {
+	table := domain.PokerTable{
+		Name:         t.Name,
+		GameType:     t.GameType,
+		Limit:        t.Limit,
+		AnteType:     domain.AntePerPlayer,
+		MinBuyIn:     t.StartingChips,
+		MaxBuyIn:     t.StartingChips,
+		MaxPlayers:   t.TableSize,
+		TournamentID: &t.ID,
+	}
+	if len(t.Levels) > 0 {
+		table.SmallBlind = t.Levels[0].SmallBlind
+		table.BigBlind = t.Levels[0].BigBlind
+		table.Ante = t.Levels[0].Ante
+	}
+	return table
+}
+
+func checkLevels(levels []domain.BlindLevel, levelSeconds int) error {
+	if len(levels) == 0 || levelSeconds <= 0 {
+		return domain.ErrInvalidBlindSchedule
+	}
+	for _, l := range levels {
+		if !l.SmallBlind.IsPositive() || l.BigBlind.LessThan(l.SmallBlind) || l.Ante.IsNegative() {
+			return domain.ErrInvalidBlindSchedule
+		}
+	}
+	return nil
+}
+
+// withSchedule checks and fills in how a tournament runs. A Sit-and-Go
+// plays at a single table of MaxPlayers with no late registration; a
+// scheduled tournament needs a start time in the future, and MaxPlayers
+// caps the whole field.
+func withSchedule(t domain.Tournament) (domain.Tournament, error) {
+	if t.Kind == "" {
+		t.Kind = domain.TournamentSitAndGo
+	}
+	if !t.Kind.IsValid() || t.MaxPlayers < 2 || t.BreakEvery < 0 || t.BreakSeconds < 0 ||
+		(t.BreakEvery > 0) != (t.BreakSeconds > 0) {
+		return t, domain.ErrInvalidSchedule
+	}
+
+	if t.Kind == domain.TournamentSitAndGo {
+		if t.MaxPlayers > 9 {
+			return t, domain.ErrInvalidSchedule
+		}
+		t.TableSize = t.MaxPlayers
+		t.StartsAt = nil
+		t.RegistrationOpensAt = nil
+		t.LateRegLevels = 0
+		t.MaxReEntries = 0
+		return t, nil
+	}
+
+	if t.TableSize == 0 {
+		t.TableSize = 9
+	}
+	switch {
+	case t.StartsAt == nil || !t.StartsAt.After(time.Now()),
+		t.RegistrationOpensAt != nil && !t.RegistrationOpensAt.Before(*t.StartsAt),
+		t.TableSize < 2 || t.TableSize > 9,
+		t.LateRegLevels < 0 || t.LateRegLevels > len(t.Levels),
+		t.MaxReEntries < 0 || (t.MaxReEntries > 0 && t.LateRegLevels == 0):
+		return t, domain.ErrInvalidSchedule
+	}
+	return t, nil
+}
+
+func checkPayouts(payouts []decimal.Decimal, maxPlayers int) error {
+	if len(payouts) == 0 || len(payouts) > maxPlayers {
+		return domain.ErrInvalidPayouts
+	}
+	total := decimal.Zero
+	for _, p := range payouts {
+		if !p.IsPositive() {
+			return domain.ErrInvalidPayouts
+		}
+		total = total.Add(p)
+	}
+	if !total.Equal(decimal.NewFromInt(100)) {
+		return domain.ErrInvalidPayouts
+	}
+	return nil
+}
+
+// defaultPayouts pays the winner alone at three seats or fewer, the top
+// two at up to six, and the top three above that.
+func defaultPayouts(maxPlayers int) []decimal.Decimal {
+	switch {
+	case maxPlayers <= 3:
+		return []decimal.Decimal{decimal.NewFromInt(100)}
+	case maxPlayers <= 6:
+		return []decimal.Decimal{decimal.NewFromInt(65), decimal.NewFromInt(35)}
+	default:
+		return []decimal.Decimal{decimal.NewFromInt(50), decimal.NewFromInt(30), decimal.NewFromInt(20)}
+	}
+}
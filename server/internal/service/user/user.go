@@ -1,20 +1,80 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jokeoa/goigaming/internal/core/domain"
 	"github.com/jokeoa/goigaming/internal/core/ports"
+	"github.com/jokeoa/goigaming/internal/repository/postgres"
+	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	usernameChangeCooldown = 30 * 24 * time.Hour
+	emailVerificationTTL   = 24 * time.Hour
+	maxAvatarSize          = 2 << 20
+)
+
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
 type Service struct {
-	userRepo ports.UserRepository
+	pool             *pgxpool.Pool
+	userRepo         ports.UserRepository
+	verificationRepo ports.EmailVerificationRepository
+	userFn           func(db postgres.DBTX) ports.UserRepository
+	verificationFn   func(db postgres.DBTX) ports.EmailVerificationRepository
+	playerFn         func(db postgres.DBTX) ports.PokerPlayerRepository
+	sessionFn        func(db postgres.DBTX) ports.SessionRepository
+	storage          ports.FileStorage
+	mailer           ports.Mailer
+	renamer          ports.PlayerRenamer
+	terminator       ports.SessionTerminator
 }
 
-func NewService(userRepo ports.UserRepository) *Service {
-	return &Service{userRepo: userRepo}
+func NewService(
+	pool *pgxpool.Pool,
+	userRepo ports.UserRepository,
+	verificationRepo ports.EmailVerificationRepository,
+	userFn func(db postgres.DBTX) ports.UserRepository,
+	verificationFn func(db postgres.DBTX) ports.EmailVerificationRepository,
+	playerFn func(db postgres.DBTX) ports.PokerPlayerRepository,
+	sessionFn func(db postgres.DBTX) ports.SessionRepository,
+	storage ports.FileStorage,
+	mailer ports.Mailer,
+	renamer ports.PlayerRenamer,
+	terminator ports.SessionTerminator,
+) *Service {
+	return &Service{
+		pool:             pool,
+		userRepo:         userRepo,
+		verificationRepo: verificationRepo,
+		userFn:           userFn,
+		verificationFn:   verificationFn,
+		playerFn:         playerFn,
+		sessionFn:        sessionFn,
+		storage:          storage,
+		mailer:           mailer,
+		renamer:          renamer,
+		terminator:       terminator,
+	}
 }
 
 func (s *Service) GetProfile(ctx context.Context, userID uuid.UUID) (domain.UserProfile, error) {
@@ -22,7 +82,7 @@ func (s *Service) GetProfile(ctx context.Context, userID uuid.UUID) (domain.User
 	if err != nil {
 		return domain.UserProfile{}, fmt.Errorf("UserService.GetProfile: %w", err)
 	}
-	return domain.NewUserProfile(user), nil
+	return s.profile(ctx, user)
 }
 
 func (s *Service) GetByID(ctx context.Context, userID uuid.UUID) (domain.User, error) {
@@ -32,3 +92,265 @@ func (s *Service) GetByID(ctx context.Context, userID uuid.UUID) (domain.User, e
 	}
 	return user, nil
 }
+
+// UpdateProfile checks every requested change before making any of them,
+// then applies them all in one transaction. The avatar is saved last
+// inside it and removed again if the transaction fails. Once it commits,
+// the verification code is mailed, revoked sessions are closed and the new
+// username is shown at the tables. If the mail cannot be sent the changes
+// stand, and the new email address can be requested again.
+func (s *Service) UpdateProfile(ctx context.Context, userID uuid.UUID, update domain.ProfileUpdate) (domain.UserProfile, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile: %w", err)
+	}
+
+	if update.Password != nil {
+		hash, err := checkPasswordChange(user, *update.Password)
+		if err != nil {
+			return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile password: %w", err)
+		}
+		user.PasswordHash = hash
+	}
+
+	renamed := update.Username != nil && *update.Username != user.Username
+	if renamed {
+		now := time.Now()
+		if user.UsernameChangedAt != nil && now.Sub(*user.UsernameChangedAt) < usernameChangeCooldown {
+			return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile username: %w", domain.ErrUsernameChangeTooSoon)
+		}
+		user.Username = *update.Username
+		user.UsernameChangedAt = &now
+	}
+
+	var (
+		verification *domain.EmailVerification
+		token        string
+	)
+	if update.Email != nil && *update.Email != user.Email {
+		v, t, err := s.checkEmailChange(ctx, user, *update.Email)
+		if err != nil {
+			return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile email: %w", err)
+		}
+		verification, token = &v, t
+	}
+
+	var (
+		avatar    []byte
+		avatarExt string
+	)
+	if update.Avatar != nil {
+		avatar, avatarExt, err = readAvatar(*update.Avatar)
+		if err != nil {
+			return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile avatar: %w", err)
+		}
+	}
+
+	previousAvatar := user.AvatarURL
+
+	var (
+		updated   domain.User
+		revoked   []uuid.UUID
+		avatarURL string
+	)
+
+	err = postgres.RunInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		userRepo := s.userFn(tx)
+
+		u, err := userRepo.Update(ctx, user)
+		if err != nil {
+			return err
+		}
+		updated = u
+
+		if renamed {
+			if err := s.playerFn(tx).UpdateUsernameByUserID(ctx, user.ID, user.Username); err != nil {
+				return err
+			}
+		}
+
+		if update.Password != nil {
+			ids, err := s.sessionFn(tx).RevokeAllExcept(ctx, user.ID, update.Password.SessionID)
+			if err != nil {
+				return err
+			}
+			revoked = ids
+		}
+
+		if verification != nil {
+			if _, err := s.verificationFn(tx).Upsert(ctx, *verification); err != nil {
+				return err
+			}
+		}
+
+		if avatar != nil {
+			key := fmt.Sprintf("avatars/%s/%s%s", user.ID, uuid.New(), avatarExt)
+			url, err := s.storage.Save(ctx, key, bytes.NewReader(avatar))
+			if err != nil {
+				return err
+			}
+			avatarURL = url
+
+			updated.AvatarURL = url
+			if updated, err = userRepo.Update(ctx, updated); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		if avatarURL != "" {
+			_ = s.storage.Delete(ctx, avatarURL)
+		}
+		return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile: %w", err)
+	}
+
+	if avatarURL != "" && previousAvatar != "" {
+		_ = s.storage.Delete(ctx, previousAvatar)
+	}
+	for _, id := range revoked {
+		s.terminator.CloseSession(id)
+	}
+	if renamed {
+		s.renamer.RenamePlayer(user.ID, user.Username)
+	}
+	if verification != nil {
+		if err := s.sendVerification(ctx, updated, *verification, token); err != nil {
+			return domain.UserProfile{}, fmt.Errorf("UserService.UpdateProfile: %w", err)
+		}
+	}
+
+	return s.profile(ctx, updated)
+}
+
+func (s *Service) VerifyEmail(ctx context.Context, userID uuid.UUID, token string) (domain.UserProfile, error) {
+	v, err := s.verificationRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return domain.UserProfile{}, fmt.Errorf("UserService.VerifyEmail: %w", err)
+	}
+
+	if time.Now().After(v.ExpiresAt) || subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(v.TokenHash)) != 1 {
+		return domain.UserProfile{}, domain.ErrInvalidVerificationToken
+	}
+
+	var user domain.User
+
+	err = postgres.RunInTx(ctx, s.pool, func(tx pgx.Tx) error {
+		userRepo := s.userFn(tx)
+
+		u, err := userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		u.Email = v.Email
+		user, err = userRepo.Update(ctx, u)
+		if err != nil {
+			return err
+		}
+
+		return s.verificationFn(tx).DeleteByUserID(ctx, userID)
+	})
+	if err != nil {
+		return domain.UserProfile{}, fmt.Errorf("UserService.VerifyEmail: %w", err)
+	}
+
+	return domain.NewUserProfile(user), nil
+}
+
+// checkPasswordChange confirms the current password and hashes the new
+// one.
+func checkPasswordChange(user domain.User, change domain.PasswordChange) (string, error) {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(change.Current)); err != nil {
+		return "", domain.ErrInvalidCredentials
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(change.New), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// checkEmailChange makes sure email is free and prepares its verification
+// along with the token to mail.
+func (s *Service) checkEmailChange(ctx context.Context, user domain.User, email string) (domain.EmailVerification, string, error) {
+	existing, err := s.userRepo.FindByEmail(ctx, email)
+	if err == nil && existing.ID != user.ID {
+		return domain.EmailVerification{}, "", domain.ErrUserAlreadyExists
+	}
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return domain.EmailVerification{}, "", err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return domain.EmailVerification{}, "", err
+	}
+
+	return domain.EmailVerification{
+		UserID:    user.ID,
+		Email:     email,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(emailVerificationTTL),
+	}, token, nil
+}
+
+func (s *Service) sendVerification(ctx context.Context, user domain.User, v domain.EmailVerification, token string) error {
+	body := fmt.Sprintf("Hi %s,\n\nuse this code to confirm your new email address: %s\n\nThe code expires in 24 hours.", user.Username, token)
+	if err := s.mailer.Send(ctx, v.Email, "Confirm your new email address", body); err != nil {
+		return fmt.Errorf("send verification: %w", err)
+	}
+	return nil
+}
+
+// readAvatar reads an uploaded avatar and picks its file extension.
+func readAvatar(upload domain.Upload) ([]byte, string, error) {
+	if upload.Size > maxAvatarSize {
+		return nil, "", domain.ErrAvatarTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(upload.Reader, maxAvatarSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("read upload: %w", err)
+	}
+	if len(data) > maxAvatarSize {
+		return nil, "", domain.ErrAvatarTooLarge
+	}
+
+	ext, ok := avatarExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, "", domain.ErrInvalidAvatar
+	}
+	return data, ext, nil
+}
+
+func (s *Service) profile(ctx context.Context, user domain.User) (domain.UserProfile, error) {
+	profile := domain.NewUserProfile(user)
+
+	v, err := s.verificationRepo.FindByUserID(ctx, user.ID)
+	switch {
+	case err == nil:
+		if time.Now().Before(v.ExpiresAt) {
+			profile.PendingEmail = v.Email
+		}
+	case !errors.Is(err, domain.ErrInvalidVerificationToken):
+		return domain.UserProfile{}, fmt.Errorf("UserService.profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -16,12 +17,16 @@ import (
 
 const maxRetries = 3
 
+// cashOutReference marks the withdrawals users ask for, which KYC limits.
+const cashOutReference = "cash_out"
+
 type Service struct {
 	pool       *pgxpool.Pool
 	walletFn   func(db postgres.DBTX) ports.WalletRepository
 	txFn       func(db postgres.DBTX) ports.TransactionRepository
 	walletRepo ports.WalletRepository
 	txRepo     ports.TransactionRepository
+	limiter    ports.WithdrawalLimiter
 }
 
 func NewService(
@@ -30,6 +35,7 @@ func NewService(
 	txRepo ports.TransactionRepository,
 	walletFn func(db postgres.DBTX) ports.WalletRepository,
 	txFn func(db postgres.DBTX) ports.TransactionRepository,
+	limiter ports.WithdrawalLimiter,
 ) *Service {
 	return &Service{
 		pool:       pool,
@@ -37,6 +43,7 @@ func NewService(
 		txFn:       txFn,
 		walletRepo: walletRepo,
 		txRepo:     txRepo,
+		limiter:    limiter,
 	}
 }
 
@@ -131,7 +138,7 @@ func (s *Service) Withdraw(ctx context.Context, userID uuid.UUID, amount string)
 	var result domain.Wallet
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		result, err = s.executeWithdraw(ctx, userID, amt)
+		result, err = s.executeWithdraw(ctx, userID, amt, false)
 		if err == nil {
 			return result, nil
 		}
@@ -143,7 +150,37 @@ func (s *Service) Withdraw(ctx context.Context, userID uuid.UUID, amount string)
 	return domain.Wallet{}, fmt.Errorf("WalletService.Withdraw: max retries exceeded: %w", err)
 }
 
-func (s *Service) executeWithdraw(ctx context.Context, userID uuid.UUID, amount decimal.Decimal) (domain.Wallet, error) {
+// CashOut is a withdrawal requested by the user, as opposed to the debits
+// games make through Withdraw. It is subject to the KYC limits, which
+// count every cash-out within domain.KYCLimitWindow.
+func (s *Service) CashOut(ctx context.Context, userID uuid.UUID, amount string) (domain.Wallet, error) {
+	amt, err := decimal.NewFromString(amount)
+	if err != nil {
+		return domain.Wallet{}, domain.ErrInvalidAmount
+	}
+	if !amt.IsPositive() {
+		return domain.Wallet{}, domain.ErrInvalidAmount
+	}
+
+	var result domain.Wallet
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		result, err = s.executeWithdraw(ctx, userID, amt, true)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, domain.ErrOptimisticLock) {
+			return domain.Wallet{}, fmt.Errorf("WalletService.CashOut: %w", err)
+		}
+	}
+
+	return domain.Wallet{}, fmt.Errorf("WalletService.CashOut: max retries exceeded: %w", err)
+}
+
+// executeWithdraw debits the wallet. A cash-out is first checked against
+// the KYC limits in the same transaction, so that two at once cannot both
+// pass: the second fails on the wallet's version and is checked again.
+func (s *Service) executeWithdraw(ctx context.Context, userID uuid.UUID, amount decimal.Decimal, cashOut bool) (domain.Wallet, error) {
 	var result domain.Wallet
 
 	err := postgres.RunInTx(ctx, s.pool, func(tx pgx.Tx) error {
@@ -159,6 +196,18 @@ func (s *Service) executeWithdraw(ctx context.Context, userID uuid.UUID, amount
 			return domain.ErrInsufficientFunds
 		}
 
+		reference := "withdrawal"
+		if cashOut {
+			reference = cashOutReference
+			total, err := txRepo.SumSince(ctx, userID, cashOutReference, time.Now().Add(-domain.KYCLimitWindow))
+			if err != nil {
+				return err
+			}
+			if err := s.limiter.CheckWithdrawal(ctx, userID, amount, total.Neg()); err != nil {
+				return err
+			}
+		}
+
 		newBalance := w.Balance.Sub(amount)
 
 		updated, err := walletRepo.UpdateBalance(ctx, userID, newBalance, w.Version)
@@ -170,7 +219,7 @@ func (s *Service) executeWithdraw(ctx context.Context, userID uuid.UUID, amount
 			WalletID:      userID,
 			Amount:        amount.Neg(),
 			BalanceAfter:  newBalance,
-			ReferenceType: "withdrawal",
+			ReferenceType: reference,
 		})
 		if err != nil {
 			return fmt.Errorf("WalletService.Withdraw create transaction: %w", err)
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS email_verifications;
+
+ALTER TABLE users
+    DROP COLUMN username_changed_at,
+    DROP COLUMN avatar_url;
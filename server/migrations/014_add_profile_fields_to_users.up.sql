@@ -0,0 +1,11 @@
+ALTER TABLE users
+    ADD COLUMN avatar_url          TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN username_changed_at TIMESTAMPTZ;
+
+CREATE TABLE email_verifications (
+    user_id    UUID         NOT NULL PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64)  NOT NULL,
+    expires_at TIMESTAMPTZ  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
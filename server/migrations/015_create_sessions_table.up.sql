@@ -0,0 +1,12 @@
+CREATE TABLE sessions (
+    id           UUID         NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent   VARCHAR(512) NOT NULL DEFAULT '',
+    ip_address   VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS kyc_documents;
+DROP TABLE IF EXISTS kyc_requests;
+
+ALTER TABLE users DROP COLUMN kyc_level;
@@ -0,0 +1,56 @@
+ALTER TABLE users
+    ADD COLUMN kyc_level SMALLINT NOT NULL DEFAULT 0 CHECK (kyc_level BETWEEN 0 AND 2);
+
+CREATE TABLE kyc_requests (
+    id              UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    requested_level SMALLINT    NOT NULL CHECK (requested_level BETWEEN 1 AND 2),
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'info_requested', 'approved', 'rejected')),
+    reviewer_id     UUID        REFERENCES users(id) ON DELETE SET NULL,
+    review_note     TEXT        NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at     TIMESTAMPTZ
+);
+
+CREATE INDEX idx_kyc_requests_user_id ON kyc_requests(user_id);
+CREATE INDEX idx_kyc_requests_status ON kyc_requests(status, created_at);
+
+-- A user has at most one request waiting on either side.
+CREATE UNIQUE INDEX idx_kyc_requests_open ON kyc_requests(user_id)
+    WHERE status IN ('pending', 'info_requested');
+
+CREATE TABLE kyc_documents (
+    id           UUID         NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    request_id   UUID         NOT NULL REFERENCES kyc_requests(id) ON DELETE CASCADE,
+    type         VARCHAR(30)  NOT NULL,
+    url          TEXT         NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size         BIGINT       NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_kyc_documents_request_id ON kyc_documents(request_id);
+
+CREATE TABLE notifications (
+    id         UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(50) NOT NULL,
+    message    TEXT        NOT NULL,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at DESC);
+
+CREATE TABLE audit_logs (
+    id          UUID        NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    actor_id    UUID        REFERENCES users(id) ON DELETE SET NULL,
+    action      VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id   UUID        NOT NULL,
+    details     JSONB       NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id);
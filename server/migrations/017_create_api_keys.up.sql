@@ -0,0 +1,26 @@
+CREATE TABLE api_keys (
+    id           UUID         NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL,
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+
+CREATE TABLE api_key_usage (
+    id         UUID         NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    api_key_id UUID         NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    method     VARCHAR(10)  NOT NULL,
+    path       VARCHAR(255) NOT NULL,
+    ip_address VARCHAR(64)  NOT NULL DEFAULT '',
+    status     INT          NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_key_usage_key_id ON api_key_usage(api_key_id, created_at DESC);
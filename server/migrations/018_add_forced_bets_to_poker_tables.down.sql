@@ -0,0 +1,4 @@
+ALTER TABLE poker_tables
+    DROP COLUMN straddle,
+    DROP COLUMN ante_type,
+    DROP COLUMN ante;
@@ -0,0 +1,4 @@
+ALTER TABLE poker_tables
+    ADD COLUMN ante      DECIMAL(15,4) NOT NULL DEFAULT 0 CHECK (ante >= 0),
+    ADD COLUMN ante_type VARCHAR(20)   NOT NULL DEFAULT 'none' CHECK (ante_type IN ('none', 'per_player', 'big_blind')),
+    ADD COLUMN straddle  VARCHAR(20)   NOT NULL DEFAULT 'none' CHECK (straddle IN ('none', 'utg', 'button'));
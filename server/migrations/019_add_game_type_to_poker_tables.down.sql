@@ -0,0 +1,2 @@
+ALTER TABLE poker_tables
+    DROP COLUMN game_type;
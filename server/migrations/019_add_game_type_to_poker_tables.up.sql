@@ -0,0 +1,2 @@
+ALTER TABLE poker_tables
+    ADD COLUMN game_type VARCHAR(20) NOT NULL DEFAULT 'holdem' CHECK (game_type IN ('holdem', 'plo'));
@@ -0,0 +1,5 @@
+UPDATE poker_tables SET game_type = 'plo' WHERE game_type = 'plo8';
+
+ALTER TABLE poker_tables
+    DROP CONSTRAINT poker_tables_game_type_check,
+    ADD CONSTRAINT poker_tables_game_type_check CHECK (game_type IN ('holdem', 'plo'));
@@ -0,0 +1,3 @@
+ALTER TABLE poker_tables
+    DROP CONSTRAINT poker_tables_game_type_check,
+    ADD CONSTRAINT poker_tables_game_type_check CHECK (game_type IN ('holdem', 'plo', 'plo8'));
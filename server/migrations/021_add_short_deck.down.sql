@@ -0,0 +1,6 @@
+UPDATE poker_tables SET game_type = 'holdem' WHERE game_type = 'short_deck';
+
+ALTER TABLE poker_tables
+    DROP COLUMN blind_type,
+    DROP CONSTRAINT poker_tables_game_type_check,
+    ADD CONSTRAINT poker_tables_game_type_check CHECK (game_type IN ('holdem', 'plo', 'plo8'));
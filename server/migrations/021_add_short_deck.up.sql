@@ -0,0 +1,4 @@
+ALTER TABLE poker_tables
+    DROP CONSTRAINT poker_tables_game_type_check,
+    ADD CONSTRAINT poker_tables_game_type_check CHECK (game_type IN ('holdem', 'plo', 'plo8', 'short_deck')),
+    ADD COLUMN blind_type VARCHAR(20) NOT NULL DEFAULT 'blinds' CHECK (blind_type IN ('blinds', 'ante_only', 'button_blind'));
@@ -0,0 +1,4 @@
+ALTER TABLE poker_tables
+    ADD COLUMN betting_limit VARCHAR(20) NOT NULL DEFAULT 'no_limit' CHECK (betting_limit IN ('no_limit', 'pot_limit', 'fixed_limit'));
+
+UPDATE poker_tables SET betting_limit = 'pot_limit' WHERE game_type IN ('plo', 'plo8');
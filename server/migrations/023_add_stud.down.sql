@@ -0,0 +1,11 @@
+DELETE FROM poker_hands WHERE stage IN ('third_street', 'fourth_street', 'fifth_street', 'sixth_street', 'seventh_street');
+
+ALTER TABLE poker_hands
+    DROP CONSTRAINT poker_hands_stage_check,
+    ADD CONSTRAINT poker_hands_stage_check CHECK (stage IN ('waiting', 'preflop', 'flop', 'turn', 'river', 'showdown', 'complete'));
+
+UPDATE poker_tables SET game_type = 'holdem', betting_limit = 'no_limit' WHERE game_type = 'stud';
+
+ALTER TABLE poker_tables
+    DROP CONSTRAINT poker_tables_game_type_check,
+    ADD CONSTRAINT poker_tables_game_type_check CHECK (game_type IN ('holdem', 'plo', 'plo8', 'short_deck'));
@@ -0,0 +1,5 @@
+DELETE FROM poker_tables WHERE tournament_id IS NOT NULL;
+ALTER TABLE poker_tables DROP COLUMN tournament_id;
+
+DROP TABLE IF EXISTS tournament_entries;
+DROP TABLE IF EXISTS tournaments;
@@ -0,0 +1,36 @@
+CREATE TABLE tournaments (
+    id             UUID          NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    name           VARCHAR(100)  NOT NULL,
+    game_type      VARCHAR(20)   NOT NULL DEFAULT 'holdem',
+    betting_limit  VARCHAR(20)   NOT NULL DEFAULT 'no_limit',
+    buy_in         DECIMAL(15,4) NOT NULL CHECK (buy_in > 0),
+    fee            DECIMAL(15,4) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    starting_chips DECIMAL(15,4) NOT NULL CHECK (starting_chips > 0),
+    max_players    INT           NOT NULL CHECK (max_players >= 2 AND max_players <= 9),
+    levels         JSONB         NOT NULL,
+    level_seconds  INT           NOT NULL CHECK (level_seconds > 0),
+    payouts        JSONB         NOT NULL,
+    status         VARCHAR(20)   NOT NULL DEFAULT 'registering' CHECK (status IN ('registering', 'running', 'finished', 'cancelled')),
+    table_id       UUID          REFERENCES poker_tables(id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    started_at     TIMESTAMPTZ,
+    ended_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_tournaments_status ON tournaments(status);
+
+CREATE TABLE tournament_entries (
+    id            UUID          NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    tournament_id UUID          NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    user_id       UUID          NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username      VARCHAR(100)  NOT NULL,
+    position      INT           NOT NULL DEFAULT 0,
+    prize         DECIMAL(15,4) NOT NULL DEFAULT 0,
+    eliminated_at TIMESTAMPTZ,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT NOW(),
+    UNIQUE(tournament_id, user_id)
+);
+
+CREATE INDEX idx_tournament_entries_tournament_id ON tournament_entries(tournament_id);
+
+ALTER TABLE poker_tables ADD COLUMN tournament_id UUID REFERENCES tournaments(id) ON DELETE CASCADE;
@@ -0,0 +1,22 @@
+DELETE FROM tournament_entries WHERE entry_number > 1;
+
+ALTER TABLE tournament_entries
+    DROP CONSTRAINT tournament_entries_tournament_id_user_id_entry_number_key,
+    DROP COLUMN entry_number,
+    ADD CONSTRAINT tournament_entries_tournament_id_user_id_key UNIQUE (tournament_id, user_id);
+
+DROP INDEX IF EXISTS idx_tournaments_starts_at;
+
+DELETE FROM tournaments WHERE kind = 'scheduled' OR max_players > 9;
+
+ALTER TABLE tournaments
+    DROP COLUMN break_seconds,
+    DROP COLUMN break_every,
+    DROP COLUMN table_size,
+    DROP COLUMN max_re_entries,
+    DROP COLUMN late_reg_levels,
+    DROP COLUMN registration_opens_at,
+    DROP COLUMN starts_at,
+    DROP COLUMN kind,
+    DROP CONSTRAINT tournaments_max_players_check,
+    ADD CONSTRAINT tournaments_max_players_check CHECK (max_players >= 2 AND max_players <= 9);
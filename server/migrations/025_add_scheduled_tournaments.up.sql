@@ -0,0 +1,20 @@
+ALTER TABLE tournaments
+    DROP CONSTRAINT tournaments_max_players_check,
+    ADD CONSTRAINT tournaments_max_players_check CHECK (max_players >= 2),
+    ADD COLUMN kind                  VARCHAR(20) NOT NULL DEFAULT 'sit_and_go' CHECK (kind IN ('sit_and_go', 'scheduled')),
+    ADD COLUMN starts_at             TIMESTAMPTZ,
+    ADD COLUMN registration_opens_at TIMESTAMPTZ,
+    ADD COLUMN late_reg_levels       INT NOT NULL DEFAULT 0 CHECK (late_reg_levels >= 0),
+    ADD COLUMN max_re_entries        INT NOT NULL DEFAULT 0 CHECK (max_re_entries >= 0),
+    ADD COLUMN table_size            INT NOT NULL DEFAULT 9 CHECK (table_size >= 2 AND table_size <= 9),
+    ADD COLUMN break_every           INT NOT NULL DEFAULT 0 CHECK (break_every >= 0),
+    ADD COLUMN break_seconds         INT NOT NULL DEFAULT 0 CHECK (break_seconds >= 0);
+
+UPDATE tournaments SET table_size = max_players;
+
+CREATE INDEX idx_tournaments_starts_at ON tournaments(starts_at) WHERE status = 'registering';
+
+ALTER TABLE tournament_entries
+    ADD COLUMN entry_number INT NOT NULL DEFAULT 1,
+    DROP CONSTRAINT tournament_entries_tournament_id_user_id_key,
+    ADD CONSTRAINT tournament_entries_tournament_id_user_id_entry_number_key UNIQUE (tournament_id, user_id, entry_number);
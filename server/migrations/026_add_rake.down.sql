@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS rake_entries;
+DROP TABLE IF EXISTS house_accounts;
+
+ALTER TABLE poker_hands DROP COLUMN rake;
+
+ALTER TABLE poker_tables
+    DROP COLUMN no_flop_no_drop,
+    DROP COLUMN rake_caps,
+    DROP COLUMN rake_percent;
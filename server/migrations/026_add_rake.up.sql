@@ -0,0 +1,25 @@
+ALTER TABLE poker_tables
+    ADD COLUMN rake_percent    DECIMAL(5,2) NOT NULL DEFAULT 0 CHECK (rake_percent >= 0 AND rake_percent <= 100),
+    ADD COLUMN rake_caps       JSONB        NOT NULL DEFAULT '[]',
+    ADD COLUMN no_flop_no_drop BOOLEAN      NOT NULL DEFAULT FALSE;
+
+ALTER TABLE poker_hands ADD COLUMN rake DECIMAL(15,4) NOT NULL DEFAULT 0;
+
+CREATE TABLE house_accounts (
+    name       VARCHAR(50)   NOT NULL PRIMARY KEY,
+    balance    DECIMAL(20,4) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO house_accounts (name) VALUES ('rake');
+
+CREATE TABLE rake_entries (
+    id         UUID          NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+    hand_id    UUID          NOT NULL UNIQUE REFERENCES poker_hands(id) ON DELETE CASCADE,
+    table_id   UUID          NOT NULL REFERENCES poker_tables(id) ON DELETE CASCADE,
+    amount     DECIMAL(15,4) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rake_entries_created_at ON rake_entries(created_at);
+CREATE INDEX idx_rake_entries_table_id ON rake_entries(table_id);
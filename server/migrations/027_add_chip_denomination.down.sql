@@ -0,0 +1 @@
+ALTER TABLE poker_tables DROP COLUMN chip_denomination;
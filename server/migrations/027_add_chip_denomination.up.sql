@@ -0,0 +1,2 @@
+ALTER TABLE poker_tables
+    ADD COLUMN chip_denomination DECIMAL(15,4) NOT NULL DEFAULT 0.01 CHECK (chip_denomination > 0);
@@ -0,0 +1 @@
+ALTER TABLE poker_hands DROP COLUMN second_board;
@@ -0,0 +1 @@
+ALTER TABLE poker_hands ADD COLUMN second_board TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE poker_hands ADD COLUMN winners JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1 @@
+CREATE INDEX idx_transactions_wallet_type_created ON transactions(wallet_id, reference_type, created_at);
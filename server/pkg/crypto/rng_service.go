@@ -18,8 +18,8 @@ func (s *Service) HashSeed(serverSeed string) string {
 	return HashSeed(serverSeed)
 }
 
-func (s *Service) ShuffleDeck(serverSeed, clientSeed string, nonce int) []domain.Card {
-	return ShuffleDeck(serverSeed, clientSeed, nonce)
+func (s *Service) ShuffleDeck(deck []domain.Card, serverSeed, clientSeed string, nonce int) []domain.Card {
+	return ShuffleDeck(deck, serverSeed, clientSeed, nonce)
 }
 
 func (s *Service) VerifySeed(serverSeed, hash string) bool {
@@ -10,8 +10,8 @@ import (
 	"github.com/jokeoa/goigaming/internal/core/domain"
 )
 
-func ShuffleDeck(serverSeed, clientSeed string, nonce int) []domain.Card {
-	deck := domain.FullDeck()
+func ShuffleDeck(cards []domain.Card, serverSeed, clientSeed string, nonce int) []domain.Card {
+	deck := append([]domain.Card(nil), cards...)
 	gen := newEntropyGenerator(serverSeed, clientSeed, nonce)
 
 	for i := len(deck) - 1; i > 0; i-- {